@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// 密码连续错误多少次后锁定账号
+	LoginFailMaxCount int64 = 5
+	// 统计密码错误次数的时间窗口
+	LoginFailWindow = time.Minute * 10
+	// 账号锁定时长
+	LoginLockTime = time.Minute * 15
+	// RefreshToken的有效期
+	RefreshTokenExpireTime = time.Hour * 24 * 30
+)
+
+func keyLoginFail(accountName string) string {
+	return "loginfail:" + accountName
+}
+
+// 每个RefreshToken一个key,同一个账号可以在多个设备上免密码登录
+func keyRefreshToken(accountId int64, tokenHash string) string {
+	return "rtoken:" + strconv.FormatInt(accountId, 10) + ":" + tokenHash
+}
+
+// 账号是否因为密码错误次数过多而锁定,返回剩余的锁定时间
+func GetLoginLockTime(accountName string) time.Duration {
+	count, err := GetRedis().Get(context.Background(), keyLoginFail(accountName)).Int64()
+	if IsRedisError(err) {
+		slog.Error("GetLoginLockTime error", "accountName", accountName, "error", err)
+		return 0
+	}
+	if count < LoginFailMaxCount {
+		return 0
+	}
+	ttl, err := GetRedis().TTL(context.Background(), keyLoginFail(accountName)).Result()
+	if IsRedisError(err) || ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
+// 记录一次密码错误,返回时间窗口内的错误次数,达到LoginFailMaxCount时锁定账号
+func IncrLoginFail(accountName string) int64 {
+	key := keyLoginFail(accountName)
+	count, err := GetRedis().Incr(context.Background(), key).Result()
+	if IsRedisError(err) {
+		slog.Error("IncrLoginFail error", "accountName", accountName, "error", err)
+		return 0
+	}
+	if count == 1 {
+		GetRedis().Expire(context.Background(), key, LoginFailWindow)
+	} else if count == LoginFailMaxCount {
+		GetRedis().Expire(context.Background(), key, LoginLockTime)
+		slog.Warn("AccountLocked", "accountName", accountName, "lockTime", LoginLockTime)
+	}
+	return count
+}
+
+// 登录成功,清除密码错误次数
+func ClearLoginFail(accountName string) {
+	err := GetRedis().Del(context.Background(), keyLoginFail(accountName)).Err()
+	if IsRedisError(err) {
+		slog.Error("ClearLoginFail error", "accountName", accountName, "error", err)
+	}
+}
+
+// 新生成一个RefreshToken,格式: 账号id.随机串
+//
+//	redis里只存随机串的hash,redis数据泄露也无法用来登录
+func NewRefreshToken(accountId int64) string {
+	random := generateRandomSession()
+	err := GetRedis().SetEx(context.Background(), keyRefreshToken(accountId, hashRefreshToken(random)), 1, RefreshTokenExpireTime).Err()
+	if IsRedisError(err) {
+		slog.Error("NewRefreshToken error", "accountId", accountId, "error", err)
+		return ""
+	}
+	return strconv.FormatInt(accountId, 10) + "." + random
+}
+
+// 验证并消耗RefreshToken,每个RefreshToken只能使用一次
+func UseRefreshToken(accountId int64, token string) bool {
+	accountIdStr, random, ok := strings.Cut(token, ".")
+	if !ok || random == "" || accountIdStr != strconv.FormatInt(accountId, 10) {
+		return false
+	}
+	count, err := GetRedis().Del(context.Background(), keyRefreshToken(accountId, hashRefreshToken(random))).Result()
+	if IsRedisError(err) {
+		slog.Error("UseRefreshToken error", "accountId", accountId, "error", err)
+		return false
+	}
+	return count == 1
+}
+
+func hashRefreshToken(random string) string {
+	sum := sha256.Sum256([]byte(random))
+	return hex.EncodeToString(sum[:])
+}
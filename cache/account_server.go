@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// 账号最近分配的游戏服的保留时间
+var AccountLastServerExpire = 7 * 24 * time.Hour
+
+func keyAccountLastServer(accountId int64) string {
+	return "accountserver:" + strconv.FormatInt(accountId, 10)
+}
+
+// 记录账号最近分配的游戏服,登录服优先把账号分配到该游戏服(粘性分配)
+func SetAccountLastServer(accountId int64, gameServerId int32) bool {
+	err := GetRedis().Set(context.Background(), keyAccountLastServer(accountId), gameServerId, AccountLastServerExpire).Err()
+	if IsRedisError(err) {
+		slog.Error("SetAccountLastServer error", "accountId", accountId, "gameServerId", gameServerId, "error", err)
+		return false
+	}
+	return true
+}
+
+// 获取账号最近分配的游戏服,返回0表示没有记录
+func GetAccountLastServer(accountId int64) int32 {
+	gameServerId, err := GetRedis().Get(context.Background(), keyAccountLastServer(accountId)).Int()
+	if IsRedisError(err) {
+		slog.Error("GetAccountLastServer error", "accountId", accountId, "error", err)
+		return 0
+	}
+	return int32(gameServerId)
+}
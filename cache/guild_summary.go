@@ -0,0 +1,166 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/fish-tennis/gserver/pb"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+// 公会摘要缓存
+//
+//	公会列表和公会搜索直接读缓存,不访问mongodb
+//	guild:{list}:info HASH guildId -> GuildInfo(proto序列化)
+//	guild:{list}:name ZSET 所有score都是0,member是"小写公会名:公会id",利用ZRANGEBYLEX实现公会名前缀搜索
+//	使用hash tag保证2个key在redis集群的同一个slot,才能使用事务
+
+func keyGuildSummary() string {
+	return "guild:{list}:info"
+}
+
+func keyGuildNameIndex() string {
+	return "guild:{list}:name"
+}
+
+// 公会名索引的member,同时也作为公会搜索的游标
+func GuildNameIndexMember(guildInfo *pb.GuildInfo) string {
+	return strings.ToLower(guildInfo.GetName()) + ":" + strconv.FormatInt(guildInfo.GetId(), 10)
+}
+
+// 更新公会摘要
+func UpdateGuildSummary(guildInfo *pb.GuildInfo) bool {
+	data, err := proto.Marshal(guildInfo)
+	if err != nil {
+		slog.Error("UpdateGuildSummary marshal error", "guildId", guildInfo.GetId(), "error", err)
+		return false
+	}
+	_, err = GetRedis().TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.HSet(context.Background(), keyGuildSummary(), strconv.FormatInt(guildInfo.GetId(), 10), data)
+		pipe.ZAdd(context.Background(), keyGuildNameIndex(), redis.Z{
+			Member: GuildNameIndexMember(guildInfo),
+		})
+		return nil
+	})
+	if IsRedisError(err) {
+		slog.Error("UpdateGuildSummary error", "guildId", guildInfo.GetId(), "error", err)
+		return false
+	}
+	return true
+}
+
+// 删除公会摘要
+func RemoveGuildSummary(guildInfo *pb.GuildInfo) bool {
+	_, err := GetRedis().TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.HDel(context.Background(), keyGuildSummary(), strconv.FormatInt(guildInfo.GetId(), 10))
+		pipe.ZRem(context.Background(), keyGuildNameIndex(), GuildNameIndexMember(guildInfo))
+		return nil
+	})
+	if IsRedisError(err) {
+		slog.Error("RemoveGuildSummary error", "guildId", guildInfo.GetId(), "error", err)
+		return false
+	}
+	return true
+}
+
+// 缓存的公会数量
+func GetGuildSummaryCount() (int64, error) {
+	count, err := GetRedis().ZCard(context.Background(), keyGuildNameIndex()).Result()
+	if IsRedisError(err) {
+		slog.Error("GetGuildSummaryCount error", "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// 按公会名排序分页获取公会摘要
+//
+//	pageIndex从0开始
+func GetGuildSummaryPage(pageIndex, pageSize int32) ([]*pb.GuildInfo, error) {
+	start := int64(pageIndex) * int64(pageSize)
+	members, err := GetRedis().ZRange(context.Background(), keyGuildNameIndex(), start, start+int64(pageSize)-1).Result()
+	if IsRedisError(err) {
+		slog.Error("GetGuildSummaryPage error", "error", err)
+		return nil, err
+	}
+	return getGuildSummaries(members)
+}
+
+// 按公会名前缀搜索公会摘要
+//
+//	cursor为空表示从头开始,否则从cursor之后开始(不包含cursor)
+//	返回的lastMember是本次扫描到的最后一个索引,包括摘要已删除的残留索引,用作下次扫描的游标
+//	返回的hasMore表示是否还有更多数据
+func ScanGuildSummaries(namePrefix string, cursor string, count int32) (guildInfos []*pb.GuildInfo, lastMember string, hasMore bool, err error) {
+	namePrefix = strings.ToLower(namePrefix)
+	rangeBy := &redis.ZRangeBy{
+		Min:   "-",
+		Max:   "+",
+		Count: int64(count) + 1, // 多取一个,用于判断是否还有更多数据
+	}
+	if namePrefix != "" {
+		rangeBy.Min = "[" + namePrefix
+		rangeBy.Max = "[" + namePrefix + "\xff"
+	}
+	if cursor != "" {
+		// 游标必须在前缀范围内,防止客户端传入任意游标
+		if namePrefix != "" && !strings.HasPrefix(cursor, namePrefix) {
+			return nil, "", false, nil
+		}
+		rangeBy.Min = "(" + cursor
+	}
+	members, err := GetRedis().ZRangeByLex(context.Background(), keyGuildNameIndex(), rangeBy).Result()
+	if IsRedisError(err) {
+		slog.Error("ScanGuildSummaries error", "namePrefix", namePrefix, "error", err)
+		return nil, "", false, err
+	}
+	if len(members) > int(count) {
+		hasMore = true
+		members = members[:count]
+	}
+	if len(members) > 0 {
+		lastMember = members[len(members)-1]
+	}
+	guildInfos, err = getGuildSummaries(members)
+	return guildInfos, lastMember, hasMore, err
+}
+
+// 根据公会名索引批量获取公会摘要,索引残留(摘要已删除)的公会会被跳过
+func getGuildSummaries(members []string) ([]*pb.GuildInfo, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+	fields := make([]string, 0, len(members))
+	for _, member := range members {
+		idx := strings.LastIndexByte(member, ':')
+		if idx < 0 {
+			continue
+		}
+		fields = append(fields, member[idx+1:])
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	values, err := GetRedis().HMGet(context.Background(), keyGuildSummary(), fields...).Result()
+	if IsRedisError(err) {
+		slog.Error("getGuildSummaries error", "error", err)
+		return nil, err
+	}
+	guildInfos := make([]*pb.GuildInfo, 0, len(values))
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+		guildInfo := &pb.GuildInfo{}
+		if err := proto.Unmarshal([]byte(str), guildInfo); err != nil {
+			slog.Error("getGuildSummaries unmarshal error", "guildId", fields[i], "error", err)
+			continue
+		}
+		guildInfos = append(guildInfos, guildInfo)
+	}
+	return guildInfos, nil
+}
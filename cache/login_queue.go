@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 登录排队
+//
+//	所有游戏服都满员时,登录服把账号放进排队队列,多个登录服共用一个队列
+//	loginqueue:{q}:list ZSET member是"登录服id:账号id",score是入队序号,实现先进先出
+//	loginqueue:{q}:seq 入队序号
+//	loginqueue:{q}:admitted 累计出队(排到)的人数,用于估算排队速度
+//	使用hash tag保证这几个key在redis集群的同一个slot
+
+func keyLoginQueue() string {
+	return "loginqueue:{q}:list"
+}
+
+func keyLoginQueueSeq() string {
+	return "loginqueue:{q}:seq"
+}
+
+func keyLoginQueueAdmitted() string {
+	return "loginqueue:{q}:admitted"
+}
+
+// 排队队列的member
+func LoginQueueMember(loginServerId int32, accountId int64) string {
+	return strconv.FormatInt(int64(loginServerId), 10) + ":" + strconv.FormatInt(accountId, 10)
+}
+
+// 解析排队队列的member
+func ParseLoginQueueMember(member string) (loginServerId int32, accountId int64) {
+	loginServerIdStr, accountIdStr, ok := strings.Cut(member, ":")
+	if !ok {
+		return
+	}
+	serverId, _ := strconv.ParseInt(loginServerIdStr, 10, 32)
+	loginServerId = int32(serverId)
+	accountId, _ = strconv.ParseInt(accountIdStr, 10, 64)
+	return
+}
+
+// 加入排队队列,已经在队列中的话,保持原来的位置
+func JoinLoginQueue(member string) bool {
+	_, err := GetRedis().ZScore(context.Background(), keyLoginQueue(), member).Result()
+	if err == nil {
+		return true
+	}
+	if IsRedisError(err) {
+		slog.Error("JoinLoginQueue error", "member", member, "error", err)
+		return false
+	}
+	seq, err := GetRedis().Incr(context.Background(), keyLoginQueueSeq()).Result()
+	if IsRedisError(err) {
+		slog.Error("JoinLoginQueue error", "member", member, "error", err)
+		return false
+	}
+	err = GetRedis().ZAddNX(context.Background(), keyLoginQueue(), redis.Z{
+		Score:  float64(seq),
+		Member: member,
+	}).Err()
+	if IsRedisError(err) {
+		slog.Error("JoinLoginQueue error", "member", member, "error", err)
+		return false
+	}
+	return true
+}
+
+// 移出排队队列
+func RemoveLoginQueue(members ...string) bool {
+	if len(members) == 0 {
+		return true
+	}
+	args := make([]any, len(members))
+	for i, member := range members {
+		args[i] = member
+	}
+	err := GetRedis().ZRem(context.Background(), keyLoginQueue(), args...).Err()
+	if IsRedisError(err) {
+		slog.Error("RemoveLoginQueue error", "error", err)
+		return false
+	}
+	return true
+}
+
+// 排队人数
+func GetLoginQueueLength() (int64, error) {
+	count, err := GetRedis().ZCard(context.Background(), keyLoginQueue()).Result()
+	if IsRedisError(err) {
+		slog.Error("GetLoginQueueLength error", "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// 获取排在前面的count个member
+func GetLoginQueueHead(count int64) ([]string, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	members, err := GetRedis().ZRange(context.Background(), keyLoginQueue(), 0, count-1).Result()
+	if IsRedisError(err) {
+		slog.Error("GetLoginQueueHead error", "error", err)
+		return nil, err
+	}
+	return members, nil
+}
+
+// 批量获取排队位置(从1开始),不在队列中的返回0
+func GetLoginQueuePositions(members []string) ([]int64, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+	cmds, err := GetRedis().Pipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		for _, member := range members {
+			pipe.ZRank(context.Background(), keyLoginQueue(), member)
+		}
+		return nil
+	})
+	if IsRedisError(err) {
+		slog.Error("GetLoginQueuePositions error", "error", err)
+		return nil, err
+	}
+	positions := make([]int64, len(members))
+	for i, cmd := range cmds {
+		rank, rankErr := cmd.(*redis.IntCmd).Result()
+		if rankErr == nil {
+			positions[i] = rank + 1
+		}
+	}
+	return positions, nil
+}
+
+// 增加累计出队的人数
+func IncrLoginQueueAdmitted(count int64) {
+	err := GetRedis().IncrBy(context.Background(), keyLoginQueueAdmitted(), count).Err()
+	if IsRedisError(err) {
+		slog.Error("IncrLoginQueueAdmitted error", "error", err)
+	}
+}
+
+// 累计出队的人数
+func GetLoginQueueAdmitted() int64 {
+	count, err := GetRedis().Get(context.Background(), keyLoginQueueAdmitted()).Int64()
+	if IsRedisError(err) {
+		slog.Error("GetLoginQueueAdmitted error", "error", err)
+	}
+	return count
+}
@@ -2,6 +2,7 @@ package cfg
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,9 +20,11 @@ import (
 )
 
 var (
-	// 配置数据文件扩展名,默认为".json",可设置为".pb"以加载protobuf格式
+	// 配置数据文件扩展名,默认为".json",可设置为".pb",".csv",".yaml"以加载对应的格式
 	// 在调用Load之前设置此变量来控制加载格式
 	DataFileExt = ".json"
+
+	ErrUnsupportedFileType = errors.New("unsupported file type")
 )
 
 // map类型的配置数据管理
@@ -47,18 +50,31 @@ func (this *DataMap[E]) Range(f func(e E) bool) {
 	}
 }
 
+// 深拷贝
+func (this *DataMap[E]) Clone() *DataMap[E] {
+	clone := NewDataMap[E]()
+	for cfgId, cfg := range this.Elems {
+		clone.Elems[cfgId] = cloneElem(cfg)
+	}
+	return clone
+}
+
 // 加载配置数据
 func (this *DataMap[E]) Load(fileName string) error {
 	if this.Elems == nil {
 		this.Elems = make(map[int32]E)
 	}
-	if strings.HasSuffix(fileName, ".json") {
+	switch dataFileType(fileName) {
+	case ".json":
 		return this.LoadJson(fileName)
-	}
-	if strings.HasSuffix(fileName, ".pb") {
+	case ".pb":
 		return this.LoadPb(fileName)
+	case ".csv":
+		return this.LoadCsv(fileName)
+	case ".yaml":
+		return this.LoadYaml(fileName)
 	}
-	return errors.New("unsupported file type")
+	return ErrUnsupportedFileType
 }
 
 // 从json文件加载数据
@@ -79,6 +95,51 @@ func (this *DataMap[E]) LoadJson(fileName string) error {
 	return nil
 }
 
+// 从csv文件加载数据
+func (this *DataMap[E]) LoadCsv(fileName string) error {
+	cfgList, err := loadCsv[E](fileName)
+	if err != nil {
+		slog.Error("LoadCsvErr", "fileName", fileName, "err", err)
+		return err
+	}
+	cfgMap := make(map[int32]E, len(cfgList))
+	for _, cfg := range cfgList {
+		if _, ok := cfgMap[cfg.GetCfgId()]; ok {
+			slog.Error("duplicate id", "fileName", fileName, "id", cfg.GetCfgId())
+		}
+		cfgMap[cfg.GetCfgId()] = cfg
+	}
+	this.Elems = cfgMap
+	slog.Info("LoadCsv", "fileName", fileName, "count", len(this.Elems))
+	return nil
+}
+
+// 从yaml文件加载数据
+func (this *DataMap[E]) LoadYaml(fileName string) error {
+	cfgMap := make(map[int32]E)
+	if err := loadYaml(fileName, &cfgMap); err != nil {
+		slog.Error("LoadYamlErr", "fileName", fileName, "err", err)
+		return err
+	}
+	this.Elems = cfgMap
+	slog.Info("LoadYaml", "fileName", fileName, "count", len(this.Elems))
+	return nil
+}
+
+// 保存配置数据,格式由文件扩展名决定,配置项按id排序
+func (this *DataMap[E]) Save(fileName string) error {
+	ids := make([]int32, 0, len(this.Elems))
+	for id := range this.Elems {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	cfgList := make([]E, 0, len(ids))
+	for _, id := range ids {
+		cfgList = append(cfgList, this.Elems[id])
+	}
+	return saveDataFile(fileName, this.Elems, cfgList)
+}
+
 // 创建索引
 func (this *DataMap[E]) CreateIndexInt32(indexFn func(e E) int32) map[int32]*DataMap[E] {
 	indexMap := make(map[int32]*DataMap[E])
@@ -175,12 +236,29 @@ func (m *StrDataMap[E]) Range(f func(e E) bool) {
 	}
 }
 
-// 加载配置数据,支持json和csv
+// 深拷贝
+func (m *StrDataMap[E]) Clone() *StrDataMap[E] {
+	clone := NewStrDataMap[E]()
+	for cfgId, cfg := range m.Elems {
+		clone.Elems[cfgId] = cloneElem(cfg)
+	}
+	return clone
+}
+
+// 加载配置数据,支持json,csv和yaml
 func (m *StrDataMap[E]) Load(fileName string) error {
 	if m.Elems == nil {
 		m.Elems = make(map[string]E)
 	}
-	return m.LoadJson(fileName)
+	switch dataFileType(fileName) {
+	case ".json":
+		return m.LoadJson(fileName)
+	case ".csv":
+		return m.LoadCsv(fileName)
+	case ".yaml":
+		return m.LoadYaml(fileName)
+	}
+	return ErrUnsupportedFileType
 }
 
 // 从json文件加载数据
@@ -201,6 +279,51 @@ func (m *StrDataMap[E]) LoadJson(fileName string) error {
 	return nil
 }
 
+// 从csv文件加载数据
+func (m *StrDataMap[E]) LoadCsv(fileName string) error {
+	cfgList, err := loadCsv[E](fileName)
+	if err != nil {
+		slog.Error("LoadCsvErr", "fileName", fileName, "err", err)
+		return err
+	}
+	cfgMap := make(map[string]E, len(cfgList))
+	for _, cfg := range cfgList {
+		if _, ok := cfgMap[cfg.GetCfgId()]; ok {
+			slog.Error("duplicate id", "fileName", fileName, "id", cfg.GetCfgId())
+		}
+		cfgMap[cfg.GetCfgId()] = cfg
+	}
+	m.Elems = cfgMap
+	slog.Info("LoadCsv", "fileName", fileName, "count", len(m.Elems))
+	return nil
+}
+
+// 从yaml文件加载数据
+func (m *StrDataMap[E]) LoadYaml(fileName string) error {
+	cfgMap := make(map[string]E)
+	if err := loadYaml(fileName, &cfgMap); err != nil {
+		slog.Error("LoadYamlErr", "fileName", fileName, "err", err)
+		return err
+	}
+	m.Elems = cfgMap
+	slog.Info("LoadYaml", "fileName", fileName, "count", len(m.Elems))
+	return nil
+}
+
+// 保存配置数据,格式由文件扩展名决定,配置项按id排序
+func (m *StrDataMap[E]) Save(fileName string) error {
+	ids := make([]string, 0, len(m.Elems))
+	for id := range m.Elems {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	cfgList := make([]E, 0, len(ids))
+	for _, id := range ids {
+		cfgList = append(cfgList, m.Elems[id])
+	}
+	return saveDataFile(fileName, m.Elems, cfgList)
+}
+
 // 创建子集
 func (m *StrDataMap[E]) CreateSubset(filter func(e E) bool) *StrDataMap[E] {
 	subMap := NewStrDataMap[E]()
@@ -247,15 +370,30 @@ func (this *DataSlice[E]) Range(f func(e E) bool) {
 	}
 }
 
+// 深拷贝
+func (this *DataSlice[E]) Clone() *DataSlice[E] {
+	clone := &DataSlice[E]{
+		Elems: make([]E, len(this.Elems)),
+	}
+	for i, cfg := range this.Elems {
+		clone.Elems[i] = cloneElem(cfg)
+	}
+	return clone
+}
+
 // 加载配置数据
 func (this *DataSlice[E]) Load(fileName string) error {
-	if strings.HasSuffix(fileName, ".json") {
+	switch dataFileType(fileName) {
+	case ".json":
 		return this.LoadJson(fileName)
-	}
-	if strings.HasSuffix(fileName, ".pb") {
+	case ".pb":
 		return this.LoadPb(fileName)
+	case ".csv":
+		return this.LoadCsv(fileName)
+	case ".yaml":
+		return this.LoadYaml(fileName)
 	}
-	return errors.New("unsupported file type")
+	return ErrUnsupportedFileType
 }
 
 // 从json文件加载数据
@@ -313,6 +451,37 @@ func (this *DataSlice[E]) LoadPb(fileName string) error {
 	return nil
 }
 
+// 从csv文件加载数据
+func (this *DataSlice[E]) LoadCsv(fileName string) error {
+	cfgList, err := loadCsv[E](fileName)
+	if err != nil {
+		slog.Error("LoadCsvErr", "fileName", fileName, "err", err)
+		return err
+	}
+	this.Elems = cfgList
+	slog.Info("LoadCsv", "fileName", fileName, "count", len(this.Elems))
+	this.checkDuplicateCfgId(fileName)
+	return nil
+}
+
+// 从yaml文件加载数据
+func (this *DataSlice[E]) LoadYaml(fileName string) error {
+	var cfgList []E
+	if err := loadYaml(fileName, &cfgList); err != nil {
+		slog.Error("LoadYamlErr", "fileName", fileName, "err", err)
+		return err
+	}
+	this.Elems = cfgList
+	slog.Info("LoadYaml", "fileName", fileName, "count", len(this.Elems))
+	this.checkDuplicateCfgId(fileName)
+	return nil
+}
+
+// 保存配置数据,格式由文件扩展名决定
+func (this *DataSlice[E]) Save(fileName string) error {
+	return saveDataFile(fileName, this.Elems, this.Elems)
+}
+
 // 如果配置项是CfgData,检查id是否重复
 func (this *DataSlice[E]) checkDuplicateCfgId(fileName string) {
 	for i := 0; i < len(this.Elems); i++ {
@@ -357,6 +526,47 @@ func LoadObjectFromPb(fileName string, obj proto.Message) error {
 	return nil
 }
 
+// 数据文件的格式,.yml和.yaml都作为yaml格式
+func dataFileType(fileName string) string {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext == ".yml" {
+		return ".yaml"
+	}
+	return ext
+}
+
+// 保存配置数据
+//
+//	data: json和yaml格式保存的数据,和加载时的结构一致
+//	cfgList: csv和pb格式按顺序保存的配置项
+func saveDataFile[E any](fileName string, data any, cfgList []E) error {
+	switch dataFileType(fileName) {
+	case ".json":
+		fileData, err := json.MarshalIndent(data, "", "\t")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(fileName, fileData, 0644)
+	case ".pb":
+		buffer := new(bytes.Buffer)
+		for _, cfg := range cfgList {
+			msg, ok := any(cfg).(proto.Message)
+			if !ok {
+				return fmt.Errorf("type %T does not implement proto.Message", cfg)
+			}
+			if _, err := protodelim.MarshalTo(buffer, msg); err != nil {
+				return err
+			}
+		}
+		return os.WriteFile(fileName, buffer.Bytes(), 0644)
+	case ".csv":
+		return saveCsv(fileName, cfgList)
+	case ".yaml":
+		return saveYaml(fileName, data)
+	}
+	return ErrUnsupportedFileType
+}
+
 func ResolveDataFile(fileName string) string {
 	return EnsureDataFileExt(fileName, DataFileExt)
 }
@@ -388,6 +598,14 @@ func newElement[E any]() (E, error) {
 	return elem, nil
 }
 
+// 拷贝配置对象,proto对象深拷贝,其他类型直接复制
+func cloneElem[E any](e E) E {
+	if message, ok := any(e).(proto.Message); ok {
+		return proto.Clone(message).(E)
+	}
+	return e
+}
+
 type loadable interface {
 	Load(filename string) error
 }
@@ -424,9 +642,22 @@ func LoadObjectConfig[T proto.Message](filter func(string) bool, fileName, dataD
 	return nil
 }
 
-func Process[T any](fn func(T) error, data T) error {
-	if fn != nil {
-		return fn(data)
+// 预处理
+//
+//	预处理可能会读取其他表(如条件模板表),所以每次加载都要重新预处理,防止其他表重新加载后生成的数据过期
+//	数据和上一个快照相同(没有重新加载)时,先拷贝一份再预处理,防止修改正在使用的数据
+func Process[T comparable](fn func(*Snapshot, T) error, s *Snapshot, data *T, oldData T) error {
+	var zero T
+	if fn == nil || *data == zero {
+		return nil
 	}
-	return nil
+	if *data == oldData {
+		switch v := any(*data).(type) {
+		case interface{ Clone() T }:
+			*data = v.Clone()
+		case proto.Message:
+			*data = proto.Clone(v).(T)
+		}
+	}
+	return fn(s, *data)
 }
@@ -1,8 +1,13 @@
 package cfg
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/fish-tennis/gserver/pb"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 )
@@ -13,17 +18,243 @@ func TestCfgLoad(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	Quests.Range(func(e *pb.QuestCfg) bool {
+	Get().Quests.Range(func(e *pb.QuestCfg) bool {
 		slog.Info("QuestCfg", "CfgId", e.CfgId, "Conditions", e.Conditions, "Progress", e.Progress, "Properties", e.Properties)
 		return true
 	})
-	ActivityCfgs.Range(func(e *pb.ActivityCfg) bool {
+	Get().ActivityCfgs.Range(func(e *pb.ActivityCfg) bool {
 		slog.Info("ActivityCfg", "CfgId", e.CfgId, "QuestIds", e.QuestIds, "ExchangeIds", e.ExchangeIds)
 		return true
 	})
-	ConditionTemplateCfgs.Range(func(e *pb.ConditionTemplateCfg) bool {
+	Get().ConditionTemplateCfgs.Range(func(e *pb.ConditionTemplateCfg) bool {
 		slog.Info("ConditionTemplateCfg", "CfgId", e.CfgId, "Conditions", e)
 		return true
 	})
 	time.Sleep(time.Second)
 }
+
+func TestCfgReload(t *testing.T) {
+	dir := "./../cfgdata/"
+	if err := Load(dir, nil); err != nil {
+		t.Fatal(err)
+	}
+	old := Get()
+	if err := Load(dir, nil); err != nil {
+		t.Fatal(err)
+	}
+	s := Get()
+	if s == old || s.Quests == old.Quests || len(s.Quests.Elems) != len(old.Quests.Elems) {
+		t.Fatal("snapshot not swapped")
+	}
+	// 数据文件没有变化,版本号也不变
+	if s.Version == 0 || s.Version != old.Version {
+		t.Fatalf("version changed old:%v new:%v", old.Version, s.Version)
+	}
+	// 旧的快照不受影响
+	if old.Quests.GetCfg(1) == nil || len(old.QuestsByLevel) != len(s.QuestsByLevel) {
+		t.Fatal("old snapshot changed")
+	}
+	// 加载失败时不替换
+	if err := Load("./not_exist_dir/", nil); err == nil {
+		t.Fatal("load not exist dir")
+	}
+	if Get() != s {
+		t.Fatal("snapshot swapped after load error")
+	}
+	// 过滤掉的文件沿用当前快照里的数据
+	if err := Load(dir, func(fileName string) bool {
+		return fileName == "ItemCfg.json"
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if Get().ItemCfgs == s.ItemCfgs || Get().ShopCfgs != s.ShopCfgs {
+		t.Fatal("filter error")
+	}
+	if Get().Version != s.Version {
+		t.Fatalf("filter version changed old:%v new:%v", s.Version, Get().Version)
+	}
+	// 有预处理的表,拷贝一份重新预处理,不修改旧快照里的数据
+	if Get().Quests == s.Quests || Get().Quests.GetCfg(1) == s.Quests.GetCfg(1) || Get().QuestsDay == s.QuestsDay {
+		t.Fatal("processed table not copied")
+	}
+	if len(Get().Quests.GetCfg(1).GetConditions()) != len(s.Quests.GetCfg(1).GetConditions()) {
+		t.Fatal("processed table data error")
+	}
+}
+
+func TestCfgValidate(t *testing.T) {
+	if err := Load("./../cfgdata/", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(Get()); err != nil {
+		t.Fatal(err)
+	}
+	// 构造错误的关联数据
+	s := *Get()
+	s.Quests = NewDataMap[*pb.QuestCfg]()
+	s.Quests.Elems[1] = &pb.QuestCfg{CfgId: 1, NextQuests: []int32{99999}}
+	s.ExchangeCfgs = NewDataMap[*pb.ExchangeCfg]()
+	s.ExchangeCfgs.Elems[2] = &pb.ExchangeCfg{CfgId: 2, Rewards: []*pb.AddElemArg{{CfgId: 99999, Num: 1}}}
+	s.ExchangeCfgs.Elems[6] = &pb.ExchangeCfg{CfgId: 6, GuildConditions: []*pb.ConditionCfg{
+		{Type: int32(pb.ConditionType_ConditionType_GuildPropertyCompare), Key: "NotExists"},
+	}}
+	s.ActivityCfgs = NewDataMap[*pb.ActivityCfg]()
+	s.ActivityCfgs.Elems[3] = &pb.ActivityCfg{CfgId: 3, QuestIds: []int32{1}}
+	s.ProgressTemplateCfgs = NewDataMap[*pb.ProgressTemplateCfg]()
+	s.ProgressTemplateCfgs.Elems[4] = &pb.ProgressTemplateCfg{CfgId: 4, Type: int32(pb.ProgressType_ProgressType_Event), Event: "EventNotExists"}
+	s.ConditionTemplateCfgs = NewDataMap[*pb.ConditionTemplateCfg]()
+	s.ConditionTemplateCfgs.Elems[5] = &pb.ConditionTemplateCfg{CfgId: 5, Type: 99999}
+	err := Validate(&s)
+	var cfgErrs CfgErrors
+	if !errors.As(err, &cfgErrs) {
+		t.Fatalf("err:%v", err)
+	}
+	expected := map[string]int32{
+		"ActivityCfgs":          3,
+		"ConditionTemplateCfgs": 5,
+		"ExchangeCfgs":          2,
+		"ProgressTemplateCfgs":  4,
+		"Quests":                1,
+	}
+	for table, cfgId := range expected {
+		if !slices.ContainsFunc(cfgErrs, func(e *CfgError) bool {
+			return e.Table == table && e.CfgId == cfgId
+		}) {
+			t.Errorf("missing error table:%v cfgId:%v errs:%v", table, cfgId, err)
+		}
+	}
+	if !slices.ContainsFunc(cfgErrs, func(e *CfgError) bool {
+		return e.Table == "ExchangeCfgs" && e.CfgId == 6
+	}) {
+		t.Errorf("missing GuildConditions error errs:%v", err)
+	}
+	t.Log(err)
+}
+
+type savable interface {
+	loadable
+	Save(fileName string) error
+}
+
+// cfgdata里的所有配置表
+var testTables = []struct {
+	fileName string
+	newFn    func() savable
+}{
+	{"ItemCfg.json", func() savable { return NewDataMap[*pb.ItemCfg]() }},
+	{"condition_template.json", func() savable { return NewDataMap[*pb.ConditionTemplateCfg]() }},
+	{"progress_template.json", func() savable { return NewDataMap[*pb.ProgressTemplateCfg]() }},
+	{"levelcfg.json", func() savable { return &DataSlice[*pb.LevelExp]{} }},
+	{"exchange.json", func() savable { return NewDataMap[*pb.ExchangeCfg]() }},
+	{"Quests.json", func() savable { return NewDataMap[*pb.QuestCfg]() }},
+	{"ShopCfg.json", func() savable { return NewDataMap[*pb.ShopCfg]() }},
+	{"activitycfg.json", func() savable { return NewDataMap[*pb.ActivityCfg]() }},
+	{"rankcfg.json", func() savable { return NewDataMap[*pb.RankCfg]() }},
+	{"rankrewardcfg.json", func() savable { return NewDataMap[*pb.RankRewardCfg]() }},
+	{"guildlevelcfg.json", func() savable { return &DataSlice[*pb.LevelExp]{} }},
+	{"guilddonatecfg.json", func() savable { return NewDataMap[*pb.GuildDonateCfg]() }},
+}
+
+// json转换成csv和yaml后再加载,数据保持一致
+func TestCfgFormatRoundTrip(t *testing.T) {
+	dir := "./../cfgdata/"
+	// 检查是否覆盖了所有配置表
+	var fileNames []string
+	if _, err := LoadSnapshot(dir, func(fileName string) bool {
+		fileNames = append(fileNames, fileName)
+		return false
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, fileName := range fileNames {
+		if !slices.ContainsFunc(testTables, func(table struct {
+			fileName string
+			newFn    func() savable
+		}) bool {
+			return table.fileName == fileName
+		}) {
+			t.Errorf("table %v not tested", fileName)
+		}
+	}
+	defer func(ext string) {
+		DataFileExt = ext
+	}(DataFileExt)
+	DataFileExt = ".json"
+	jsonSnapshot, err := LoadSnapshot(dir, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ext := range []string{".csv", ".yaml", ".yml", ".pb", ".json"} {
+		tmpDir := t.TempDir()
+		for _, table := range testTables {
+			src := table.newFn()
+			if err = src.Load(dir + table.fileName); err != nil {
+				t.Fatal(err)
+			}
+			tmpFileName := filepath.Join(tmpDir, EnsureDataFileExt(table.fileName, ext))
+			if err = src.Save(tmpFileName); err != nil {
+				t.Fatalf("%v err:%v", tmpFileName, err)
+			}
+			dst := table.newFn()
+			if err = dst.Load(tmpFileName); err != nil {
+				t.Fatalf("%v err:%v", tmpFileName, err)
+			}
+			srcData, _ := json.Marshal(src)
+			dstData, _ := json.Marshal(dst)
+			if string(srcData) != string(dstData) {
+				t.Errorf("%v not equal\nsrc:%s\ndst:%s", tmpFileName, srcData, dstData)
+			}
+		}
+		// 通过DataFileExt选择格式,预处理后的数据也保持一致
+		DataFileExt = ext
+		s, loadErr := LoadSnapshot(tmpDir, nil, nil)
+		if loadErr != nil {
+			t.Fatalf("%v err:%v", ext, loadErr)
+		}
+		if err = Validate(s); err != nil {
+			t.Fatalf("%v err:%v", ext, err)
+		}
+		if len(s.QuestsByLevel) != len(jsonSnapshot.QuestsByLevel) || s.GetNeedExp(2) != jsonSnapshot.GetNeedExp(2) {
+			t.Errorf("%v process err", ext)
+		}
+		DataFileExt = ".json"
+	}
+}
+
+// excel导出的csv格式:字段选项,注释行,注释列,枚举名
+func TestCfgLoadCsv(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "exchange.csv")
+	csvData := "CfgId,#Comment,\"Rewards\n#Field=Num_CfgId#Ref=ItemCfg\",ConditionTemplates,Detail\n" +
+		"#id,comment,reward,condition,detail\n" +
+		"1,c1,\"2_1\n5_2\",1_5;2__3,\"a,b_c;d\"\n" +
+		",,,,\n" +
+		"2,c2,1_3,,\n"
+	if err := os.WriteFile(fileName, []byte(csvData), 0644); err != nil {
+		t.Fatal(err)
+	}
+	exchanges := NewDataMap[*pb.ExchangeCfg]()
+	if err := exchanges.Load(fileName); err != nil {
+		t.Fatal(err)
+	}
+	if len(exchanges.Elems) != 2 {
+		t.Fatalf("count:%v", len(exchanges.Elems))
+	}
+	e := exchanges.GetCfg(1)
+	if len(e.Rewards) != 2 || e.Rewards[0].CfgId != 1 || e.Rewards[0].Num != 2 || e.Rewards[1].CfgId != 2 || e.Rewards[1].Num != 5 {
+		t.Errorf("Rewards:%v", e.Rewards)
+	}
+	if len(e.ConditionTemplates) != 2 || e.ConditionTemplates[0].CfgId != 1 || !slices.Equal(e.ConditionTemplates[0].Args, []int32{5}) ||
+		e.ConditionTemplates[1].CfgId != 2 || len(e.ConditionTemplates[1].Args) != 0 || !slices.Equal(e.ConditionTemplates[1].Options, []int32{3}) {
+		t.Errorf("ConditionTemplates:%v", e.ConditionTemplates)
+	}
+	if e.Detail != "a,b_c;d" {
+		t.Errorf("Detail:%v", e.Detail)
+	}
+	// 字段不存在
+	if err := os.WriteFile(fileName, []byte("CfgId,NotExists\n1,1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := exchanges.Load(fileName); !errors.Is(err, ErrCsvFormat) {
+		t.Errorf("err:%v", err)
+	}
+}
@@ -0,0 +1,473 @@
+package cfg
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// csv格式的配置数据,和excelexporter的格式一致
+//
+//	第一行是字段名,字段名后面可以带选项,如"Rewards#Field=CfgId_Num"表示结构体字段的顺序
+//	以#开头的行和列是注释
+//	嵌套字段使用excelexporter的紧凑格式:
+//	  数组: 1;2;3 (也可以换行分隔)
+//	  结构体: 字段值用_分隔,默认按proto里的字段顺序,如AddElemArg: 1_5
+//	  结构体数组: 1_5;2_10
+//	  结构体里的数组: 用,分隔,如CfgArgOptions: 1_5,6_2
+//	  map: K_V;K_V,值是结构体时为K_字段值_字段值,如IntEventFields: IsPvp_=_1;RoomType_=_2,3
+const (
+	csvListSep     = ";"
+	csvFieldSep    = "_"
+	csvSubListSep  = ","
+	csvCommentFlag = "#"
+)
+
+var ErrCsvFormat = errors.New("csv format error")
+
+// csv的一列
+type csvColumn struct {
+	fd protoreflect.FieldDescriptor
+	// 结构体字段(或者map的值是结构体)的紧凑格式里的字段顺序
+	subFields []protoreflect.FieldDescriptor
+}
+
+// 从csv文件加载配置项列表
+func loadCsv[E any](fileName string) ([]E, error) {
+	fileData, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	// 去掉excel保存csv时可能带的BOM
+	fileData = bytes.TrimPrefix(fileData, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(fileData))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	var elems []E
+	var columns []*csvColumn
+	for rowIndex, record := range records {
+		if columns == nil {
+			sample, newErr := newProtoElement[E]()
+			if newErr != nil {
+				return nil, newErr
+			}
+			if columns, err = parseCsvHeader(sample.ProtoReflect().Descriptor(), record); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if isCsvSkipRow(record) {
+			continue
+		}
+		elem, newErr := newElement[E]()
+		if newErr != nil {
+			return nil, newErr
+		}
+		msg := any(elem).(proto.Message).ProtoReflect()
+		for i, cell := range record {
+			if i >= len(columns) || columns[i] == nil {
+				continue
+			}
+			if err = columns[i].parseValue(msg, cell); err != nil {
+				return nil, fmt.Errorf("%w row:%v column:%v %v", ErrCsvFormat, rowIndex+1, columns[i].fd.Name(), err)
+			}
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// 空行和注释行
+func isCsvSkipRow(record []string) bool {
+	if len(record) == 0 || strings.HasPrefix(record[0], csvCommentFlag) {
+		return true
+	}
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// 解析字段名行,注释列返回nil
+func parseCsvHeader(md protoreflect.MessageDescriptor, header []string) ([]*csvColumn, error) {
+	columns := make([]*csvColumn, len(header))
+	for i, cell := range header {
+		// 字段名后面的选项可以换行,如"Rewards\n#Field=CfgId_Num#Ref=ItemCfg"
+		name, options, _ := strings.Cut(strings.TrimSpace(cell), csvCommentFlag)
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, fmt.Errorf("%w field %v not exists in %v", ErrCsvFormat, name, md.FullName())
+		}
+		column := &csvColumn{fd: fd}
+		if subMd := compactMessage(fd); subMd != nil {
+			column.subFields = defaultCompactFields(subMd)
+			for _, option := range strings.Split(options, csvCommentFlag) {
+				key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+				// #Field=no表示使用默认的字段顺序
+				if key != "Field" || value == "" || value == "no" {
+					continue
+				}
+				column.subFields = column.subFields[:0:0]
+				for _, subName := range strings.Split(value, csvFieldSep) {
+					subFd := subMd.Fields().ByName(protoreflect.Name(subName))
+					if subFd == nil {
+						return nil, fmt.Errorf("%w field %v not exists in %v", ErrCsvFormat, subName, subMd.FullName())
+					}
+					column.subFields = append(column.subFields, subFd)
+				}
+			}
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+// 使用紧凑格式的结构体类型,包括结构体数组和值是结构体的map
+func compactMessage(fd protoreflect.FieldDescriptor) protoreflect.MessageDescriptor {
+	if fd.IsMap() {
+		return fd.MapValue().Message()
+	}
+	return fd.Message()
+}
+
+func defaultCompactFields(md protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	fields := make([]protoreflect.FieldDescriptor, 0, md.Fields().Len())
+	for i := 0; i < md.Fields().Len(); i++ {
+		fields = append(fields, md.Fields().Get(i))
+	}
+	return fields
+}
+
+// 数组和map的元素可以用;或者换行分隔
+func splitCsvList(cell string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(cell, func(r rune) bool {
+		return r == ';' || r == '\n'
+	}) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (c *csvColumn) parseValue(msg protoreflect.Message, cell string) error {
+	if strings.TrimSpace(cell) == "" {
+		return nil
+	}
+	fd := c.fd
+	switch {
+	case fd.IsMap():
+		m := msg.Mutable(fd).Map()
+		for _, item := range splitCsvList(cell) {
+			key, value, ok := strings.Cut(item, csvFieldSep)
+			if !ok {
+				return fmt.Errorf("map item %v need K_V", item)
+			}
+			mapKey, err := parseCsvScalar(fd.MapKey(), key)
+			if err != nil {
+				return err
+			}
+			if fd.MapValue().Message() != nil {
+				mapValue := m.NewValue()
+				if err = parseCompactMessage(mapValue.Message(), c.subFields, value); err != nil {
+					return err
+				}
+				m.Set(mapKey.MapKey(), mapValue)
+				continue
+			}
+			mapValue, err := parseCsvScalar(fd.MapValue(), value)
+			if err != nil {
+				return err
+			}
+			m.Set(mapKey.MapKey(), mapValue)
+		}
+	case fd.IsList():
+		list := msg.Mutable(fd).List()
+		for _, item := range splitCsvList(cell) {
+			if fd.Message() != nil {
+				elem := list.NewElement()
+				if err := parseCompactMessage(elem.Message(), c.subFields, item); err != nil {
+					return err
+				}
+				list.Append(elem)
+				continue
+			}
+			value, err := parseCsvScalar(fd, item)
+			if err != nil {
+				return err
+			}
+			list.Append(value)
+		}
+	case fd.Message() != nil:
+		return parseCompactMessage(msg.Mutable(fd).Message(), c.subFields, strings.TrimSpace(cell))
+	default:
+		value, err := parseCsvScalar(fd, cell)
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, value)
+	}
+	return nil
+}
+
+// 解析紧凑格式的结构体,如1_5,6_2
+func parseCompactMessage(msg protoreflect.Message, fields []protoreflect.FieldDescriptor, text string) error {
+	values := strings.Split(text, csvFieldSep)
+	if len(values) > len(fields) {
+		return fmt.Errorf("%v too many fields for %v", text, msg.Descriptor().FullName())
+	}
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		fd := fields[i]
+		switch {
+		case fd.IsMap() || fd.Message() != nil:
+			return fmt.Errorf("%v nested field %v not supported", text, fd.Name())
+		case fd.IsList():
+			list := msg.Mutable(fd).List()
+			for _, item := range strings.Split(value, csvSubListSep) {
+				itemValue, err := parseCsvScalar(fd, item)
+				if err != nil {
+					return err
+				}
+				list.Append(itemValue)
+			}
+		default:
+			fieldValue, err := parseCsvScalar(fd, value)
+			if err != nil {
+				return err
+			}
+			msg.Set(fd, fieldValue)
+		}
+	}
+	return nil
+}
+
+func parseCsvScalar(fd protoreflect.FieldDescriptor, text string) (protoreflect.Value, error) {
+	text = strings.TrimSpace(text)
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(text)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(text, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(text, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(text, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(text, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(text, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(text, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(text), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(text)), nil
+	case protoreflect.EnumKind:
+		// 枚举可以填数值或者枚举名
+		if enumValue := fd.Enum().Values().ByName(protoreflect.Name(text)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		v, err := strconv.ParseInt(text, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), err
+	}
+	return protoreflect.Value{}, fmt.Errorf("kind %v not supported", fd.Kind())
+}
+
+// 把配置项列表保存成csv文件
+//
+//	只保存有值的字段,结构体字段使用默认的字段顺序
+func saveCsv[E any](fileName string, elems []E) error {
+	sample, err := newProtoElement[E]()
+	if err != nil {
+		return err
+	}
+	md := sample.ProtoReflect().Descriptor()
+	var columns []*csvColumn
+	var header []string
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if !slices.ContainsFunc(elems, func(e E) bool {
+			return any(e).(proto.Message).ProtoReflect().Has(fd)
+		}) {
+			continue
+		}
+		column := &csvColumn{fd: fd}
+		if subMd := compactMessage(fd); subMd != nil {
+			column.subFields = defaultCompactFields(subMd)
+		}
+		columns = append(columns, column)
+		header = append(header, string(fd.Name()))
+	}
+	buffer := new(bytes.Buffer)
+	writer := csv.NewWriter(buffer)
+	writer.Write(header)
+	for _, elem := range elems {
+		msg := any(elem).(proto.Message).ProtoReflect()
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			if record[i], err = column.formatValue(msg); err != nil {
+				return fmt.Errorf("%w column:%v %v", ErrCsvFormat, column.fd.Name(), err)
+			}
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, buffer.Bytes(), 0644)
+}
+
+func (c *csvColumn) formatValue(msg protoreflect.Message) (string, error) {
+	fd := c.fd
+	if !msg.Has(fd) {
+		return "", nil
+	}
+	var items []string
+	switch {
+	case fd.IsMap():
+		var err error
+		msg.Get(fd).Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			var keyText, valueText string
+			if keyText, err = formatCompactScalar(fd.MapKey(), key.Value()); err != nil {
+				return false
+			}
+			if fd.MapValue().Message() != nil {
+				valueText, err = formatCompactMessage(value.Message(), c.subFields)
+			} else {
+				valueText, err = formatCsvScalar(fd.MapValue(), value, csvListSep)
+			}
+			items = append(items, keyText+csvFieldSep+valueText)
+			return err == nil
+		})
+		if err != nil {
+			return "", err
+		}
+		// map的遍历顺序是随机的,排序后保证每次保存的结果一致
+		slices.Sort(items)
+	case fd.IsList():
+		list := msg.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			var item string
+			var err error
+			if fd.Message() != nil {
+				item, err = formatCompactMessage(list.Get(i).Message(), c.subFields)
+			} else {
+				item, err = formatCsvScalar(fd, list.Get(i), csvListSep)
+			}
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+	case fd.Message() != nil:
+		return formatCompactMessage(msg.Get(fd).Message(), c.subFields)
+	default:
+		return formatCsvScalar(fd, msg.Get(fd), "")
+	}
+	return strings.Join(items, csvListSep), nil
+}
+
+// 结构体转换成紧凑格式,末尾的空字段省略
+func formatCompactMessage(msg protoreflect.Message, fields []protoreflect.FieldDescriptor) (string, error) {
+	values := make([]string, len(fields))
+	for i, fd := range fields {
+		if !msg.Has(fd) {
+			continue
+		}
+		switch {
+		case fd.IsMap() || fd.Message() != nil:
+			return "", fmt.Errorf("nested field %v not supported", fd.Name())
+		case fd.IsList():
+			list := msg.Get(fd).List()
+			items := make([]string, 0, list.Len())
+			for j := 0; j < list.Len(); j++ {
+				item, err := formatCompactScalar(fd, list.Get(j))
+				if err != nil {
+					return "", err
+				}
+				items = append(items, item)
+			}
+			values[i] = strings.Join(items, csvSubListSep)
+		default:
+			value, err := formatCompactScalar(fd, msg.Get(fd))
+			if err != nil {
+				return "", err
+			}
+			values[i] = value
+		}
+	}
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	return strings.Join(values, csvFieldSep), nil
+}
+
+// 紧凑格式里的值不能包含分隔符
+func formatCompactScalar(fd protoreflect.FieldDescriptor, value protoreflect.Value) (string, error) {
+	return formatCsvScalar(fd, value, csvListSep+csvFieldSep+csvSubListSep)
+}
+
+// seps:值里不能包含的分隔符
+func formatCsvScalar(fd protoreflect.FieldDescriptor, value protoreflect.Value, seps string) (string, error) {
+	var text string
+	switch fd.Kind() {
+	case protoreflect.FloatKind:
+		text = strconv.FormatFloat(value.Float(), 'g', -1, 32)
+	case protoreflect.DoubleKind:
+		text = strconv.FormatFloat(value.Float(), 'g', -1, 64)
+	case protoreflect.EnumKind:
+		text = strconv.Itoa(int(value.Enum()))
+	case protoreflect.BytesKind:
+		text = string(value.Bytes())
+	default:
+		text = value.String()
+	}
+	if seps != "" && strings.ContainsAny(text, seps+"\n") {
+		return "", fmt.Errorf("value %q contains separator", text)
+	}
+	return text, nil
+}
+
+func newProtoElement[E any]() (proto.Message, error) {
+	elem, err := newElement[E]()
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := any(elem).(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("type %T does not implement proto.Message", elem)
+	}
+	return msg, nil
+}
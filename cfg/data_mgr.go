@@ -15,7 +15,31 @@ var (
     isLoading   = int32(0)
     register = &processRegister{}
 	DataDir string
+)
+
+// 所有的数据文件,按加载顺序
+var dataFileNames = []string{
+    "ItemCfg.json",
+    "condition_template.json",
+    "progress_template.json",
+    "levelcfg.json",
+    "exchange.json",
+    "Quests.json",
+    "ShopCfg.json",
+    "activitycfg.json",
+    "rankcfg.json",
+    "rankrewardcfg.json",
+    "guildlevelcfg.json",
+    "guilddonatecfg.json",
+}
 
+// 配置数据快照,加载完成后不再修改,重新加载时整体替换
+type Snapshot struct {
+    // 版本号,由数据文件的内容计算,数据相同时版本号也相同
+    Version int64
+    // 数据文件的md5,用于计算版本号
+    fileMd5s map[string]string
+    
     //物品数据
     ItemCfgs *DataMap[*pb.ItemCfg]
     
@@ -26,8 +50,8 @@ var (
     ProgressTemplateCfgs *DataMap[*pb.ProgressTemplateCfg]
     
     //等级配置
-    
     LevelExps *DataSlice[*pb.LevelExp]
+    
     //兑换数据
     ExchangeCfgs *DataMap[*pb.ExchangeCfg]
     
@@ -40,39 +64,53 @@ var (
     //活动数据
     ActivityCfgs *DataMap[*pb.ActivityCfg]
     
+    //排行榜数据
+    RankCfgs *DataMap[*pb.RankCfg]
     
-)
-
-// 预处理接口注册
-type processRegister struct {
-    ItemCfgsProcess func(mgr *DataMap[*pb.ItemCfg]) error
+    //排行榜奖励数据
+    RankRewardCfgs *DataMap[*pb.RankRewardCfg]
     
+    //公会等级配置
+    GuildLevelExps *DataSlice[*pb.LevelExp]
     
-	ConditionTemplateCfgsProcess func(mgr *DataMap[*pb.ConditionTemplateCfg]) error
+    //公会捐献数据
+    GuildDonateCfgs *DataMap[*pb.GuildDonateCfg]
     
+    // 预处理生成的索引数据
+    SnapshotIndexes
+}
+
+// 预处理接口注册
+type processRegister struct {
+    ItemCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.ItemCfg]) error
     
-	ProgressTemplateCfgsProcess func(mgr *DataMap[*pb.ProgressTemplateCfg]) error
+    ConditionTemplateCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.ConditionTemplateCfg]) error
     
+    ProgressTemplateCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.ProgressTemplateCfg]) error
     
-	
-    LevelExpsProcess func(mgr *DataSlice[*pb.LevelExp]) error
+    LevelExpsProcess func(s *Snapshot, mgr *DataSlice[*pb.LevelExp]) error
     
-	ExchangeCfgsProcess func(mgr *DataMap[*pb.ExchangeCfg]) error
+    ExchangeCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.ExchangeCfg]) error
     
+    QuestsProcess func(s *Snapshot, mgr *DataMap[*pb.QuestCfg]) error
     
-	QuestsProcess func(mgr *DataMap[*pb.QuestCfg]) error
+    ShopCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.ShopCfg]) error
     
+    ActivityCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.ActivityCfg]) error
     
-	ShopCfgsProcess func(mgr *DataMap[*pb.ShopCfg]) error
+    RankCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.RankCfg]) error
     
+    RankRewardCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.RankRewardCfg]) error
     
-	ActivityCfgsProcess func(mgr *DataMap[*pb.ActivityCfg]) error
+    GuildLevelExpsProcess func(s *Snapshot, mgr *DataSlice[*pb.LevelExp]) error
     
+    GuildDonateCfgsProcess func(s *Snapshot, mgr *DataMap[*pb.GuildDonateCfg]) error
     
-	
 }
 
-// filter:过滤接口,返回false则不加载该文件
+// filter:过滤接口,返回false则不加载该文件,沿用当前快照里的数据
+//
+//	所有数据加载,预处理和检查都成功后,才会替换当前快照,失败时当前快照不变
 func Load(dataDir string, filter func(fileName string) bool) error {
     if !atomic.CompareAndSwapInt32(&isLoading, 0, 1) {
         return ErrLoadingConcurrency
@@ -81,59 +119,111 @@ func Load(dataDir string, filter func(fileName string) bool) error {
     dataDir = filepath.ToSlash(dataDir)
     if strings.LastIndexByte(dataDir, filepath.Separator) != len(dataDir)-1 {
         dataDir += string(filepath.Separator)
+    }
+    s, err := LoadSnapshot(dataDir, filter, Get())
+    if err != nil {
+        return err
+    }
+    if err = Validate(s); err != nil {
+        return err
     }
 	DataDir = dataDir
+    swap(s)
+    return nil
+}
+
+// 加载配置数据到一个新的快照,不会替换当前快照,也不做Validate检查
+//
+//	base:filter过滤掉的文件沿用base里的数据,为nil时表示空快照
+func LoadSnapshot(dataDir string, filter func(fileName string) bool, base *Snapshot) (*Snapshot, error) {
+    dataDir = filepath.ToSlash(dataDir)
+    if strings.LastIndexByte(dataDir, filepath.Separator) != len(dataDir)-1 {
+        dataDir += string(filepath.Separator)
+    }
+    old := base
+    if old == nil {
+        old = new(Snapshot)
+    }
+    s := new(Snapshot)
+    *s = *old
     var err error
     
-    if err = LoadConfig(filter, "ItemCfg.json", dataDir, NewDataMap[*pb.ItemCfg], &ItemCfgs); err != nil {
-        return err
+    if err = LoadConfig(filter, "ItemCfg.json", dataDir, NewDataMap[*pb.ItemCfg], &s.ItemCfgs); err != nil {
+        return nil, err
     }
-    if err = LoadConfig(filter, "condition_template.json", dataDir, NewDataMap[*pb.ConditionTemplateCfg], &ConditionTemplateCfgs); err != nil {
-        return err
+    if err = LoadConfig(filter, "condition_template.json", dataDir, NewDataMap[*pb.ConditionTemplateCfg], &s.ConditionTemplateCfgs); err != nil {
+        return nil, err
     }
-    if err = LoadConfig(filter, "progress_template.json", dataDir, NewDataMap[*pb.ProgressTemplateCfg], &ProgressTemplateCfgs); err != nil {
-        return err
+    if err = LoadConfig(filter, "progress_template.json", dataDir, NewDataMap[*pb.ProgressTemplateCfg], &s.ProgressTemplateCfgs); err != nil {
+        return nil, err
     }
-    if err = LoadConfig(filter, "levelcfg.json", dataDir, func() *DataSlice[*pb.LevelExp] { return &DataSlice[*pb.LevelExp]{} }, &LevelExps); err != nil {
-        return err
+    if err = LoadConfig(filter, "levelcfg.json", dataDir, func() *DataSlice[*pb.LevelExp] { return &DataSlice[*pb.LevelExp]{} }, &s.LevelExps); err != nil {
+        return nil, err
     }
-    if err = LoadConfig(filter, "exchange.json", dataDir, NewDataMap[*pb.ExchangeCfg], &ExchangeCfgs); err != nil {
-        return err
+    if err = LoadConfig(filter, "exchange.json", dataDir, NewDataMap[*pb.ExchangeCfg], &s.ExchangeCfgs); err != nil {
+        return nil, err
     }
-    if err = LoadConfig(filter, "Quests.json", dataDir, NewDataMap[*pb.QuestCfg], &Quests); err != nil {
-        return err
+    if err = LoadConfig(filter, "Quests.json", dataDir, NewDataMap[*pb.QuestCfg], &s.Quests); err != nil {
+        return nil, err
     }
-    if err = LoadConfig(filter, "ShopCfg.json", dataDir, NewDataMap[*pb.ShopCfg], &ShopCfgs); err != nil {
-        return err
+    if err = LoadConfig(filter, "ShopCfg.json", dataDir, NewDataMap[*pb.ShopCfg], &s.ShopCfgs); err != nil {
+        return nil, err
     }
-    if err = LoadConfig(filter, "activitycfg.json", dataDir, NewDataMap[*pb.ActivityCfg], &ActivityCfgs); err != nil {
-        return err
+    if err = LoadConfig(filter, "activitycfg.json", dataDir, NewDataMap[*pb.ActivityCfg], &s.ActivityCfgs); err != nil {
+        return nil, err
+    }
+    if err = LoadConfig(filter, "rankcfg.json", dataDir, NewDataMap[*pb.RankCfg], &s.RankCfgs); err != nil {
+        return nil, err
+    }
+    if err = LoadConfig(filter, "rankrewardcfg.json", dataDir, NewDataMap[*pb.RankRewardCfg], &s.RankRewardCfgs); err != nil {
+        return nil, err
+    }
+    if err = LoadConfig(filter, "guildlevelcfg.json", dataDir, func() *DataSlice[*pb.LevelExp] { return &DataSlice[*pb.LevelExp]{} }, &s.GuildLevelExps); err != nil {
+        return nil, err
+    }
+    if err = LoadConfig(filter, "guilddonatecfg.json", dataDir, NewDataMap[*pb.GuildDonateCfg], &s.GuildDonateCfgs); err != nil {
+        return nil, err
     }
 
     
-    if err = Process(register.ItemCfgsProcess, ItemCfgs); err != nil {
-        return err
+    if err = Process(register.ItemCfgsProcess, s, &s.ItemCfgs, old.ItemCfgs); err != nil {
+        return nil, err
     }
-    if err = Process(register.ConditionTemplateCfgsProcess, ConditionTemplateCfgs); err != nil {
-        return err
+    if err = Process(register.ConditionTemplateCfgsProcess, s, &s.ConditionTemplateCfgs, old.ConditionTemplateCfgs); err != nil {
+        return nil, err
     }
-    if err = Process(register.ProgressTemplateCfgsProcess, ProgressTemplateCfgs); err != nil {
-        return err
+    if err = Process(register.ProgressTemplateCfgsProcess, s, &s.ProgressTemplateCfgs, old.ProgressTemplateCfgs); err != nil {
+        return nil, err
     }
-    if err = Process(register.LevelExpsProcess, LevelExps); err != nil {
-        return err
+    if err = Process(register.LevelExpsProcess, s, &s.LevelExps, old.LevelExps); err != nil {
+        return nil, err
     }
-    if err = Process(register.ExchangeCfgsProcess, ExchangeCfgs); err != nil {
-        return err
+    if err = Process(register.ExchangeCfgsProcess, s, &s.ExchangeCfgs, old.ExchangeCfgs); err != nil {
+        return nil, err
     }
-    if err = Process(register.QuestsProcess, Quests); err != nil {
-        return err
+    if err = Process(register.QuestsProcess, s, &s.Quests, old.Quests); err != nil {
+        return nil, err
     }
-    if err = Process(register.ShopCfgsProcess, ShopCfgs); err != nil {
-        return err
+    if err = Process(register.ShopCfgsProcess, s, &s.ShopCfgs, old.ShopCfgs); err != nil {
+        return nil, err
     }
-    if err = Process(register.ActivityCfgsProcess, ActivityCfgs); err != nil {
-        return err
+    if err = Process(register.ActivityCfgsProcess, s, &s.ActivityCfgs, old.ActivityCfgs); err != nil {
+        return nil, err
     }
-    return nil
+    if err = Process(register.RankCfgsProcess, s, &s.RankCfgs, old.RankCfgs); err != nil {
+        return nil, err
+    }
+    if err = Process(register.RankRewardCfgsProcess, s, &s.RankRewardCfgs, old.RankRewardCfgs); err != nil {
+        return nil, err
+    }
+    if err = Process(register.GuildLevelExpsProcess, s, &s.GuildLevelExps, old.GuildLevelExps); err != nil {
+        return nil, err
+    }
+    if err = Process(register.GuildDonateCfgsProcess, s, &s.GuildDonateCfgs, old.GuildDonateCfgs); err != nil {
+        return nil, err
+    }
+    if err = s.updateVersion(dataDir, filter); err != nil {
+        return nil, err
+    }
+    return s, nil
 }
@@ -6,9 +6,18 @@ func init() {
 	register.ExchangeCfgsProcess = exchangeAfterLoad
 }
 
-func exchangeAfterLoad(mgr *DataMap[*pb.ExchangeCfg]) error {
+func exchangeAfterLoad(s *Snapshot, mgr *DataMap[*pb.ExchangeCfg]) error {
 	mgr.Range(func(e *pb.ExchangeCfg) bool {
-		e.Conditions = ConvertConditionCfgs(e.ConditionTemplates)
+		e.Conditions = nil
+		e.GuildConditions = nil
+		// 公会条件需要在公会所在服务器检查,和玩家条件分开
+		for _, condition := range s.ConvertConditionCfgs(e.ConditionTemplates) {
+			if condition.GetType() == int32(pb.ConditionType_ConditionType_GuildPropertyCompare) {
+				e.GuildConditions = append(e.GuildConditions, condition)
+			} else {
+				e.Conditions = append(e.Conditions, condition)
+			}
+		}
 		return true
 	})
 	return nil
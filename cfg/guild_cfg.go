@@ -0,0 +1,35 @@
+package cfg
+
+import (
+	"log/slog"
+
+	"github.com/fish-tennis/gserver/pb"
+)
+
+func init() {
+	register.GuildLevelExpsProcess = guildLevelAfterLoad
+	register.GuildDonateCfgsProcess = guildDonateAfterLoad
+}
+
+func guildLevelAfterLoad(s *Snapshot, mgr *DataSlice[*pb.LevelExp]) error {
+	s.GuildMaxLevel = int32(mgr.Len())
+	return nil
+}
+
+func guildDonateAfterLoad(s *Snapshot, mgr *DataMap[*pb.GuildDonateCfg]) error {
+	mgr.Range(func(e *pb.GuildDonateCfg) bool {
+		if e.GetExp() < 0 || e.GetContribution() < 0 {
+			slog.Error("GuildDonateCfgErr", "CfgId", e.GetCfgId(), "Exp", e.GetExp(), "Contribution", e.GetContribution())
+		}
+		return true
+	})
+	return nil
+}
+
+// 公会升到下一级所需要经验值
+func (s *Snapshot) GetGuildNeedExp(nextLevel int32) int32 {
+	if s.GuildLevelExps == nil || nextLevel <= 0 || int(nextLevel) > s.GuildLevelExps.Len() {
+		return 0
+	}
+	return s.GuildLevelExps.GetCfg(int(nextLevel - 1)).GetNeedExp()
+}
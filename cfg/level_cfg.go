@@ -4,23 +4,19 @@ import (
 	"github.com/fish-tennis/gserver/pb"
 )
 
-var (
-	MaxLevel int32
-)
-
 func init() {
 	register.LevelExpsProcess = LevelAfterLoad
 }
 
-func LevelAfterLoad(mgr *DataSlice[*pb.LevelExp]) error {
-	MaxLevel = int32(mgr.Len())
+func LevelAfterLoad(s *Snapshot, mgr *DataSlice[*pb.LevelExp]) error {
+	s.MaxLevel = int32(mgr.Len())
 	return nil
 }
 
 // 下一级所需要经验值
-func GetNeedExp(nextLevel int32) int32 {
-	if LevelExps == nil || nextLevel <= 0 || int(nextLevel) > LevelExps.Len() {
+func (s *Snapshot) GetNeedExp(nextLevel int32) int32 {
+	if s.LevelExps == nil || nextLevel <= 0 || int(nextLevel) > s.LevelExps.Len() {
 		return 0
 	}
-	return LevelExps.GetCfg(int(nextLevel - 1)).GetNeedExp()
+	return s.LevelExps.GetCfg(int(nextLevel - 1)).GetNeedExp()
 }
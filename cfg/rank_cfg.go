@@ -0,0 +1,59 @@
+package cfg
+
+import (
+	"cmp"
+	"log/slog"
+	"math"
+	"slices"
+
+	"github.com/fish-tennis/gserver/pb"
+)
+
+func init() {
+	register.RankCfgsProcess = rankAfterLoad
+	register.RankRewardCfgsProcess = rankRewardAfterLoad
+}
+
+func rankAfterLoad(s *Snapshot, mgr *DataMap[*pb.RankCfg]) error {
+	ranksByName := make(map[string]*pb.RankCfg)
+	mgr.Range(func(e *pb.RankCfg) bool {
+		if e.GetName() == "" {
+			slog.Error("RankCfgErr name empty", "CfgId", e.GetCfgId())
+			return true
+		}
+		if _, ok := ranksByName[e.GetName()]; ok {
+			slog.Error("RankCfgErr duplicate name", "CfgId", e.GetCfgId(), "name", e.GetName())
+			return true
+		}
+		ranksByName[e.GetName()] = e
+		if e.ProgressTemplate != nil {
+			e.Progress = s.ConvertProgressCfg(e.ProgressTemplate)
+			// 排行榜的分数一般没有上限
+			if e.Progress != nil && e.Progress.Total <= 0 {
+				e.Progress.Total = math.MaxInt32
+			}
+		}
+		return true
+	})
+	s.RanksByName = ranksByName
+	return nil
+}
+
+func rankRewardAfterLoad(s *Snapshot, mgr *DataMap[*pb.RankRewardCfg]) error {
+	rankRewardsByRank := make(map[int32][]*pb.RankRewardCfg)
+	mgr.Range(func(e *pb.RankRewardCfg) bool {
+		if e.GetMinRank() <= 0 || e.GetMaxRank() < e.GetMinRank() {
+			slog.Error("RankRewardCfgErr rank range", "CfgId", e.GetCfgId(), "MinRank", e.GetMinRank(), "MaxRank", e.GetMaxRank())
+			return true
+		}
+		rankRewardsByRank[e.GetRankCfgId()] = append(rankRewardsByRank[e.GetRankCfgId()], e)
+		return true
+	})
+	for _, rewards := range rankRewardsByRank {
+		slices.SortFunc(rewards, func(a, b *pb.RankRewardCfg) int {
+			return cmp.Compare(a.GetMinRank(), b.GetMinRank())
+		})
+	}
+	s.RankRewardsByRank = rankRewardsByRank
+	return nil
+}
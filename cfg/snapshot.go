@@ -0,0 +1,78 @@
+package cfg
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"os"
+	"sync/atomic"
+
+	"github.com/fish-tennis/gserver/pb"
+)
+
+// 当前使用的配置数据快照
+var current atomic.Pointer[Snapshot]
+
+func init() {
+	current.Store(&Snapshot{})
+}
+
+// 预处理生成的索引数据,由各个表的预处理接口填充
+//
+//	每次加载都会重新预处理,预处理接口只能给字段赋值新的对象,不能修改旧快照里的对象
+type SnapshotIndexes struct {
+	QuestsByLevel         map[int32]*DataMap[*pb.QuestCfg] // 按玩家等级限制的索引
+	QuestsDay             *DataMap[*pb.QuestCfg]           // 日常刷新的任务
+	ExchangeIdsByActivity map[int32]int32                  // map[ExchangeId]ActivityId
+	RanksByName           map[string]*pb.RankCfg           // 按排行榜名的索引
+	RankRewardsByRank     map[int32][]*pb.RankRewardCfg    // 按排行榜配置id的索引,按MinRank排序
+	MaxLevel              int32
+	GuildMaxLevel         int32
+}
+
+// 获取当前的配置数据快照(线程安全)
+//
+//	重新加载配置时整体替换快照,已经获取到的旧快照不受影响
+//	同一个处理流程里多次读取配置时,应该只调用一次Get,防止前后读到不同的快照
+//	玩家协程里使用Player.GetCfg,同一条消息的处理过程使用同一个快照
+func Get() *Snapshot {
+	return current.Load()
+}
+
+// 替换当前快照
+func swap(s *Snapshot) {
+	current.Store(s)
+}
+
+// 根据数据文件的内容计算版本号
+//
+//	数据文件相同时版本号也相同,不同的服务器之间可以用版本号判断数据是否一致
+//	filter过滤掉的文件沿用上一个快照里的md5
+func (s *Snapshot) updateVersion(dataDir string, filter func(fileName string) bool) error {
+	fileMd5s := make(map[string]string, len(dataFileNames))
+	for _, fileName := range dataFileNames {
+		if filter != nil && !filter(fileName) {
+			fileMd5s[fileName] = s.fileMd5s[fileName]
+			continue
+		}
+		fileData, err := os.ReadFile(ResolveDataFile(dataDir + fileName))
+		if err != nil {
+			return err
+		}
+		sum := md5.Sum(fileData)
+		fileMd5s[fileName] = hex.EncodeToString(sum[:])
+	}
+	h := md5.New()
+	for _, fileName := range dataFileNames {
+		h.Write([]byte(fileName))
+		h.Write([]byte(fileMd5s[fileName]))
+	}
+	s.fileMd5s = fileMd5s
+	s.Version = int64(binary.BigEndian.Uint64(h.Sum(nil)) & math.MaxInt64)
+	// 0表示还没有加载过配置数据
+	if s.Version == 0 {
+		s.Version = 1
+	}
+	return nil
+}
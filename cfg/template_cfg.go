@@ -15,8 +15,8 @@ import (
 // 其他表要配置条件和进度,只需要配置对应的模板id和参数即可,就可以由策划人员轻松配置了
 
 // 条件模板id+values+options,转换成ConditionCfg对象
-func ConvertConditionCfg(cfgArg *pb.CfgArgOptions) *pb.ConditionCfg {
-	conditionTemplate := ConditionTemplateCfgs.GetCfg(cfgArg.CfgId)
+func (s *Snapshot) ConvertConditionCfg(cfgArg *pb.CfgArgOptions) *pb.ConditionCfg {
+	conditionTemplate := s.ConditionTemplateCfgs.GetCfg(cfgArg.CfgId)
 	if conditionTemplate == nil {
 		return nil
 	}
@@ -31,10 +31,10 @@ func ConvertConditionCfg(cfgArg *pb.CfgArgOptions) *pb.ConditionCfg {
 	}
 }
 
-func ConvertConditionCfgs(cfgArgs []*pb.CfgArgOptions) []*pb.ConditionCfg {
+func (s *Snapshot) ConvertConditionCfgs(cfgArgs []*pb.CfgArgOptions) []*pb.ConditionCfg {
 	var conditions []*pb.ConditionCfg
 	for _, cfgArg := range cfgArgs {
-		condition := ConvertConditionCfg(cfgArg)
+		condition := s.ConvertConditionCfg(cfgArg)
 		if condition == nil {
 			slog.Error("condition nil", "cfgArg", cfgArg)
 			continue
@@ -45,8 +45,8 @@ func ConvertConditionCfgs(cfgArgs []*pb.CfgArgOptions) []*pb.ConditionCfg {
 }
 
 // 进度模板配置id+进度值,转换成ProgressCfg对象
-func ConvertProgressCfg(cfgArg *pb.CfgArg) *pb.ProgressCfg {
-	progressTemplate := ProgressTemplateCfgs.GetCfg(cfgArg.CfgId)
+func (s *Snapshot) ConvertProgressCfg(cfgArg *pb.CfgArg) *pb.ProgressCfg {
+	progressTemplate := s.ProgressTemplateCfgs.GetCfg(cfgArg.CfgId)
 	if progressTemplate == nil {
 		return nil
 	}
@@ -63,10 +63,10 @@ func ConvertProgressCfg(cfgArg *pb.CfgArg) *pb.ProgressCfg {
 	}
 }
 
-func convertProgressCfgs(cfgArgs []*pb.CfgArg) []*pb.ProgressCfg {
+func (s *Snapshot) convertProgressCfgs(cfgArgs []*pb.CfgArg) []*pb.ProgressCfg {
 	var progressCfgs []*pb.ProgressCfg
 	for _, cfgArg := range cfgArgs {
-		progress := ConvertProgressCfg(cfgArg)
+		progress := s.ConvertProgressCfg(cfgArg)
 		if progress == nil {
 			slog.Error("progress nil", "cfgArg", cfgArg)
 			continue
@@ -0,0 +1,262 @@
+package cfg
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/fish-tennis/gserver/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var ErrSnapshotIncomplete = errors.New("snapshot incomplete")
+
+// 配置数据的错误
+type CfgError struct {
+	Table string // 表名,和Snapshot的字段名一致
+	CfgId int32
+	Err   string
+}
+
+func (e *CfgError) Error() string {
+	return fmt.Sprintf("%v[%v]:%v", e.Table, e.CfgId, e.Err)
+}
+
+// 检查出的所有配置数据错误
+type CfgErrors []*CfgError
+
+func (e CfgErrors) Error() string {
+	s := make([]string, 0, len(e))
+	for _, cfgErr := range e {
+		s = append(s, cfgErr.Error())
+	}
+	return strings.Join(s, "\n")
+}
+
+// 配置数据检查
+//
+//	所有表加载和预处理完成之后,检查表之间的关联,有错误时不会替换当前快照
+//	只收集错误,不中断检查,方便一次修正所有的错误
+func Validate(s *Snapshot) error {
+	if s.ItemCfgs == nil || s.ConditionTemplateCfgs == nil || s.ProgressTemplateCfgs == nil ||
+		s.LevelExps == nil || s.ExchangeCfgs == nil || s.Quests == nil || s.ShopCfgs == nil || s.ActivityCfgs == nil ||
+		s.RankCfgs == nil || s.RankRewardCfgs == nil || s.GuildLevelExps == nil || s.GuildDonateCfgs == nil {
+		return ErrSnapshotIncomplete
+	}
+	v := &validator{s: s}
+	v.checkConditionTemplates()
+	v.checkProgressTemplates()
+	v.checkQuests()
+	v.checkExchanges()
+	v.checkShops()
+	v.checkActivities()
+	v.checkRanks()
+	v.checkGuildDonates()
+	if len(v.errs) == 0 {
+		return nil
+	}
+	slices.SortFunc(v.errs, func(a, b *CfgError) int {
+		if c := cmp.Compare(a.Table, b.Table); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.CfgId, b.CfgId)
+	})
+	for _, cfgErr := range v.errs {
+		slog.Error("ValidateErr", "table", cfgErr.Table, "cfgId", cfgErr.CfgId, "err", cfgErr.Err)
+	}
+	return v.errs
+}
+
+// 公会条件支持的属性名,和公会服务器上的检查对象保持一致
+var guildConditionKeys = []string{"Level", "Contribution", "MemberCount"}
+
+type validator struct {
+	s    *Snapshot
+	errs CfgErrors
+}
+
+func (v *validator) addError(table string, cfgId int32, format string, args ...any) {
+	v.errs = append(v.errs, &CfgError{
+		Table: table,
+		CfgId: cfgId,
+		Err:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) checkItemId(table string, cfgId int32, field string, itemId int32) {
+	if v.s.ItemCfgs.GetCfg(itemId) == nil {
+		v.addError(table, cfgId, "%v item %v not exists", field, itemId)
+	}
+}
+
+func (v *validator) checkConditionTemplateArgs(table string, cfgId int32, cfgArgs []*pb.CfgArgOptions) {
+	for _, cfgArg := range cfgArgs {
+		if v.s.ConditionTemplateCfgs.GetCfg(cfgArg.GetCfgId()) == nil {
+			v.addError(table, cfgId, "ConditionTemplates %v not exists", cfgArg.GetCfgId())
+		}
+	}
+}
+
+func (v *validator) checkProgressTemplateArg(table string, cfgId int32, cfgArg *pb.CfgArg) {
+	if cfgArg != nil && v.s.ProgressTemplateCfgs.GetCfg(cfgArg.GetCfgId()) == nil {
+		v.addError(table, cfgId, "ProgressTemplate %v not exists", cfgArg.GetCfgId())
+	}
+}
+
+func (v *validator) checkConditionTemplates() {
+	v.s.ConditionTemplateCfgs.Range(func(e *pb.ConditionTemplateCfg) bool {
+		if _, ok := pb.ConditionType_name[e.GetType()]; !ok || e.GetType() == int32(pb.ConditionType_ConditionType_None) {
+			v.addError("ConditionTemplateCfgs", e.GetCfgId(), "unknown ConditionType %v", e.GetType())
+		}
+		return true
+	})
+}
+
+func (v *validator) checkProgressTemplates() {
+	v.s.ProgressTemplateCfgs.Range(func(e *pb.ProgressTemplateCfg) bool {
+		if e.GetType() != int32(pb.ProgressType_ProgressType_Event) {
+			return true
+		}
+		// 事件需要是proto消息,事件字段名就是proto的字段名
+		eventDescriptor := findEventDescriptor(e.GetEvent())
+		if eventDescriptor == nil {
+			v.addError("ProgressTemplateCfgs", e.GetCfgId(), "event %v has no registered proto", e.GetEvent())
+			return true
+		}
+		fieldNames := make([]string, 0, len(e.GetIntEventFields())+len(e.GetStringEventFields())+1)
+		if e.GetProgressField() != "" {
+			fieldNames = append(fieldNames, e.GetProgressField())
+		}
+		for fieldName := range e.GetIntEventFields() {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		for fieldName := range e.GetStringEventFields() {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		for _, fieldName := range fieldNames {
+			if eventDescriptor.Fields().ByName(protoreflect.Name(fieldName)) == nil {
+				v.addError("ProgressTemplateCfgs", e.GetCfgId(), "event %v has no field %v", e.GetEvent(), fieldName)
+			}
+		}
+		return true
+	})
+}
+
+// 事件名对应的proto消息
+func findEventDescriptor(eventName string) protoreflect.MessageDescriptor {
+	if eventName == "" {
+		return nil
+	}
+	packageName := (&pb.ProgressCfg{}).ProtoReflect().Descriptor().ParentFile().Package()
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(packageName.Append(protoreflect.Name(eventName)))
+	if err != nil {
+		return nil
+	}
+	return messageType.Descriptor()
+}
+
+func (v *validator) checkQuests() {
+	v.s.Quests.Range(func(e *pb.QuestCfg) bool {
+		if e.GetPreQuest() > 0 && v.s.Quests.GetCfg(e.GetPreQuest()) == nil {
+			v.addError("Quests", e.GetCfgId(), "PreQuest %v not exists", e.GetPreQuest())
+		}
+		for _, nextQuestId := range e.GetNextQuests() {
+			if v.s.Quests.GetCfg(nextQuestId) == nil {
+				v.addError("Quests", e.GetCfgId(), "NextQuests %v not exists", nextQuestId)
+			}
+		}
+		for _, reward := range e.GetRewards() {
+			v.checkItemId("Quests", e.GetCfgId(), "Rewards", reward.GetCfgId())
+		}
+		for _, collect := range e.GetCollects() {
+			v.checkItemId("Quests", e.GetCfgId(), "Collects", collect.GetCfgId())
+		}
+		v.checkConditionTemplateArgs("Quests", e.GetCfgId(), e.GetConditionTemplates())
+		v.checkProgressTemplateArg("Quests", e.GetCfgId(), e.GetProgressTemplate())
+		return true
+	})
+}
+
+func (v *validator) checkExchanges() {
+	v.s.ExchangeCfgs.Range(func(e *pb.ExchangeCfg) bool {
+		for _, consume := range e.GetConsumes() {
+			v.checkItemId("ExchangeCfgs", e.GetCfgId(), "Consumes", consume.GetCfgId())
+		}
+		for _, reward := range e.GetRewards() {
+			v.checkItemId("ExchangeCfgs", e.GetCfgId(), "Rewards", reward.GetCfgId())
+		}
+		v.checkConditionTemplateArgs("ExchangeCfgs", e.GetCfgId(), e.GetConditionTemplates())
+		if len(e.GetGuildConditions()) > 0 && e.GetCategory() != int32(pb.ExchangeCategory_ExchangeCategory_GuildShop) {
+			// 只有公会商店的商品才会在公会所在服务器检查公会条件
+			v.addError("ExchangeCfgs", e.GetCfgId(), "GuildConditions only supported by ExchangeCategory_GuildShop")
+		}
+		for _, condition := range e.GetGuildConditions() {
+			if !slices.Contains(guildConditionKeys, condition.GetKey()) {
+				v.addError("ExchangeCfgs", e.GetCfgId(), "GuildConditions key %v not supported", condition.GetKey())
+			}
+		}
+		return true
+	})
+}
+
+func (v *validator) checkShops() {
+	v.s.ShopCfgs.Range(func(e *pb.ShopCfg) bool {
+		for _, exchangeId := range e.GetExchangeIds() {
+			if v.s.ExchangeCfgs.GetCfg(exchangeId) == nil {
+				v.addError("ShopCfgs", e.GetCfgId(), "ExchangeIds %v not exists", exchangeId)
+			}
+		}
+		return true
+	})
+}
+
+func (v *validator) checkActivities() {
+	v.s.ActivityCfgs.Range(func(e *pb.ActivityCfg) bool {
+		for _, questId := range e.GetQuestIds() {
+			questCfg := v.s.Quests.GetCfg(questId)
+			if questCfg == nil {
+				v.addError("ActivityCfgs", e.GetCfgId(), "QuestIds %v not exists", questId)
+				continue
+			}
+			// 活动的任务由活动模块接取,不能被普通任务流程自动接取
+			if questCfg.GetQuestType() != int32(pb.QuestType_QuestType_SubQuest) {
+				v.addError("ActivityCfgs", e.GetCfgId(), "QuestIds %v QuestType is not QuestType_SubQuest", questId)
+			}
+		}
+		for _, exchangeId := range e.GetExchangeIds() {
+			if v.s.ExchangeCfgs.GetCfg(exchangeId) == nil {
+				v.addError("ActivityCfgs", e.GetCfgId(), "ExchangeIds %v not exists", exchangeId)
+			}
+		}
+		return true
+	})
+}
+
+func (v *validator) checkRanks() {
+	v.s.RankCfgs.Range(func(e *pb.RankCfg) bool {
+		v.checkProgressTemplateArg("RankCfgs", e.GetCfgId(), e.GetProgressTemplate())
+		return true
+	})
+	v.s.RankRewardCfgs.Range(func(e *pb.RankRewardCfg) bool {
+		if v.s.RankCfgs.GetCfg(e.GetRankCfgId()) == nil {
+			v.addError("RankRewardCfgs", e.GetCfgId(), "RankCfgId %v not exists", e.GetRankCfgId())
+		}
+		for _, reward := range e.GetRewards() {
+			v.checkItemId("RankRewardCfgs", e.GetCfgId(), "Rewards", reward.GetCfgId())
+		}
+		return true
+	})
+}
+
+func (v *validator) checkGuildDonates() {
+	v.s.GuildDonateCfgs.Range(func(e *pb.GuildDonateCfg) bool {
+		for _, consume := range e.GetConsumes() {
+			v.checkItemId("GuildDonateCfgs", e.GetCfgId(), "Consumes", consume.GetCfgId())
+		}
+		return true
+	})
+}
@@ -0,0 +1,90 @@
+package cfg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yaml格式的配置数据,结构和json格式一致
+//
+//	map类型的配置是id到配置项的映射,slice类型的配置是配置项的数组
+func loadYaml(fileName string, v any) error {
+	fileData, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	var data any
+	if err = yaml.Unmarshal(fileData, &data); err != nil {
+		return err
+	}
+	// 转换成json再解析,配置项的字段名和类型转换和json格式保持一致
+	jsonData, err := json.Marshal(normalizeYaml(data))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+// yaml里的map的key可以是任意类型(如数字id),转换成json支持的map[string]any
+func normalizeYaml(v any) any {
+	switch data := v.(type) {
+	case map[string]any:
+		for k, value := range data {
+			data[k] = normalizeYaml(value)
+		}
+	case map[any]any:
+		m := make(map[string]any, len(data))
+		for k, value := range data {
+			m[fmt.Sprint(k)] = normalizeYaml(value)
+		}
+		return m
+	case []any:
+		for i, value := range data {
+			data[i] = normalizeYaml(value)
+		}
+	}
+	return v
+}
+
+func saveYaml(fileName string, v any) error {
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	var data any
+	if err = decoder.Decode(&data); err != nil {
+		return err
+	}
+	yamlData, err := yaml.Marshal(jsonNumberToYaml(data))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, yamlData, 0644)
+}
+
+// json.Number会被yaml当成字符串,转换成整数或浮点数,防止大整数被转换成科学计数法
+func jsonNumberToYaml(v any) any {
+	switch data := v.(type) {
+	case json.Number:
+		if i, err := data.Int64(); err == nil {
+			return i
+		}
+		f, _ := data.Float64()
+		return f
+	case map[string]any:
+		for k, value := range data {
+			data[k] = jsonNumberToYaml(value)
+		}
+	case []any:
+		for i, value := range data {
+			data[i] = jsonNumberToYaml(value)
+		}
+	}
+	return v
+}
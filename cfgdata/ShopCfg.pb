@@ -1 +1 @@
-测试商店
\ No newline at end of file
+测试商店公会商店
\ No newline at end of file
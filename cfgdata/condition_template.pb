@@ -1 +1 @@
->= 0Level 0DayCount>=
\ No newline at end of file
+Level>= 0DayCount>= 0Level>= Contribution>= 
\ No newline at end of file
@@ -1,4 +1,4 @@
-*(J商店1的商品2"*(
-J商店1的商品3"
-N(J活动1的礼包1"(J活动3的礼包"+"ц(J活动5的礼包1+(J活动5的礼包2"
-҆1(J商店1的商品1,5级可买"
\ No newline at end of file
+1"(J商店1的商品1,5级可买*"(J商店1的商品2*"
+(
+J商店1的商品3>"(8J'公会商店的商品1,公会2级可买C2"(8J&公会商店的商品2,贡献100可买dN"(J活动1的礼包1"(J活动3的礼包+ц"(J活动5的礼包1+҆"
+(J活动5的礼包2
\ No newline at end of file
@@ -0,0 +1,4 @@
+d
+ 
+*金币捐献
+2 <*道具捐献
\ No newline at end of file
@@ -0,0 +1 @@
+BLevel d:.等级排行榜,演示覆盖分数的排行榜DPvpWin d:1PVP胜场排行榜,演示累加分数的排行榜
\ No newline at end of file
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/fish-tennis/gserver/cfg"
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+// 字段的变化,值是json格式,和配置数据文件的格式一致
+type FieldChange struct {
+	Field string
+	Old   json.RawMessage `json:",omitempty"`
+	New   json.RawMessage `json:",omitempty"`
+}
+
+// 配置项的变化
+//
+//	CfgId是int32或string,和表的key类型一致
+type ElemChange struct {
+	CfgId  any
+	Fields []*FieldChange
+}
+
+// 一个表的变化
+type TableDiff struct {
+	Table   string // 表名,和cfg.Snapshot的字段名一致
+	Added   []any
+	Removed []any
+	Changed []*ElemChange
+}
+
+// 危险的修改,可能影响已经在线上的玩家数据
+type Danger struct {
+	Table string
+	CfgId any
+	Msg   string
+}
+
+// 对比结果
+type Report struct {
+	OldDir         string
+	NewDir         string
+	ValidateErrors []string `json:",omitempty"` // 新配置的检查错误(cfg.Validate)
+	Tables         []*TableDiff
+	Dangers        []*Danger `json:",omitempty"`
+}
+
+func (r *Report) HasChanges() bool {
+	return len(r.Tables) > 0
+}
+
+// 危险修改的检查接口
+//
+//	oldElem或newElem为nil,表示删除或新增
+type dangerChecker func(r *Report, table string, cfgId any, oldElem, newElem any)
+
+var dangerCheckers = []dangerChecker{
+	checkRemovedQuest,
+	checkRemovedItem,
+	checkLoweredCountLimit,
+}
+
+// 删除了任务,玩家身上可能还有这个任务
+func checkRemovedQuest(r *Report, table string, cfgId any, oldElem, newElem any) {
+	if _, ok := oldElem.(*pb.QuestCfg); ok && newElem == nil {
+		r.addDanger(table, cfgId, "quest removed, players may still hold it")
+	}
+}
+
+// 删除了物品,玩家背包里可能还有这个物品
+func checkRemovedItem(r *Report, table string, cfgId any, oldElem, newElem any) {
+	if _, ok := oldElem.(*pb.ItemCfg); ok && newElem == nil {
+		r.addDanger(table, cfgId, "item removed, players may still hold it")
+	}
+}
+
+// 兑换次数限制变小了,已经兑换的次数可能超过新的限制(0表示不限制)
+func checkLoweredCountLimit(r *Report, table string, cfgId any, oldElem, newElem any) {
+	oldExchange, ok := oldElem.(*pb.ExchangeCfg)
+	if !ok {
+		return
+	}
+	newExchange, ok := newElem.(*pb.ExchangeCfg)
+	if !ok {
+		return
+	}
+	oldLimit, newLimit := oldExchange.GetCountLimit(), newExchange.GetCountLimit()
+	if newLimit > 0 && (oldLimit == 0 || newLimit < oldLimit) {
+		r.addDanger(table, cfgId, fmt.Sprintf("CountLimit lowered from %v to %v", oldLimit, newLimit))
+	}
+}
+
+func (r *Report) addDanger(table string, cfgId any, msg string) {
+	r.Dangers = append(r.Dangers, &Danger{
+		Table: table,
+		CfgId: cfgId,
+		Msg:   msg,
+	})
+}
+
+// 对比2个配置数据快照的所有表
+func Diff(report *Report, oldSnapshot, newSnapshot *cfg.Snapshot) {
+	oldVal := reflect.ValueOf(oldSnapshot).Elem()
+	newVal := reflect.ValueOf(newSnapshot).Elem()
+	for i := 0; i < oldVal.NumField(); i++ {
+		field := oldVal.Type().Field(i)
+		oldElems, ok := tableElems(oldVal.Field(i))
+		if !ok {
+			continue
+		}
+		newElems, _ := tableElems(newVal.Field(i))
+		if tableDiff := diffTable(report, field.Name, oldElems, newElems); tableDiff != nil {
+			report.Tables = append(report.Tables, tableDiff)
+		}
+	}
+}
+
+// 获取DataMap,StrDataMap和DataSlice里的配置项
+//
+//	key是配置项的id(int32或string),DataSlice的配置项没有id的,用序号(从1开始)作为id
+func tableElems(table reflect.Value) (map[any]any, bool) {
+	if table.Kind() != reflect.Pointer || table.Type().Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	if _, ok := table.Type().Elem().FieldByName("Elems"); !ok {
+		return nil, false
+	}
+	elems := make(map[any]any)
+	if table.IsNil() {
+		return elems, true
+	}
+	elemsVal := table.Elem().FieldByName("Elems")
+	switch elemsVal.Kind() {
+	case reflect.Map:
+		iter := elemsVal.MapRange()
+		for iter.Next() {
+			elems[iter.Key().Interface()] = iter.Value().Interface()
+		}
+	case reflect.Slice:
+		for i := 0; i < elemsVal.Len(); i++ {
+			elem := elemsVal.Index(i).Interface()
+			switch cfgData := elem.(type) {
+			case internal.CfgData:
+				elems[cfgData.GetCfgId()] = elem
+			case internal.StrCfgData:
+				elems[cfgData.GetCfgId()] = elem
+			default:
+				elems[int32(i+1)] = elem
+			}
+		}
+	default:
+		return nil, false
+	}
+	return elems, true
+}
+
+func diffTable(report *Report, table string, oldElems, newElems map[any]any) *TableDiff {
+	tableDiff := &TableDiff{
+		Table: table,
+	}
+	for _, cfgId := range sortedKeys(oldElems, newElems) {
+		oldElem, newElem := oldElems[cfgId], newElems[cfgId]
+		switch {
+		case oldElem == nil:
+			tableDiff.Added = append(tableDiff.Added, cfgId)
+		case newElem == nil:
+			tableDiff.Removed = append(tableDiff.Removed, cfgId)
+		default:
+			fields := diffFields(oldElem, newElem)
+			if len(fields) == 0 {
+				continue
+			}
+			tableDiff.Changed = append(tableDiff.Changed, &ElemChange{
+				CfgId:  cfgId,
+				Fields: fields,
+			})
+		}
+		for _, checker := range dangerCheckers {
+			checker(report, table, cfgId, oldElem, newElem)
+		}
+	}
+	if len(tableDiff.Added) == 0 && len(tableDiff.Removed) == 0 && len(tableDiff.Changed) == 0 {
+		return nil
+	}
+	return tableDiff
+}
+
+// 对比配置项的每个导出字段
+func diffFields(oldElem, newElem any) []*FieldChange {
+	oldVal := reflect.Indirect(reflect.ValueOf(oldElem))
+	newVal := reflect.Indirect(reflect.ValueOf(newElem))
+	var fields []*FieldChange
+	for i := 0; i < oldVal.NumField(); i++ {
+		field := oldVal.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		oldJson := marshalField(oldVal.Field(i))
+		newJson := marshalField(newVal.Field(i))
+		if bytes.Equal(oldJson, newJson) {
+			continue
+		}
+		fields = append(fields, &FieldChange{
+			Field: field.Name,
+			Old:   oldJson,
+			New:   newJson,
+		})
+	}
+	return fields
+}
+
+// 字段值转换成json,零值返回nil
+func marshalField(v reflect.Value) json.RawMessage {
+	if v.IsZero() || (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0 {
+		return nil
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return json.RawMessage(fmt.Sprintf("%q", err.Error()))
+	}
+	return data
+}
+
+func sortedKeys(oldElems, newElems map[any]any) []any {
+	keys := make([]any, 0, len(oldElems))
+	for cfgId := range oldElems {
+		keys = append(keys, cfgId)
+	}
+	for cfgId := range newElems {
+		if _, ok := oldElems[cfgId]; !ok {
+			keys = append(keys, cfgId)
+		}
+	}
+	slices.SortFunc(keys, compareCfgId)
+	return keys
+}
+
+// 同一个表的id类型是一样的,int32按数值排序,string按字符串排序
+func compareCfgId(a, b any) int {
+	if x, ok := a.(int32); ok {
+		if y, ok := b.(int32); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fish-tennis/gserver/cfg"
+	"github.com/fish-tennis/gserver/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDiff(t *testing.T) {
+	oldSnapshot, err := cfg.LoadSnapshot("./../../cfgdata", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newSnapshot, err := cfg.LoadSnapshot("./../../cfgdata", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := &Report{}
+	Diff(report, oldSnapshot, newSnapshot)
+	if report.HasChanges() || len(report.Dangers) > 0 {
+		t.Fatalf("same dir has changes:%v", report.Tables)
+	}
+
+	// 删除1个任务,修改1个兑换的次数限制
+	var removedQuestId, exchangeId int32
+	newSnapshot.Quests = cfg.NewDataMap[*pb.QuestCfg]()
+	for cfgId, questCfg := range oldSnapshot.Quests.Elems {
+		if removedQuestId == 0 {
+			removedQuestId = cfgId
+			continue
+		}
+		newSnapshot.Quests.Elems[cfgId] = questCfg
+	}
+	newSnapshot.ExchangeCfgs = cfg.NewDataMap[*pb.ExchangeCfg]()
+	for cfgId, exchangeCfg := range oldSnapshot.ExchangeCfgs.Elems {
+		if exchangeId == 0 {
+			exchangeId = cfgId
+			exchangeCfg = proto.Clone(exchangeCfg).(*pb.ExchangeCfg)
+			exchangeCfg.CountLimit = exchangeCfg.CountLimit + 1
+			oldSnapshot.ExchangeCfgs.Elems[cfgId].CountLimit = exchangeCfg.CountLimit + 1
+		}
+		newSnapshot.ExchangeCfgs.Elems[cfgId] = exchangeCfg
+	}
+	report = &Report{}
+	Diff(report, oldSnapshot, newSnapshot)
+	if len(report.Tables) != 2 {
+		t.Fatalf("tables:%v", len(report.Tables))
+	}
+	for _, tableDiff := range report.Tables {
+		switch tableDiff.Table {
+		case "Quests":
+			if len(tableDiff.Removed) != 1 || tableDiff.Removed[0] != removedQuestId {
+				t.Errorf("Quests diff:%v", tableDiff.Removed)
+			}
+		case "ExchangeCfgs":
+			if len(tableDiff.Changed) != 1 || tableDiff.Changed[0].CfgId != exchangeId ||
+				tableDiff.Changed[0].Fields[0].Field != "CountLimit" {
+				t.Errorf("ExchangeCfgs diff:%v", tableDiff.Changed)
+			}
+		default:
+			t.Errorf("unexpected table:%v", tableDiff.Table)
+		}
+	}
+	if len(report.Dangers) != 2 {
+		t.Errorf("dangers:%v", report.Dangers)
+	}
+	for _, danger := range report.Dangers {
+		t.Log(danger)
+	}
+}
+
+type strCfg struct {
+	Id  string
+	Num int32
+}
+
+func (c *strCfg) GetCfgId() string {
+	return c.Id
+}
+
+// key是string的表
+func TestDiffStrDataMap(t *testing.T) {
+	oldTable := cfg.NewStrDataMap[*strCfg]()
+	oldTable.Elems["a"] = &strCfg{Id: "a", Num: 1}
+	oldTable.Elems["b"] = &strCfg{Id: "b", Num: 1}
+	newTable := cfg.NewStrDataMap[*strCfg]()
+	newTable.Elems["b"] = &strCfg{Id: "b", Num: 2}
+	newTable.Elems["c"] = &strCfg{Id: "c", Num: 1}
+	oldElems, ok := tableElems(reflect.ValueOf(oldTable))
+	if !ok {
+		t.Fatal("oldTable not a table")
+	}
+	newElems, _ := tableElems(reflect.ValueOf(newTable))
+	tableDiff := diffTable(&Report{}, "StrCfgs", oldElems, newElems)
+	if tableDiff == nil || len(tableDiff.Added) != 1 || tableDiff.Added[0] != "c" ||
+		len(tableDiff.Removed) != 1 || tableDiff.Removed[0] != "a" ||
+		len(tableDiff.Changed) != 1 || tableDiff.Changed[0].CfgId != "b" {
+		t.Fatalf("diff:%v", tableDiff)
+	}
+}
@@ -0,0 +1,118 @@
+// 对比2个配置数据目录,输出每个表的差异,用于上线前检查导表结果
+//
+//	go run ./cmd/cfgdiff -old cfgdata_online -new cfgdata
+//
+// 新的配置数据会和热更新一样做加载,预处理和检查(cfg.Validate),但不会影响任何服务器
+// 返回值: 0:正常 1:新的配置数据有错误 2:有危险的修改(如删除了任务,兑换次数限制变小)
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/fish-tennis/gserver/cfg"
+)
+
+func main() {
+	oldDir := ""
+	newDir := ""
+	isJson := false
+	flag.StringVar(&oldDir, "old", "", "old cfg dir")
+	flag.StringVar(&newDir, "new", "cfgdata", "new cfg dir")
+	flag.StringVar(&cfg.DataFileExt, "ext", cfg.DataFileExt, "cfg data file ext(.json .pb .csv .yaml/.yml)")
+	flag.BoolVar(&isJson, "json", false, "output json")
+	flag.Parse()
+	if oldDir == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+	// 加载配置数据时的Info日志太多,只输出警告和错误
+	slog.SetLogLoggerLevel(slog.LevelWarn)
+
+	oldSnapshot, err := cfg.LoadSnapshot(oldDir, nil, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load %v error:%v\n", oldDir, err)
+		os.Exit(1)
+	}
+	newSnapshot, err := cfg.LoadSnapshot(newDir, nil, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load %v error:%v\n", newDir, err)
+		os.Exit(1)
+	}
+	report := &Report{
+		OldDir: oldDir,
+		NewDir: newDir,
+	}
+	if err = cfg.Validate(newSnapshot); err != nil {
+		var cfgErrs cfg.CfgErrors
+		if errors.As(err, &cfgErrs) {
+			for _, cfgErr := range cfgErrs {
+				report.ValidateErrors = append(report.ValidateErrors, cfgErr.Error())
+			}
+		} else {
+			report.ValidateErrors = append(report.ValidateErrors, err.Error())
+		}
+	}
+	Diff(report, oldSnapshot, newSnapshot)
+
+	if isJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	} else {
+		printReport(os.Stdout, report)
+	}
+	if len(report.ValidateErrors) > 0 {
+		os.Exit(1)
+	}
+	if len(report.Dangers) > 0 {
+		os.Exit(2)
+	}
+}
+
+func printReport(w io.Writer, report *Report) {
+	fmt.Fprintf(w, "old:%v new:%v\n", report.OldDir, report.NewDir)
+	if !report.HasChanges() {
+		fmt.Fprintln(w, "no changes")
+	}
+	for _, tableDiff := range report.Tables {
+		fmt.Fprintf(w, "\n== %v added:%v removed:%v changed:%v\n", tableDiff.Table,
+			len(tableDiff.Added), len(tableDiff.Removed), len(tableDiff.Changed))
+		for _, cfgId := range tableDiff.Added {
+			fmt.Fprintf(w, "  + %v\n", cfgId)
+		}
+		for _, cfgId := range tableDiff.Removed {
+			fmt.Fprintf(w, "  - %v\n", cfgId)
+		}
+		for _, elemChange := range tableDiff.Changed {
+			fmt.Fprintf(w, "  ~ %v\n", elemChange.CfgId)
+			for _, field := range elemChange.Fields {
+				fmt.Fprintf(w, "      %v: %v -> %v\n", field.Field, rawString(field.Old), rawString(field.New))
+			}
+		}
+	}
+	if len(report.Dangers) > 0 {
+		fmt.Fprintf(w, "\n!! dangerous changes:%v\n", len(report.Dangers))
+		for _, danger := range report.Dangers {
+			fmt.Fprintf(w, "  %v[%v]: %v\n", danger.Table, danger.CfgId, danger.Msg)
+		}
+	}
+	if len(report.ValidateErrors) > 0 {
+		fmt.Fprintf(w, "\n!! validate errors:%v\n", len(report.ValidateErrors))
+		for _, validateErr := range report.ValidateErrors {
+			fmt.Fprintf(w, "  %v\n", validateErr)
+		}
+	}
+}
+
+func rawString(data json.RawMessage) string {
+	if len(data) == 0 {
+		return "(empty)"
+	}
+	return string(data)
+}
@@ -8,20 +8,42 @@ const (
 	GuildDbName   = "guild"   // 公会数据库名
 	GlobalDbName  = "global"  // 全局数据库名
 	UniqueIdName  = "_id"     // 数据库id列名
+	// 玩家名字占用表,_id是玩家名,利用_id的唯一性保证玩家名不重复
+	PlayerNameDbName = "playername"
+	// 玩家改名记录表
+	PlayerNameHistoryDbName = "playernamehistory"
+	// 处罚记录表(封号,禁言,冻结交易)
+	SanctionDbName = "sanction"
 
-	AccountIdKeyName  = "AccountId"
-	PlayerIdKeyName   = "PlayerId"
-	GuildIdKeyName    = "GuildId"
-	GlobalDbKeyName   = "Key"
-	GlobalDbValueName = "Value" // global表作为kv数据库时的value列名
+	AccountIdKeyName    = "AccountId"
+	PlayerIdKeyName     = "PlayerId"
+	GuildIdKeyName      = "GuildId"
+	SystemMailIdKeyName = "SystemMailId"
+	GlobalDbKeyName     = "Key"
+	GlobalDbValueName   = "Value" // global表作为kv数据库时的value列名
 
 	// account表里的固定字段
-	AccountName = "Name"
+	AccountName     = "Name"
+	AccountPassword = "Password"
+	AccountProvider = "Provider"
 
 	// player表里的几个固定字段
 	PlayerName      = "Name"
 	PlayerAccountId = "AccountId"
 	PlayerRegionId  = "RegionId"
+	// 删除角色的时间戳
+	PlayerDeleteTimestamp = "DeleteTimestamp"
+
+	// playername表里的字段
+	PlayerNamePlayerId = "PlayerId"
+
+	// sanction表里的字段
+	SanctionAccountId       = "AccountId"
+	SanctionPlayerId        = "PlayerId"
+	SanctionType            = "Type"
+	SanctionEndTimestamp    = "EndTimestamp"
+	SanctionTimestamp       = "Timestamp"
+	SanctionRevokeTimestamp = "RevokeTimestamp"
 )
 
 var (
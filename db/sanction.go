@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gserver/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// 处罚记录表
+//
+//	每次处罚插入一条记录,撤销时只标记撤销信息,不删除记录,保留完整的审计信息
+func getSanctionCollection() *mongo.Collection {
+	return _dbMgr.GetEntityDb(SanctionDbName).(*gentity.MongoCollection).GetCollection()
+}
+
+// 处罚记录表的索引
+func CreateSanctionIndex() {
+	_dbMgr.GetEntityDb(SanctionDbName).(*gentity.MongoCollection).CreateIndex(SanctionAccountId, false)
+}
+
+// 新增一条处罚记录
+func InsertSanction(sanction *pb.Sanction) error {
+	// NOTE: 明文保存的proto字段,字段名会被mongodb自动转为小写,所以这里手动指定字段名
+	_, err := getSanctionCollection().InsertOne(context.Background(), bson.D{
+		{SanctionAccountId, sanction.AccountId},
+		{SanctionPlayerId, sanction.PlayerId},
+		{SanctionType, sanction.Type},
+		{SanctionEndTimestamp, sanction.EndTimestamp},
+		{"Reason", sanction.Reason},
+		{"Operator", sanction.Operator},
+		{SanctionTimestamp, sanction.Timestamp},
+		{SanctionRevokeTimestamp, int64(0)},
+	})
+	return err
+}
+
+// 查询账号当前生效的处罚
+func FindActiveSanctions(accountId int64, now int64) ([]*pb.Sanction, error) {
+	filter := bson.D{
+		{SanctionAccountId, accountId},
+		{SanctionRevokeTimestamp, int64(0)},
+		{"$or", bson.A{
+			bson.D{{SanctionEndTimestamp, int64(0)}},
+			bson.D{{SanctionEndTimestamp, bson.D{{"$gt", now}}}},
+		}},
+	}
+	return findSanctions(filter)
+}
+
+// 查询账号的所有处罚记录,包括已过期和已撤销的,供GM审计使用
+func FindSanctionHistory(accountId int64) ([]*pb.Sanction, error) {
+	return findSanctions(bson.D{{SanctionAccountId, accountId}})
+}
+
+func findSanctions(filter bson.D) ([]*pb.Sanction, error) {
+	cursor, err := getSanctionCollection().Find(context.Background(), filter,
+		options.Find().SetSort(bson.D{{SanctionTimestamp, 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var sanctions []*pb.Sanction
+	err = cursor.All(context.Background(), &sanctions)
+	return sanctions, err
+}
+
+// 撤销账号当前生效的某类处罚,playerId为0时撤销账号下所有角色的处罚,返回撤销的记录数
+func RevokeSanctions(accountId int64, playerId int64, sanctionType pb.SanctionType, operator, reason string, now int64) (int64, error) {
+	filter := bson.D{
+		{SanctionAccountId, accountId},
+		{SanctionType, int32(sanctionType)},
+		{SanctionRevokeTimestamp, int64(0)},
+	}
+	if playerId > 0 {
+		filter = append(filter, bson.E{Key: SanctionPlayerId, Value: playerId})
+	}
+	result, err := getSanctionCollection().UpdateMany(context.Background(), filter, bson.D{{"$set", bson.D{
+		{SanctionRevokeTimestamp, now},
+		{"RevokeOperator", operator},
+		{"RevokeReason", reason},
+	}}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// 从处罚记录中找出对某个角色生效的处罚,有多条时返回截止时间最晚的
+//
+//	playerId为0时只匹配账号级别的处罚
+func MatchSanction(sanctions []*pb.Sanction, sanctionType pb.SanctionType, playerId int64, now int64) *pb.Sanction {
+	var match *pb.Sanction
+	for _, sanction := range sanctions {
+		if sanction.Type != int32(sanctionType) || sanction.RevokeTimestamp > 0 {
+			continue
+		}
+		if sanction.PlayerId > 0 && sanction.PlayerId != playerId {
+			continue
+		}
+		if sanction.EndTimestamp > 0 && sanction.EndTimestamp <= now {
+			continue
+		}
+		if match == nil || match.EndTimestamp > 0 && (sanction.EndTimestamp == 0 || sanction.EndTimestamp > match.EndTimestamp) {
+			match = sanction
+		}
+	}
+	return match
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/fish-tennis/gserver/pb"
+)
+
+func TestMatchSanction(t *testing.T) {
+	now := int64(1000)
+	mute := int32(pb.SanctionType_SanctionType_Mute)
+	sanctions := []*pb.Sanction{
+		{AccountId: 1, PlayerId: 0, Type: mute, EndTimestamp: 1100},
+		{AccountId: 1, PlayerId: 2, Type: mute, EndTimestamp: 1200},
+		{AccountId: 1, PlayerId: 3, Type: mute, EndTimestamp: 0},
+		{AccountId: 1, PlayerId: 0, Type: mute, EndTimestamp: 900},
+		{AccountId: 1, PlayerId: 0, Type: mute, EndTimestamp: 5000, RevokeTimestamp: 950},
+		{AccountId: 1, PlayerId: 0, Type: int32(pb.SanctionType_SanctionType_Ban), EndTimestamp: 0},
+	}
+	cases := []struct {
+		playerId int64
+		end      int64
+		ok       bool
+	}{
+		{0, 1100, true},
+		{2, 1200, true},
+		{3, 0, true},
+		{4, 1100, true},
+	}
+	for _, c := range cases {
+		match := MatchSanction(sanctions, pb.SanctionType_SanctionType_Mute, c.playerId, now)
+		if (match != nil) != c.ok || match.GetEndTimestamp() != c.end {
+			t.Errorf("playerId:%v match:%v", c.playerId, match)
+		}
+	}
+	if MatchSanction(sanctions, pb.SanctionType_SanctionType_FreezeTrade, 2, now) != nil {
+		t.Error("FreezeTrade should not match")
+	}
+	if MatchSanction(sanctions, pb.SanctionType_SanctionType_Mute, 2, 1300) != nil {
+		t.Error("expired sanction should not match")
+	}
+}
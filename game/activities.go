@@ -3,7 +3,6 @@ package game
 import (
 	"github.com/fish-tennis/gentity"
 	"github.com/fish-tennis/gentity/util"
-	"github.com/fish-tennis/gserver/cfg"
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
 	"log/slog"
@@ -48,7 +47,7 @@ func (a *Activities) LoadFromBytesMap(bytesMap any) error {
 	sourceData := bytesMap.(map[int32][]byte)
 	for activityId, bytes := range sourceData {
 		// 动态构建活动对象
-		activity := CreateNewActivity(activityId, a, a.GetPlayer().GetTimerEntries().Now())
+		activity := CreateNewActivity(a.GetPlayer().GetCfg().ActivityCfgs.GetCfg(activityId), a, a.GetPlayer().GetTimerEntries().Now())
 		if activity == nil {
 			slog.Error("activity nil", "activityId", activityId)
 			continue
@@ -65,16 +64,15 @@ func (a *Activities) LoadFromBytesMap(bytesMap any) error {
 }
 
 // 根据模板创建活动对象
-func CreateNewActivity(activityCfgId int32, activities internal.ActivityMgr, t time.Time) internal.Activity {
-	activityCfg := cfg.ActivityCfgs.GetCfg(activityCfgId)
+func CreateNewActivity(activityCfg *pb.ActivityCfg, activities internal.ActivityMgr, t time.Time) internal.Activity {
 	if activityCfg == nil {
-		slog.Error("activityCfg nil", "activityId", activityCfgId)
+		slog.Error("activityCfg nil")
 		return nil
 	}
 	if activityCtor, ok := _activityTemplateCtorMap[activityCfg.GetTemplate()]; ok {
 		return activityCtor(activities, activityCfg, t)
 	}
-	slog.Error("activityCtor nil", "activityId", activityCfgId)
+	slog.Error("activityCtor nil", "activityId", activityCfg.GetCfgId())
 	return nil
 }
 
@@ -90,7 +88,7 @@ func (a *Activities) GetActivityDefault(activityId int32) *ActivityDefault {
 
 // 添加一个新活动
 func (a *Activities) AddNewActivity(activityCfg *pb.ActivityCfg, t time.Time) internal.Activity {
-	activity := CreateNewActivity(activityCfg.CfgId, a, t)
+	activity := CreateNewActivity(activityCfg, a, t)
 	if activity == nil {
 		slog.Error("AddNewActivityErr", "activityId", activityCfg.CfgId)
 		return nil
@@ -111,7 +109,7 @@ func (a *Activities) RemoveActivity(activityId int32) {
 
 // 检查所有还没参加的活动,如果满足参加条件,则参加
 func (a *Activities) AddAllActivitiesCanJoin(t time.Time) {
-	cfg.ActivityCfgs.Range(func(activityCfg *pb.ActivityCfg) bool {
+	a.GetPlayer().GetCfg().ActivityCfgs.Range(func(activityCfg *pb.ActivityCfg) bool {
 		if a.GetActivity(activityCfg.CfgId) == nil {
 			if a.CanJoin(activityCfg, t) {
 				activity := a.AddNewActivity(activityCfg, t)
@@ -136,7 +134,7 @@ func (a *Activities) OnDataLoad() {
 		return true
 	})
 	// 活动模块定时刷新
-	a.GetPlayer().GetTimerEntries().After(time.Second, func() time.Duration {
+	a.GetPlayer().AfterTimer(time.Second, func() time.Duration {
 		a.GetPlayer().GetActivities().OnUpdate(a.GetPlayer().GetTimerEntries().Now())
 		return time.Second
 	})
@@ -159,6 +157,11 @@ func (a *Activities) OnUpdate(t time.Time) {
 	a.CheckEnd(t)
 }
 
+// 重新加载配置后,立即检查新增的活动和结束时间有变化的活动
+func (a *Activities) TriggerCfgReloaded(event *internal.EventCfgReloaded) {
+	a.OnUpdate(a.GetPlayer().GetTimerEntries().Now())
+}
+
 // 事件分发
 func (a *Activities) OnEvent(event interface{}) {
 	a.Data.Range(func(k int32, activity internal.Activity) bool {
@@ -228,8 +231,9 @@ func (a *Activities) CheckEndTime(activityCfg *pb.ActivityCfg, t time.Time) bool
 
 // 检查已经结束的活动
 func (a *Activities) CheckEnd(t time.Time) {
+	activityCfgs := a.GetPlayer().GetCfg().ActivityCfgs
 	for activityId, activity := range a.Data.Data {
-		activityCfg := cfg.ActivityCfgs.GetCfg(activityId)
+		activityCfg := activityCfgs.GetCfg(activityId)
 		if activityCfg == nil {
 			continue
 		}
@@ -248,5 +252,5 @@ type ChildActivity struct {
 
 // 活动配置数据
 func (this *ChildActivity) GetActivityCfg() *pb.ActivityCfg {
-	return cfg.ActivityCfgs.GetCfg(this.GetId())
+	return this.Activities.GetPlayer().GetCfg().ActivityCfgs.GetCfg(this.GetId())
 }
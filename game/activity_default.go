@@ -2,7 +2,6 @@ package game
 
 import (
 	"github.com/fish-tennis/gentity"
-	"github.com/fish-tennis/gserver/cfg"
 	. "github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
 	"github.com/fish-tennis/gserver/util"
@@ -106,7 +105,7 @@ func (a *ActivityDefault) OnInit(t time.Time) {
 func (a *ActivityDefault) defaultInit(t time.Time) {
 	activityCfg := a.GetActivityCfg()
 	for _, questId := range activityCfg.QuestIds {
-		questCfg := cfg.Quests.GetCfg(questId)
+		questCfg := a.Activities.GetPlayer().GetCfg().Quests.GetCfg(questId)
 		if questCfg == nil {
 			continue
 		}
@@ -136,7 +135,7 @@ func (a *ActivityDefault) Refresh(t time.Time, refreshType int32) {
 func (a *ActivityDefault) defaultRefreshQuest(t time.Time, refreshType int32) {
 	activityCfg := a.GetActivityCfg()
 	for _, questId := range activityCfg.QuestIds {
-		questCfg := cfg.Quests.GetCfg(questId)
+		questCfg := a.Activities.GetPlayer().GetCfg().Quests.GetCfg(questId)
 		if questCfg == nil {
 			continue
 		}
@@ -155,7 +154,7 @@ func (a *ActivityDefault) defaultRefreshExchange(t time.Time, refreshType int32)
 	activityCfg := a.GetActivityCfg()
 	exchange := a.Activities.GetPlayer().GetExchange()
 	for _, exchangeCfgId := range activityCfg.ExchangeIds {
-		exchangeCfg := cfg.ExchangeCfgs.GetCfg(exchangeCfgId)
+		exchangeCfg := a.Activities.GetPlayer().GetCfg().ExchangeCfgs.GetCfg(exchangeCfgId)
 		if exchangeCfg == nil {
 			continue
 		}
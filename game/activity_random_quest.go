@@ -1,7 +1,6 @@
 package game
 
 import (
-	"github.com/fish-tennis/gserver/cfg"
 	. "github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
 	"log/slog"
@@ -27,7 +26,7 @@ func randomQuestInit(a *ActivityDefault, t time.Time) {
 		return
 	}
 	questId := activityCfg.QuestIds[rand.Intn(len(activityCfg.QuestIds))]
-	questCfg := cfg.Quests.GetCfg(questId)
+	questCfg := a.Activities.GetPlayer().GetCfg().Quests.GetCfg(questId)
 	if questCfg == nil {
 		slog.Error("randomQuestInitErr")
 		return
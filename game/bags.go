@@ -63,7 +63,7 @@ func (b *Bags) GetBag(itemCfgId int32) internal.ElemContainer {
 }
 
 func (b *Bags) GetBagByArg(arg *pb.AddElemArg) internal.ElemContainer {
-	itemCfg := cfg.ItemCfgs.GetCfg(arg.GetCfgId())
+	itemCfg := b.GetPlayer().GetCfg().ItemCfgs.GetCfg(arg.GetCfgId())
 	if itemCfg == nil {
 		slog.Error("ErrItemCfgId", "itemCfgId", arg.GetCfgId())
 		return nil
@@ -202,7 +202,7 @@ func (b *Bags) TriggerPlayerEntryGame(event *internal.EventPlayerEntryGame) {
 	b.BagUniqueItem.initTimeoutList()
 	b.BagEquip.initTimeoutList()
 	// 超时检查回调
-	b.GetPlayer().GetTimerEntries().After(time.Second, func() time.Duration {
+	b.GetPlayer().AfterTimer(time.Second, func() time.Duration {
 		bagUpdate := &pb.ElemContainerUpdate{}
 		now := int32(b.GetPlayer().GetTimerEntries().Now().Unix())
 		b.BagUniqueItem.checkTimeout(now, bagUpdate)
@@ -218,7 +218,7 @@ func (b *Bags) TriggerPlayerEntryGame(event *internal.EventPlayerEntryGame) {
 // 使用道具请求
 func (b *Bags) OnItemUseReq(req *pb.ItemUseReq) (*pb.ItemUseRes, error) {
 	b.GetPlayer().Log.Debug("OnItemUseReq", "req", req)
-	itemCfg := cfg.ItemCfgs.GetCfg(req.GetCfgId())
+	itemCfg := b.GetPlayer().GetCfg().ItemCfgs.GetCfg(req.GetCfgId())
 	if itemCfg == nil {
 		b.GetPlayer().Log.Error("ErrItemCfgId", "itemCfgId", req.GetCfgId())
 		return nil, errors.New("CfgIdError")
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/fish-tennis/gentity"
-	"github.com/fish-tennis/gserver/cfg"
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
 	"github.com/fish-tennis/gserver/util"
@@ -66,9 +65,10 @@ func (b *BaseInfo) IncExp(incExp int32) {
 		newExp = math.MaxInt32
 	}
 	b.Data.Exp = int32(newExp)
+	cfgs := b.GetPlayer().GetCfg()
 	for {
-		if b.Data.Level < cfg.MaxLevel {
-			needExp := cfg.GetNeedExp(b.Data.Level + 1)
+		if b.Data.Level < cfgs.MaxLevel {
+			needExp := cfgs.GetNeedExp(b.Data.Level + 1)
 			if needExp > 0 && b.Data.Exp >= needExp {
 				b.Data.Level++
 				b.Data.Exp -= needExp
@@ -0,0 +1,193 @@
+package game
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gnet"
+	"github.com/fish-tennis/gserver/network"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+const (
+	// 组件名
+	ComponentNameChat = "Chat"
+	// 聊天内容的最大字符数
+	ChatContentMaxLen = 200
+)
+
+// 聊天频率限制:Duration时间内最多发送Count条
+type ChatRateLimit struct {
+	Count    int
+	Duration time.Duration
+}
+
+// 敏感词过滤接口
+//
+//	返回过滤后的内容,返回error表示禁止发送
+type ChatWordFilter func(player *Player, channel pb.ChatChannel, content string) (string, error)
+
+// 网关广播接口,由GameServer实现
+type GateBroadcaster interface {
+	// 发给所有连接到本服的网关
+	BroadcastToGates(packet gnet.Packet)
+}
+
+var (
+	// 各频道的发言频率限制
+	ChatRateLimits = map[pb.ChatChannel]ChatRateLimit{
+		pb.ChatChannel_ChatChannel_Private: {Count: 10, Duration: time.Second * 10},
+		pb.ChatChannel_ChatChannel_Guild:   {Count: 5, Duration: time.Second * 10},
+		pb.ChatChannel_ChatChannel_World:   {Count: 1, Duration: time.Second * 10},
+	}
+	// 敏感词过滤,默认不过滤
+	_chatWordFilter ChatWordFilter
+)
+
+// 设置敏感词过滤接口,在服务器初始化时调用
+func SetChatWordFilter(filter ChatWordFilter) {
+	_chatWordFilter = filter
+}
+
+// 利用go的init进行组件的自动注册
+func init() {
+	_playerComponentRegister.Register(ComponentNameChat, 0, func(player *Player, _ any) gentity.Component {
+		return &Chat{
+			BasePlayerComponent: BasePlayerComponent{
+				player: player,
+				name:   ComponentNameChat,
+			},
+			sendTimes: make(map[pb.ChatChannel][]time.Time),
+		}
+	})
+}
+
+// 聊天模块
+//
+//	私聊:RoutePlayerPacket(WithDirectSendClient())直接发给对方客户端
+//	公会:路由到公会所在服务器,由公会广播给所有成员
+//	世界:发给所有网关,由网关广播给自己的所有客户端
+type Chat struct {
+	BasePlayerComponent
+	// 各频道最近的发言时间,用于频率限制,不需要保存
+	sendTimes map[pb.ChatChannel][]time.Time
+}
+
+func (p *Player) GetChat() *Chat {
+	return p.GetComponentByName(ComponentNameChat).(*Chat)
+}
+
+// 检查发言频率,没超过限制时记录本次发言
+func (c *Chat) checkRateLimit(channel pb.ChatChannel, now time.Time) bool {
+	rateLimit, ok := ChatRateLimits[channel]
+	if !ok || rateLimit.Count <= 0 {
+		return true
+	}
+	sendTimes := c.sendTimes[channel]
+	// 清理时间窗口之外的记录
+	expiredCount := 0
+	for _, sendTime := range sendTimes {
+		if now.Sub(sendTime) < rateLimit.Duration {
+			break
+		}
+		expiredCount++
+	}
+	sendTimes = sendTimes[expiredCount:]
+	if len(sendTimes) >= rateLimit.Count {
+		c.sendTimes[channel] = sendTimes
+		return false
+	}
+	c.sendTimes[channel] = append(sendTimes, now)
+	return true
+}
+
+// 发送聊天
+func (c *Chat) OnChatReq(req *pb.ChatReq) (*pb.ChatRes, error) {
+	l := c.GetPlayer().Log
+	l.Debug("OnChatReq", "req", req)
+	if c.GetPlayer().IsMuted() {
+		return nil, errors.New("Muted")
+	}
+	content := strings.TrimSpace(req.GetContent())
+	if content == "" {
+		return nil, errors.New("ContentEmpty")
+	}
+	if utf8.RuneCountInString(content) > ChatContentMaxLen {
+		return nil, errors.New("ContentTooLong")
+	}
+	channel := req.GetChannel()
+	switch channel {
+	case pb.ChatChannel_ChatChannel_Private:
+		if req.GetToPlayerId() <= 0 || req.GetToPlayerId() == c.GetPlayerId() {
+			return nil, errors.New("PlayerIdError")
+		}
+	case pb.ChatChannel_ChatChannel_Guild:
+		if c.GetPlayer().GetGuild().GetGuildData().GetGuildId() == 0 {
+			return nil, errors.New("NotGuildMember")
+		}
+	case pb.ChatChannel_ChatChannel_World:
+	default:
+		return nil, errors.New("ChannelError")
+	}
+	now := c.GetPlayer().GetTimerEntries().Now()
+	if !c.checkRateLimit(channel, now) {
+		return nil, errors.New("ChatTooFrequent")
+	}
+	if _chatWordFilter != nil {
+		var err error
+		content, err = _chatWordFilter(c.GetPlayer(), channel, content)
+		if err != nil {
+			l.Debug("OnChatReq filtered", "err", err)
+			return nil, err
+		}
+	}
+	chatMessage := &pb.ChatMessage{
+		Channel:      channel,
+		FromPlayerId: c.GetPlayerId(),
+		FromName:     c.GetPlayer().GetName(),
+		Content:      content,
+		Timestamp:    int32(now.Unix()),
+	}
+	switch channel {
+	case pb.ChatChannel_ChatChannel_Private:
+		chatMessage.ToPlayerId = req.GetToPlayerId()
+		// 对方不在线时路由失败
+		if !RoutePlayerPacket(req.GetToPlayerId(), network.NewPacket(chatMessage), WithDirectSendClient()) {
+			return nil, errors.New("PlayerOffline")
+		}
+	case pb.ChatChannel_ChatChannel_Guild:
+		reply := new(pb.GuildChatRes)
+		if err := c.GetPlayer().GetGuild().RouteRpcToSelfGuild(&pb.GuildChatReq{Message: chatMessage}, reply); err != nil {
+			return nil, err
+		}
+	case pb.ChatChannel_ChatChannel_World:
+		if !BroadcastWorldChat(chatMessage) {
+			return nil, errors.New("ChatSendError")
+		}
+	}
+	return &pb.ChatRes{
+		Message: chatMessage,
+	}, nil
+}
+
+// 发送世界聊天(线程安全)
+//
+//	每个网关都连接了所有的game服,所以只需要发给本服连接的网关即可
+//	NOTE:客户端直连game服的模式不支持世界聊天
+func BroadcastWorldChat(chatMessage *pb.ChatMessage) bool {
+	broadcaster, ok := gentity.GetApplication().(GateBroadcaster)
+	if !ok {
+		slog.Error("BroadcastWorldChat not support")
+		return false
+	}
+	cmd := network.GetCommandByProto(new(pb.WorldChatBroadcast))
+	broadcaster.BroadcastToGates(network.NewGatePacket(0, gnet.PacketCommand(cmd), &pb.WorldChatBroadcast{
+		Message: chatMessage,
+	}))
+	slog.Debug("BroadcastWorldChat", "fromPlayerId", chatMessage.GetFromPlayerId())
+	return true
+}
@@ -44,8 +44,16 @@ func PlayerPropertyInt32Checker(obj any, conditionCfg *pb.ConditionCfg) bool {
 	return internal.DefaultPropertyInt32Checker(player, conditionCfg)
 }
 
+// 获取检查条件时使用的配置数据,玩家对象使用玩家当前处理流程的配置数据
+func getConditionCfgs(obj any) *cfg.Snapshot {
+	if player := ParsePlayer(obj); player != nil {
+		return player.GetCfg()
+	}
+	return cfg.Get()
+}
+
 func CheckConditionArg(obj any, conditionArg *pb.CfgArgOptions) bool {
-	condition := cfg.ConvertConditionCfg(conditionArg)
+	condition := getConditionCfgs(obj).ConvertConditionCfg(conditionArg)
 	if condition == nil {
 		return false
 	}
@@ -53,6 +61,6 @@ func CheckConditionArg(obj any, conditionArg *pb.CfgArgOptions) bool {
 }
 
 func CheckConditionArgs(obj any, conditionArgs []*pb.CfgArgOptions) bool {
-	conditions := cfg.ConvertConditionCfgs(conditionArgs)
+	conditions := getConditionCfgs(obj).ConvertConditionCfgs(conditionArgs)
 	return internal.CheckConditions(obj, conditions)
 }
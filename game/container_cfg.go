@@ -67,7 +67,7 @@ func (b *CfgContainer[E]) AddElem(arg *pb.AddElemArg, bagUpdate *pb.ElemContaine
 	if arg.GetNum() <= 0 {
 		return 0
 	}
-	//itemCfg := cfg.ItemCfgs.GetCfg(arg.GetId())
+	//itemCfg := cfg.Get().ItemCfgs.GetCfg(arg.GetId())
 	//if itemCfg == nil {
 	//	return 0
 	//}
@@ -6,7 +6,6 @@ import (
 	"slices"
 
 	"github.com/fish-tennis/gentity"
-	"github.com/fish-tennis/gserver/cfg"
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
 	"github.com/fish-tennis/gserver/util"
@@ -102,7 +101,7 @@ func (b *UniqueContainer[E]) AddElem(arg *pb.AddElemArg, bagUpdate *pb.ElemConta
 	if addCount > internal.MaxBatchAddUniqueElemCount {
 		addCount = internal.MaxBatchAddUniqueElemCount
 	}
-	itemCfg := cfg.ItemCfgs.GetCfg(arg.GetCfgId())
+	itemCfg := b.Bags.GetPlayer().GetCfg().ItemCfgs.GetCfg(arg.GetCfgId())
 	if itemCfg == nil {
 		return 0
 	}
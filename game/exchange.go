@@ -5,7 +5,6 @@ import (
 	"math"
 
 	"github.com/fish-tennis/gentity"
-	"github.com/fish-tennis/gserver/cfg"
 	. "github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
 	"github.com/fish-tennis/gserver/util"
@@ -99,14 +98,25 @@ func (e *Exchange) GetRecordsByIds(exchangeCfgId ...int32) (records []*pb.Exchan
 //
 //	商店也可以看作是一种兑换功能
 func (e *Exchange) Exchange(exchangeCfgId, exchangeCount int32) error {
+	return e.exchange(exchangeCfgId, exchangeCount, nil)
+}
+
+// extraCheck:玩家自身的检查都通过后,扣除消耗之前的额外检查,比如公会商店需要到公会所在服务器检查公会条件
+func (e *Exchange) exchange(exchangeCfgId, exchangeCount int32, extraCheck func(exchangeCfg *pb.ExchangeCfg) error) error {
 	if exchangeCount <= 0 {
 		return errors.New("exchangeCount <= 0")
 	}
-	exchangeCfg := cfg.ExchangeCfgs.GetCfg(exchangeCfgId)
+	cfgs := e.GetPlayer().GetCfg()
+	exchangeCfg := cfgs.ExchangeCfgs.GetCfg(exchangeCfgId)
 	if exchangeCfg == nil {
 		slog.Debug("Exchange exchangeCfg nil", "pid", e.GetPlayer().GetId(), "exchangeCfgId", exchangeCfgId)
 		return errors.New("exchangeCfg nil")
 	}
+	// 公会商店的商品需要检查公会条件,只能通过GuildShopBuyReq购买
+	if exchangeCfg.Category == int32(pb.ExchangeCategory_ExchangeCategory_GuildShop) && extraCheck == nil {
+		slog.Debug("Exchange GuildShopOnly", "pid", e.GetPlayer().GetId(), "exchangeCfgId", exchangeCfgId)
+		return errors.New("GuildShopOnly")
+	}
 	curExchangeCount := e.GetCount(exchangeCfgId)
 	if exchangeCfg.CountLimit > 0 && int64(curExchangeCount)+int64(exchangeCount) > int64(exchangeCfg.CountLimit) {
 		slog.Debug("Exchange CountLimit", "pid", e.GetPlayer().GetId(), "exchangeCfgId", exchangeCfgId, "exchangeCount", exchangeCount)
@@ -114,7 +124,7 @@ func (e *Exchange) Exchange(exchangeCfgId, exchangeCount int32) error {
 	}
 	// 检查兑换条件
 	var obj any
-	activityId := cfg.GetActivityIdByExchangeId(exchangeCfgId)
+	activityId := cfgs.GetActivityIdByExchangeId(exchangeCfgId)
 	// NOTE:活动礼包比较特殊,需要获取到活动对象,这样CheckConditions才能正确检查活动条件
 	if activityId > 0 {
 		obj = e.GetPlayer().GetActivities().GetActivity(activityId)
@@ -168,6 +178,11 @@ func (e *Exchange) Exchange(exchangeCfgId, exchangeCount int32) error {
 		slog.Debug("Exchange ConsumeItems notEnough", "pid", e.GetPlayer().GetId(), "exchangeCfgId", exchangeCfgId)
 		return errors.New("ConsumeItemsNotEnough")
 	}
+	if extraCheck != nil {
+		if err := extraCheck(exchangeCfg); err != nil {
+			return err
+		}
+	}
 	// 当前顺序为: 记录次数→扣除消耗→发放奖励,任一步失败无回滚,设计如此,非bug,游戏防刷需要
 	e.addExchangeCount(exchangeCfgId, exchangeCount)          // 先记录兑换次数
 	e.GetPlayer().GetBags().DelItemsByItemNums(totalConsumes) // 消耗
@@ -177,6 +192,9 @@ func (e *Exchange) Exchange(exchangeCfgId, exchangeCount int32) error {
 
 // 响应客户端的兑换请求(购买物品,兑换礼包,领取奖励等)
 func (e *Exchange) OnExchangeReq(req *pb.ExchangeReq) (*pb.ExchangeRes, error) {
+	if e.GetPlayer().IsTradeFrozen() {
+		return nil, errors.New("TradeFrozen")
+	}
 	res := &pb.ExchangeRes{}
 	for _, idCount := range req.GetIdCounts() {
 		err := e.Exchange(idCount.GetId(), idCount.GetCount())
@@ -0,0 +1,226 @@
+package game
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gserver/cache"
+	"github.com/fish-tennis/gserver/db"
+	"github.com/fish-tennis/gserver/network"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+const (
+	// 组件名
+	ComponentNameFriends = "Friends"
+	// 好友数量上限
+	MaxFriendCount = 100
+	// 好友申请数量上限
+	MaxFriendRequestCount = 50
+)
+
+// 利用go的init进行组件的自动注册
+func init() {
+	_playerComponentRegister.Register(ComponentNameFriends, 0, func(player *Player, _ any) gentity.Component {
+		return &Friends{
+			BasePlayerComponent: BasePlayerComponent{
+				player: player,
+				name:   ComponentNameFriends,
+			},
+			Friends:  gentity.NewMapData[int64, *pb.FriendData](),
+			Requests: gentity.NewMapData[int64, *pb.FriendRequest](),
+		}
+	})
+}
+
+// 好友模块
+//
+//	好友关系是双向的,双方各自保存一份,通过RoutePlayerPacket(WithSaveDb())通知对方
+//	对方不在线时,消息保存在对方的PendingMessages,上线时处理
+type Friends struct {
+	BasePlayerComponent
+	// 保存数据的子模块:好友列表
+	Friends *gentity.MapData[int64, *pb.FriendData] `child:"Friends"`
+	// 保存数据的子模块:收到的好友申请
+	Requests *gentity.MapData[int64, *pb.FriendRequest] `child:"Requests"`
+}
+
+func (p *Player) GetFriends() *Friends {
+	return p.GetComponentByName(ComponentNameFriends).(*Friends)
+}
+
+func (f *Friends) SyncDataToClient() {
+	f.GetPlayer().Send(&pb.FriendsSync{
+		Friends:  f.Friends.Data,
+		Requests: f.Requests.Data,
+	})
+}
+
+func (f *Friends) now() int32 {
+	return int32(f.GetPlayer().GetTimerEntries().Now().Unix())
+}
+
+// 添加好友
+func (f *Friends) addFriend(playerId int64, name string) bool {
+	if f.Friends.Contains(playerId) {
+		return false
+	}
+	if len(f.Friends.Data) >= MaxFriendCount {
+		slog.Debug("Friends.addFriend full", "pid", f.GetPlayerId(), "friendId", playerId)
+		return false
+	}
+	f.Friends.Set(playerId, &pb.FriendData{
+		PlayerId:  playerId,
+		Name:      name,
+		Timestamp: f.now(),
+	})
+	slog.Debug("Friends.addFriend", "pid", f.GetPlayerId(), "friendId", playerId)
+	return true
+}
+
+// 申请加好友
+func (f *Friends) OnFriendAddReq(req *pb.FriendAddReq) (*pb.FriendAddRes, error) {
+	l := f.GetPlayer().Log
+	l.Debug("OnFriendAddReq", "req", req)
+	targetId := req.GetPlayerId()
+	if targetId <= 0 || targetId == f.GetPlayerId() {
+		return nil, errors.New("PlayerIdError")
+	}
+	if f.Friends.Contains(targetId) {
+		return nil, errors.New("AlreadyFriend")
+	}
+	if len(f.Friends.Data) >= MaxFriendCount {
+		return nil, errors.New("FriendCountLimit")
+	}
+	// 对方也申请过加自己为好友,直接同意
+	if f.Requests.Contains(targetId) {
+		_, err := f.OnFriendRequestOpReq(&pb.FriendRequestOpReq{
+			PlayerId: targetId,
+			IsAgree:  true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &pb.FriendAddRes{PlayerId: targetId}, nil
+	}
+	// 目标玩家不在线时,检查是否存在该玩家
+	if _, gameServerId := cache.GetOnlinePlayer(targetId); gameServerId == 0 {
+		if accountId, _ := db.GetPlayerDb().FindAccountIdByPlayerId(targetId); accountId == 0 {
+			return nil, errors.New("PlayerNotExists")
+		}
+	}
+	RoutePlayerPacket(targetId, network.NewPacket(&pb.FriendRequestAdd{
+		Request: &pb.FriendRequest{
+			PlayerId:  f.GetPlayerId(),
+			Name:      f.GetPlayer().GetName(),
+			Timestamp: f.now(),
+		},
+	}), WithSaveDb())
+	return &pb.FriendAddRes{PlayerId: targetId}, nil
+}
+
+// 收到好友申请
+//
+//	这种格式写的函数可以自动注册非客户端的消息回调
+func (f *Friends) HandleFriendRequestAdd(msg *pb.FriendRequestAdd) {
+	request := msg.GetRequest()
+	if request == nil || request.GetPlayerId() == f.GetPlayerId() {
+		return
+	}
+	if f.Friends.Contains(request.GetPlayerId()) {
+		return
+	}
+	if !f.Requests.Contains(request.GetPlayerId()) && len(f.Requests.Data) >= MaxFriendRequestCount {
+		slog.Debug("HandleFriendRequestAdd full", "pid", f.GetPlayerId(), "fromId", request.GetPlayerId())
+		return
+	}
+	f.Requests.Set(request.GetPlayerId(), request)
+	f.GetPlayer().Send(msg)
+}
+
+// 处理好友申请
+func (f *Friends) OnFriendRequestOpReq(req *pb.FriendRequestOpReq) (*pb.FriendRequestOpRes, error) {
+	l := f.GetPlayer().Log
+	l.Debug("OnFriendRequestOpReq", "req", req)
+	request, ok := f.Requests.Get(req.GetPlayerId())
+	if !ok {
+		return nil, errors.New("RequestNotExists")
+	}
+	if req.GetIsAgree() && !f.Friends.Contains(request.GetPlayerId()) && len(f.Friends.Data) >= MaxFriendCount {
+		return nil, errors.New("FriendCountLimit")
+	}
+	f.Requests.Delete(request.GetPlayerId())
+	if req.GetIsAgree() {
+		f.addFriend(request.GetPlayerId(), request.GetName())
+	}
+	// 通知申请者
+	RoutePlayerPacket(request.GetPlayerId(), network.NewPacket(&pb.FriendRequestOpResult{
+		PlayerId:  f.GetPlayerId(),
+		Name:      f.GetPlayer().GetName(),
+		IsAgree:   req.GetIsAgree(),
+		Timestamp: f.now(),
+	}), WithSaveDb())
+	return &pb.FriendRequestOpRes{
+		PlayerId: request.GetPlayerId(),
+		IsAgree:  req.GetIsAgree(),
+	}, nil
+}
+
+// 自己的好友申请的处理结果
+func (f *Friends) HandleFriendRequestOpResult(msg *pb.FriendRequestOpResult) {
+	slog.Debug("Friends.HandleFriendRequestOpResult", "pid", f.GetPlayerId(), "msg", msg)
+	if msg.GetIsAgree() {
+		if !f.addFriend(msg.GetPlayerId(), msg.GetName()) && !f.Friends.Contains(msg.GetPlayerId()) {
+			// 自己的好友已满,通知对方解除好友关系,保持双方一致
+			RoutePlayerPacket(msg.GetPlayerId(), network.NewPacket(&pb.FriendRemoved{
+				PlayerId: f.GetPlayerId(),
+			}), WithSaveDb())
+			return
+		}
+		// 对方也可能在自己这里有申请
+		f.Requests.Delete(msg.GetPlayerId())
+	}
+	f.GetPlayer().Send(msg)
+}
+
+// 删除好友
+func (f *Friends) OnFriendRemoveReq(req *pb.FriendRemoveReq) (*pb.FriendRemoveRes, error) {
+	l := f.GetPlayer().Log
+	l.Debug("OnFriendRemoveReq", "req", req)
+	if !f.Friends.Contains(req.GetPlayerId()) {
+		return nil, errors.New("NotFriend")
+	}
+	f.Friends.Delete(req.GetPlayerId())
+	// 通知对方
+	RoutePlayerPacket(req.GetPlayerId(), network.NewPacket(&pb.FriendRemoved{
+		PlayerId: f.GetPlayerId(),
+	}), WithSaveDb())
+	return &pb.FriendRemoveRes{
+		PlayerId: req.GetPlayerId(),
+	}, nil
+}
+
+// 被好友删除
+func (f *Friends) HandleFriendRemoved(msg *pb.FriendRemoved) {
+	slog.Debug("Friends.HandleFriendRemoved", "pid", f.GetPlayerId(), "msg", msg)
+	if !f.Friends.Contains(msg.GetPlayerId()) {
+		return
+	}
+	f.Friends.Delete(msg.GetPlayerId())
+	f.GetPlayer().Send(msg)
+}
+
+// 查询好友在线状态
+func (f *Friends) OnFriendOnlineReq(req *pb.FriendOnlineReq) (*pb.FriendOnlineRes, error) {
+	friendIds := make([]int64, 0, len(f.Friends.Data))
+	for friendId := range f.Friends.Data {
+		friendIds = append(friendIds, friendId)
+	}
+	res := &pb.FriendOnlineRes{}
+	// 1次Redis Pipeline批量查询
+	for friendId := range cache.GetOnlinePlayers(friendIds) {
+		res.OnlinePlayerIds = append(res.OnlinePlayerIds, friendId)
+	}
+	return res, nil
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/fish-tennis/gserver/cache"
+	"github.com/fish-tennis/gserver/cfg"
+	"github.com/fish-tennis/gserver/db"
+	"github.com/fish-tennis/gserver/network"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+// 检查要发放的物品,物品必须存在,数量必须大于0
+func CheckItemArgs(cfgs *cfg.Snapshot, items []*pb.AddElemArg) bool {
+	for _, item := range items {
+		if item.GetNum() <= 0 || cfgs.ItemCfgs.GetCfg(item.GetCfgId()) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// GM发放物品(线程安全)
+//
+//	玩家离线:通过OfflinePlayerProcess直接修改数据库里的玩家数据
+//	玩家在线(或者离线处理期间上线了):路由给玩家协程处理,同时存入PendingMessages防止丢失
+func GrantItems(playerId int64, grant *pb.GrantItems) error {
+	if len(grant.GetItems()) == 0 {
+		return errors.New("ItemsEmpty")
+	}
+	if !CheckItemArgs(cfg.Get(), grant.GetItems()) {
+		return errors.New("ItemError")
+	}
+	if grant.GetReason() == "" || grant.GetOperator() == "" {
+		return errors.New("NeedReasonAndOperator")
+	}
+	accountId, err := db.GetPlayerDb().FindAccountIdByPlayerId(playerId)
+	if err != nil {
+		return errors.New("DbError")
+	}
+	if accountId == 0 {
+		return errors.New("PlayerNotExist")
+	}
+	slog.Info("GrantItems", "playerId", playerId, "grant", grant)
+	if _, gameServerId := cache.GetOnlinePlayer(playerId); gameServerId == 0 {
+		if OfflinePlayerProcess(playerId, &pb.PlayerData{}, func(offlinePlayerId int64, offlineData interface{}) bool {
+			return grantOfflinePlayerItems(offlinePlayerId, offlineData.(*pb.PlayerData), grant)
+		}) {
+			return nil
+		}
+	}
+	// 离线的玩家会返回false,但消息已经存入数据库了,下次上线时处理
+	RoutePlayerPacket(playerId, network.NewPacket(grant), WithSaveDb())
+	return nil
+}
+
+// 给离线玩家加物品,并保存到数据库
+func grantOfflinePlayerItems(playerId int64, playerData *pb.PlayerData, grant *pb.GrantItems) bool {
+	if playerData.XId == 0 {
+		playerData.XId = playerId
+	}
+	player := CreatePlayerFromData(playerData)
+	if player == nil {
+		return false
+	}
+	player.GetBags().AddItems(grant.GetItems())
+	// 清除缓存,防止玩家上线时用到缓存里的旧数据
+	if err := player.SaveDb(true); err != nil {
+		player.Log.Error("grantOfflinePlayerItems SaveDb error", "error", err)
+		return false
+	}
+	player.Log.Info("grantOfflinePlayerItems", "grant", grant)
+	return true
+}
+
+// 在线玩家收到GM发放的物品
+func (b *Bags) HandleGrantItems(msg *pb.GrantItems) {
+	b.AddItems(msg.GetItems())
+	b.GetPlayer().Log.Info("HandleGrantItems", "grant", msg)
+}
@@ -5,21 +5,33 @@ import (
 	"errors"
 	"log/slog"
 	"math"
+	"unicode/utf8"
 
 	"github.com/fish-tennis/gentity"
 	"github.com/fish-tennis/gnet"
+	"github.com/fish-tennis/gserver/cache"
 	"github.com/fish-tennis/gserver/db"
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/network"
 	"github.com/fish-tennis/gserver/pb"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"google.golang.org/protobuf/proto"
 )
 
 const (
 	ComponentNameGuild = "Guild"
+	// 公会列表每页的数量
+	GuildListPageSize = 10
+	// 公会名的最大字符数
+	GuildNameMaxLen = 20
+	// 公会搜索一次请求最多扫描的批次
+	guildSearchMaxScanTimes = 5
+)
+
+var (
+	// 公会成员上限(0表示不限制)
+	GuildMemberMaxCount int32 = 50
 )
 
 // 利用go的init进行组件的自动注册
@@ -62,42 +74,77 @@ func (g *Guild) SetGuildId(guildId int64) {
 }
 
 // 查询公会列表
+//
+//	数据来自redis中的公会摘要缓存,不访问mongodb
 func (g *Guild) OnGuildListReq(req *pb.GuildListReq) (*pb.GuildListRes, error) {
 	slog.Debug("Guild.OnGuildListReq")
 	// 校验 PageIndex,防止负值产生负 skip
 	if req.PageIndex < 0 {
 		return nil, errors.New("PageIndexError")
 	}
-	guildDb := db.GetGuildDb()
-	col := guildDb.(*gentity.MongoCollection).GetCollection()
-	pageSize := int64(10)
-	count, err := col.CountDocuments(context.Background(), bson.D{}, nil)
+	count, err := cache.GetGuildSummaryCount()
 	if err != nil {
-		slog.Error("Guild.OnGuildListReq: db error", "error", err)
-		return nil, errors.New("DbError")
-	}
-	cursor, dbErr := col.Find(context.Background(), bson.D{}, options.Find().SetSkip(pageSize*int64(req.PageIndex)).SetLimit(pageSize))
-	if dbErr != nil {
-		slog.Error("Guild.OnGuildListReq: db error", "error", dbErr)
-		return nil, errors.New("DbError")
-	}
-	defer cursor.Close(context.Background())
-	type guildBaseInfo struct {
-		BaseInfo *pb.GuildInfo `json:"baseinfo"`
+		return nil, errors.New("CacheError")
 	}
-	var guildInfos []*guildBaseInfo
-	err = cursor.All(context.Background(), &guildInfos)
+	guildInfos, err := cache.GetGuildSummaryPage(req.PageIndex, GuildListPageSize)
 	if err != nil {
-		slog.Error("Guild.OnGuildListReq: db error", "error", err)
-		return nil, errors.New("DbError")
+		return nil, errors.New("CacheError")
 	}
-	res := &pb.GuildListRes{
+	return &pb.GuildListRes{
 		PageIndex:  req.PageIndex,
-		PageCount:  int32(math.Ceil(float64(count) / float64(pageSize))),
-		GuildInfos: make([]*pb.GuildInfo, len(guildInfos), len(guildInfos)),
+		PageCount:  int32(math.Ceil(float64(count) / float64(GuildListPageSize))),
+		GuildInfos: guildInfos,
+	}, nil
+}
+
+// 搜索公会
+//
+//	按公会名前缀搜索,支持过滤条件和游标分页
+//	数据来自redis中的公会摘要缓存,不访问mongodb
+func (g *Guild) OnGuildSearchReq(req *pb.GuildSearchReq) (*pb.GuildSearchRes, error) {
+	slog.Debug("Guild.OnGuildSearchReq", "req", req)
+	if utf8.RuneCountInString(req.NamePrefix) > GuildNameMaxLen {
+		return nil, errors.New("NamePrefixTooLong")
 	}
-	for i, info := range guildInfos {
-		res.GuildInfos[i] = info.BaseInfo
+	res := &pb.GuildSearchRes{}
+	cursor := req.Cursor
+	// 有过滤条件时,一次可能需要扫描多批数据才能填满一页,限制扫描次数,防止一次请求扫描整个列表
+	for i := 0; i < guildSearchMaxScanTimes; i++ {
+		guildInfos, lastMember, hasMore, err := cache.ScanGuildSummaries(req.NamePrefix, cursor, GuildListPageSize)
+		if err != nil {
+			return nil, errors.New("CacheError")
+		}
+		// 默认从本批扫描到的最后一个索引继续,跳过摘要已删除的残留索引
+		nextCursor := lastMember
+		isFull := false
+		for index, guildInfo := range guildInfos {
+			if req.HasFreeSlot && guildInfo.MemberLimit > 0 && guildInfo.MemberCount >= guildInfo.MemberLimit {
+				continue
+			}
+			if guildInfo.Level < req.MinLevel {
+				continue
+			}
+			res.GuildInfos = append(res.GuildInfos, guildInfo)
+			if len(res.GuildInfos) >= GuildListPageSize {
+				isFull = true
+				if index < len(guildInfos)-1 {
+					// 本批还有没检查的公会,下次从这里继续
+					nextCursor = cache.GuildNameIndexMember(guildInfo)
+				}
+				break
+			}
+		}
+		cursor = nextCursor
+		if !hasMore && nextCursor == lastMember {
+			// 没有更多数据了
+			res.NextCursor = ""
+			break
+		}
+		// 页满或者扫描次数用完时,客户端可以用这个游标继续搜索
+		res.NextCursor = cursor
+		if isFull {
+			break
+		}
 	}
 	return res, nil
 }
@@ -109,6 +156,9 @@ func (g *Guild) OnGuildCreateReq(req *pb.GuildCreateReq) (*pb.GuildCreateRes, er
 	if g.Data.GuildId > 0 {
 		return nil, errors.New("AlreadyHaveGuild")
 	}
+	if req.Name == "" || utf8.RuneCountInString(req.Name) > GuildNameMaxLen {
+		return nil, errors.New("NameError")
+	}
 	// NOTE:如果玩家之前已经提交了一个加入其他联盟的请求,玩家又自己创建联盟
 	// 其他联盟的管理员又接受了该玩家的加入请求,如何防止该玩家同时存在于2个联盟?
 	// 利用mongodb加一个类似原子锁的操作
@@ -141,6 +191,8 @@ func (g *Guild) OnGuildCreateReq(req *pb.GuildCreateReq) (*pb.GuildCreateRes, er
 			Name:        req.Name,
 			Intro:       req.Intro,
 			MemberCount: 1,
+			Level:       1,
+			MemberLimit: GuildMemberMaxCount,
 		},
 		Members: make(map[int64]*pb.GuildMemberData),
 	}
@@ -170,6 +222,8 @@ func (g *Guild) OnGuildCreateReq(req *pb.GuildCreateReq) (*pb.GuildCreateRes, er
 		return nil, errors.New("ConcurrentError")
 	}
 	g.SetGuildId(newGuildData.Id)
+	// 加入公会摘要缓存,公会列表和搜索才能查到
+	cache.UpdateGuildSummary(newGuildData.BaseInfo)
 	slog.Debug("Guild.OnGuildCreateReq: created", "guildId", newGuildData.Id, "name", newGuildData.BaseInfo.Name)
 	return &pb.GuildCreateRes{
 		Id:   newGuildData.Id,
@@ -188,6 +242,16 @@ func (g *Guild) OnGuildJoinReq(req *pb.GuildJoinReq) (*pb.GuildJoinRes, error) {
 	return reply, err
 }
 
+// 取消自己的入会申请
+func (g *Guild) OnGuildJoinCancelReq(req *pb.GuildJoinCancelReq) (*pb.GuildJoinCancelRes, error) {
+	if req.GuildId <= 0 {
+		return nil, errors.New("IdError")
+	}
+	reply := new(pb.GuildJoinCancelRes)
+	err := g.RouteRpcToTargetGuild(req.GuildId, req, reply)
+	return reply, err
+}
+
 // 公会管理员处理申请者的入会申请
 func (g *Guild) OnGuildJoinAgreeReq(req *pb.GuildJoinAgreeReq) (*pb.GuildJoinAgreeRes, error) {
 	if g.Data.GuildId == 0 {
@@ -212,6 +276,154 @@ func (g *Guild) HandleGuildJoinReqOpResult(msg *pb.GuildJoinReqOpResult) {
 	g.GetPlayer().Send(msg)
 }
 
+// 退出公会
+func (g *Guild) OnGuildLeaveReq(req *pb.GuildLeaveReq) (*pb.GuildLeaveRes, error) {
+	if g.Data.GuildId == 0 {
+		return nil, errors.New("not a guild member")
+	}
+	reply := new(pb.GuildLeaveRes)
+	err := g.RouteRpcToSelfGuild(req, reply)
+	if err == nil {
+		// 公会服务器已通过 AtomicSetGuildId 原子写入 DB,玩家端只需更新本地内存状态
+		g.SetGuildId(0)
+	}
+	return reply, err
+}
+
+// 踢出公会成员
+func (g *Guild) OnGuildKickReq(req *pb.GuildKickReq) (*pb.GuildKickRes, error) {
+	if g.Data.GuildId == 0 {
+		return nil, errors.New("not a guild member")
+	}
+	reply := new(pb.GuildKickRes)
+	err := g.RouteRpcToSelfGuild(req, reply)
+	return reply, err
+}
+
+// 解散公会
+func (g *Guild) OnGuildDisbandReq(req *pb.GuildDisbandReq) (*pb.GuildDisbandRes, error) {
+	if g.Data.GuildId == 0 {
+		return nil, errors.New("not a guild member")
+	}
+	reply := new(pb.GuildDisbandRes)
+	err := g.RouteRpcToSelfGuild(req, reply)
+	if err == nil {
+		g.SetGuildId(0)
+	}
+	return reply, err
+}
+
+// 转让会长
+func (g *Guild) OnGuildTransferLeaderReq(req *pb.GuildTransferLeaderReq) (*pb.GuildTransferLeaderRes, error) {
+	if g.Data.GuildId == 0 {
+		return nil, errors.New("not a guild member")
+	}
+	reply := new(pb.GuildTransferLeaderRes)
+	err := g.RouteRpcToSelfGuild(req, reply)
+	return reply, err
+}
+
+// 设置公会成员的职位
+func (g *Guild) OnGuildSetPositionReq(req *pb.GuildSetPositionReq) (*pb.GuildSetPositionRes, error) {
+	if g.Data.GuildId == 0 {
+		return nil, errors.New("not a guild member")
+	}
+	reply := new(pb.GuildSetPositionRes)
+	err := g.RouteRpcToSelfGuild(req, reply)
+	return reply, err
+}
+
+// 公会捐献
+//
+//	先扣除玩家的物品,再路由到公会所在服务器增加公会经验和成员贡献
+func (g *Guild) OnGuildDonateReq(req *pb.GuildDonateReq) (*pb.GuildDonateRes, error) {
+	l := g.GetPlayer().Log
+	l.Debug("OnGuildDonateReq", "req", req)
+	if g.Data.GuildId == 0 {
+		return nil, errors.New("not a guild member")
+	}
+	donateCfg := g.GetPlayer().GetCfg().GuildDonateCfgs.GetCfg(req.DonateCfgId)
+	if donateCfg == nil {
+		return nil, errors.New("donateCfg nil")
+	}
+	bags := g.GetPlayer().GetBags()
+	if !bags.IsEnoughByItemNums(donateCfg.Consumes) {
+		return nil, errors.New("ConsumeItemsNotEnough")
+	}
+	bags.DelItemsByItemNums(donateCfg.Consumes)
+	reply := new(pb.GuildDonateRes)
+	err := g.RouteRpcToSelfGuild(req, reply)
+	if err != nil {
+		// 只有公会明确拒绝时才退还扣除的物品
+		// 超时等错误无法确定公会是否已经处理,退还物品可能导致公会经验和物品都拿到了
+		var rejectErr *GuildRejectError
+		if errors.As(err, &rejectErr) {
+			l.Error("OnGuildDonateReq rejected, refund", "donateCfgId", req.DonateCfgId, "err", err)
+			bags.AddItemsByItemNums(donateCfg.Consumes)
+		} else {
+			l.Error("OnGuildDonateReq rpc error", "donateCfgId", req.DonateCfgId, "err", err)
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+// 公会商店购买
+//
+//	复用兑换配置,玩家自身的条件在本服检查,公会条件(公会等级,成员贡献等)到公会所在服务器检查
+func (g *Guild) OnGuildShopBuyReq(req *pb.GuildShopBuyReq) (*pb.GuildShopBuyRes, error) {
+	l := g.GetPlayer().Log
+	l.Debug("OnGuildShopBuyReq", "req", req)
+	if g.Data.GuildId == 0 {
+		return nil, errors.New("not a guild member")
+	}
+	if g.GetPlayer().IsTradeFrozen() {
+		return nil, errors.New("TradeFrozen")
+	}
+	exchange := g.GetPlayer().GetExchange()
+	err := exchange.exchange(req.ExchangeCfgId, req.Count, func(exchangeCfg *pb.ExchangeCfg) error {
+		if exchangeCfg.Category != int32(pb.ExchangeCategory_ExchangeCategory_GuildShop) {
+			return errors.New("not a guild shop item")
+		}
+		return g.RouteRpcToSelfGuild(req, new(pb.GuildShopBuyRes))
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.GuildShopBuyRes{
+		ExchangeCfgId: req.ExchangeCfgId,
+		Count:         req.Count,
+	}
+	if records := exchange.GetRecordsByIds(req.ExchangeCfgId); len(records) > 0 {
+		res.Record = records[0]
+	}
+	return res, nil
+}
+
+// 被踢出公会或者公会被解散
+//
+//	玩家不在线时,该消息会保存到PendingMessages,上线时再处理
+func (g *Guild) HandleGuildMemberRemoved(msg *pb.GuildMemberRemoved) {
+	slog.Debug("Guild.HandleGuildMemberRemoved", "msg", msg)
+	// 公会服务器已通过 AtomicSetGuildId 原子写入 DB,玩家端只需更新本地内存状态
+	if g.Data.GuildId == msg.GuildId {
+		g.SetGuildId(0)
+	}
+	g.GetPlayer().Send(msg)
+}
+
+// 事件接口
+func (g *Guild) TriggerPlayerEntryGame(event *internal.EventPlayerEntryGame) {
+	// 上线时同步一次公会成员的名字,防止改名时公会服务器不可用导致成员数据里还是旧名字
+	if g.Data.GuildId > 0 {
+		g.RoutePacketToGuild(gnet.PacketCommand(network.GetCommandByProto(&pb.GuildMemberRenameReq{})), &pb.GuildMemberRenameReq{})
+	}
+}
+
+// 上线时同步公会成员名字的返回结果,不需要处理
+func (g *Guild) HandleGuildMemberRenameRes(res *pb.GuildMemberRenameRes) {
+}
+
 // 公会成员的客户端的请求消息路由到自己的公会所在服务器
 func (g *Guild) RoutePacketToGuild(cmd gnet.PacketCommand, message proto.Message) bool {
 	slog.Debug("Guild.RoutePacketToGuild", "cmd", cmd, "playerId", g.GetPlayerId(), "guildId", g.Data.GuildId)
@@ -221,6 +433,17 @@ func (g *Guild) RoutePacketToGuild(cmd gnet.PacketCommand, message proto.Message
 	return internal.GetServerList().SendPacket(internal.RouteGuildServerId(g.Data.GuildId), routePacket)
 }
 
+// 公会服务明确拒绝了请求(公会服务的消息回调返回了error)
+//
+//	和超时等错误区分开,超时的请求公会服务可能已经处理过了
+type GuildRejectError struct {
+	Reason string
+}
+
+func (e *GuildRejectError) Error() string {
+	return e.Reason
+}
+
 // 客户端的请求消息路由到目标公会所在服务器,并阻塞等待返回结果
 func (g *Guild) RouteRpcToTargetGuild(targetGuildId int64, message proto.Message, reply proto.Message) error {
 	// 转换成给公会服务的路由消息,附带上玩家信息
@@ -236,7 +459,7 @@ func (g *Guild) RouteRpcToTargetGuild(targetGuildId int64, message proto.Message
 	if err == nil {
 		if routePlayerMessage.Error != "" {
 			slog.Error("Guild.RouteRpcToTargetGuild error", "toServerId", toServerId, "error", routePlayerMessage.Error)
-			return errors.New(routePlayerMessage.Error)
+			return &GuildRejectError{Reason: routePlayerMessage.Error}
 		}
 		err = routePlayerMessage.PacketData.UnmarshalTo(reply)
 		if err != nil {
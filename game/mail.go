@@ -0,0 +1,285 @@
+package game
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gentity/util"
+	"github.com/fish-tennis/gserver/cfg"
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/network"
+	"github.com/fish-tennis/gserver/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// 组件名
+	ComponentNameMail = "Mail"
+	// 邮件默认有效期
+	MailDefaultExpireDuration = time.Hour * 24 * 30
+)
+
+// 利用go的init进行组件的自动注册
+func init() {
+	_playerComponentRegister.Register(ComponentNameMail, 0, func(player *Player, _ any) gentity.Component {
+		return &Mail{
+			BasePlayerComponent: BasePlayerComponent{
+				player: player,
+				name:   ComponentNameMail,
+			},
+			Mails:      gentity.NewMapData[int64, *pb.MailData](),
+			SystemMail: gentity.NewProtoData(&pb.MailSystemData{}),
+		}
+	})
+}
+
+// 邮件模块
+type Mail struct {
+	BasePlayerComponent
+	// 保存数据的子模块:邮件列表
+	Mails *gentity.MapData[int64, *pb.MailData] `child:"Mails"`
+	// 保存数据的子模块:系统邮件的拉取记录
+	SystemMail *gentity.ProtoData[*pb.MailSystemData] `child:"SystemMail;plain"`
+}
+
+func (p *Player) GetMail() *Mail {
+	return p.GetComponentByName(ComponentNameMail).(*Mail)
+}
+
+func (m *Mail) SyncDataToClient() {
+	m.GetPlayer().Send(&pb.MailSync{
+		Mails: m.Mails.Data,
+	})
+}
+
+// 给玩家发邮件(线程安全)
+//
+//	不管玩家是否在线,邮件都先保存到玩家的PendingMessages,防止丢失
+//	玩家在线:路由到玩家所在的服务器,由玩家协程处理
+//	玩家离线:玩家下次上线时,从PendingMessages取出处理
+//	返回ErrRouteSaveDb时邮件没有发出,返回其他路由错误时邮件已经保存,玩家下次上线时会收到
+func SendMail(toPlayerId int64, mail *pb.MailData) error {
+	if err := CheckMailAttachments(cfg.Get(), mail.GetAttachments()); err != nil {
+		return err
+	}
+	if mail.MailId == 0 {
+		mail.MailId = util.GenUniqueId()
+	}
+	now := time.Now()
+	if mail.SendTime == 0 {
+		mail.SendTime = int32(now.Unix())
+	}
+	if mail.ExpireTime == 0 {
+		mail.ExpireTime = int32(now.Add(MailDefaultExpireDuration).Unix())
+	}
+	slog.Debug("SendMail", "toPlayerId", toPlayerId, "mailId", mail.MailId, "title", mail.Title)
+	err := routePlayerPacket(toPlayerId, network.NewPacket(&pb.MailAdd{Mail: mail}), WithSaveDb())
+	// 离线玩家的邮件已经存入数据库了,上线时处理
+	if errors.Is(err, ErrRoutePlayerOffline) {
+		return nil
+	}
+	return err
+}
+
+// 检查邮件附件,和GM发放物品的检查一样
+//
+//	不能发放的附件会导致邮件无法领取,也就无法删除
+func CheckMailAttachments(cfgs *cfg.Snapshot, attachments []*pb.AddElemArg) error {
+	if !CheckItemArgs(cfgs, attachments) {
+		return errors.New("AttachmentError")
+	}
+	return nil
+}
+
+func (m *Mail) isExpired(mail *pb.MailData, now int32) bool {
+	return mail.GetExpireTime() > 0 && mail.GetExpireTime() <= now
+}
+
+// 添加邮件
+func (m *Mail) AddMail(mail *pb.MailData) bool {
+	if m.Mails.Contains(mail.GetMailId()) {
+		// 同一封邮件可能被重复投递(如PendingMessages重试)
+		slog.Debug("AddMail duplicate", "pid", m.GetPlayerId(), "mailId", mail.GetMailId())
+		return false
+	}
+	if m.isExpired(mail, int32(m.GetPlayer().GetTimerEntries().Now().Unix())) {
+		slog.Debug("AddMail expired", "pid", m.GetPlayerId(), "mailId", mail.GetMailId())
+		return false
+	}
+	m.Mails.Set(mail.GetMailId(), mail)
+	m.GetPlayer().Send(&pb.MailAdd{Mail: mail})
+	slog.Debug("AddMail", "pid", m.GetPlayerId(), "mailId", mail.GetMailId(), "title", mail.GetTitle())
+	return true
+}
+
+// 删除过期邮件
+func (m *Mail) checkExpire(now int32) {
+	var expiredIds []int64
+	m.Mails.Range(func(mailId int64, mail *pb.MailData) bool {
+		if m.isExpired(mail, now) {
+			expiredIds = append(expiredIds, mailId)
+		}
+		return true
+	})
+	if len(expiredIds) == 0 {
+		return
+	}
+	for _, mailId := range expiredIds {
+		m.Mails.Delete(mailId)
+	}
+	m.GetPlayer().Send(&pb.MailRemove{
+		MailIds: expiredIds,
+	})
+	slog.Debug("Mail.checkExpire", "pid", m.GetPlayerId(), "expiredIds", expiredIds)
+}
+
+// 拉取新的系统邮件
+//
+//	系统邮件id是递增的,只拉取id大于LastSystemMailId的系统邮件,然后推进LastSystemMailId
+//	拉取过的系统邮件不会再拉取,即使当时不满足过滤条件
+func (m *Mail) pullSystemMails() {
+	globalEntity := GetGlobalEntity()
+	if globalEntity == nil {
+		return
+	}
+	systemMailComponent := globalEntity.GetSystemMail()
+	lastSystemMailId := m.SystemMail.Data.GetLastSystemMailId()
+	systemMails := systemMailComponent.GetMailsAfter(lastSystemMailId)
+	if len(systemMails) == 0 {
+		return
+	}
+	now := int32(m.GetPlayer().GetTimerEntries().Now().Unix())
+	for _, systemMail := range systemMails {
+		lastSystemMailId = max(lastSystemMailId, systemMail.GetSystemMailId())
+		if isSystemMailExpired(systemMail, now) {
+			continue
+		}
+		if !systemMailComponent.CheckFilter(m.GetPlayer(), systemMail) {
+			continue
+		}
+		// 系统邮件是共享的只读数据,需要拷贝
+		mail := proto.Clone(systemMail.GetMail()).(*pb.MailData)
+		mail.MailId = util.GenUniqueId()
+		m.AddMail(mail)
+	}
+	m.SystemMail.Data.LastSystemMailId = lastSystemMailId
+	m.SystemMail.SetDirty()
+	slog.Debug("Mail.pullSystemMails", "pid", m.GetPlayerId(), "lastSystemMailId", lastSystemMailId)
+}
+
+// 事件接口
+func (m *Mail) TriggerPlayerEntryGame(event *internal.EventPlayerEntryGame) {
+	m.checkExpire(int32(m.GetPlayer().GetTimerEntries().Now().Unix()))
+	m.pullSystemMails()
+	// 过期检查和在线期间新增的系统邮件
+	m.GetPlayer().AfterTimer(time.Minute, func() time.Duration {
+		m.checkExpire(int32(m.GetPlayer().GetTimerEntries().Now().Unix()))
+		m.pullSystemMails()
+		return time.Minute
+	})
+}
+
+// 收到新邮件
+//
+//	这种格式写的函数可以自动注册非客户端的消息回调
+func (m *Mail) HandleMailAdd(msg *pb.MailAdd) {
+	if msg.GetMail() == nil {
+		return
+	}
+	m.AddMail(msg.GetMail())
+}
+
+// 阅读邮件
+func (m *Mail) OnMailReadReq(req *pb.MailReadReq) (*pb.MailReadRes, error) {
+	mail, ok := m.Mails.Get(req.GetMailId())
+	if !ok {
+		return nil, errors.New("MailNotExists")
+	}
+	if !mail.IsRead {
+		mail.IsRead = true
+		m.Mails.Set(mail.GetMailId(), mail)
+	}
+	return &pb.MailReadRes{
+		MailId: req.GetMailId(),
+	}, nil
+}
+
+// 领取邮件附件
+func (m *Mail) OnMailClaimReq(req *pb.MailClaimReq) (*pb.MailClaimRes, error) {
+	res := &pb.MailClaimRes{}
+	now := int32(m.GetPlayer().GetTimerEntries().Now().Unix())
+	for _, mailId := range req.GetMailIds() {
+		mail, ok := m.Mails.Get(mailId)
+		if !ok || mail.IsClaimed || len(mail.GetAttachments()) == 0 {
+			continue
+		}
+		if m.isExpired(mail, now) {
+			continue
+		}
+		mail.IsRead = true
+		// 背包放不下的附件留在邮件里,腾出空间后可以再次领取
+		remains := m.addAttachments(mail.GetAttachments())
+		if len(remains) > 0 {
+			mail.Attachments = remains
+			m.Mails.Set(mailId, mail)
+			// 用剩余的附件覆盖客户端的邮件数据
+			m.GetPlayer().Send(&pb.MailAdd{
+				Mail: mail,
+			})
+			slog.Debug("OnMailClaimReq bag full", "pid", m.GetPlayerId(), "mailId", mailId, "remains", remains)
+			continue
+		}
+		mail.IsClaimed = true
+		m.Mails.Set(mailId, mail)
+		res.MailIds = append(res.MailIds, mailId)
+		slog.Debug("OnMailClaimReq", "pid", m.GetPlayerId(), "mailId", mailId)
+	}
+	return res, nil
+}
+
+// 发放附件,返回没有放进背包的附件
+//
+//	永远无法发放的附件(物品不存在或者数量不对)直接丢弃,不然邮件就无法领取和删除了
+func (m *Mail) addAttachments(attachments []*pb.AddElemArg) []*pb.AddElemArg {
+	bags := m.GetPlayer().GetBags()
+	itemCfgs := m.GetPlayer().GetCfg().ItemCfgs
+	bagUpdate := &pb.ElemContainerUpdate{}
+	var remains []*pb.AddElemArg
+	for _, attachment := range attachments {
+		if attachment.GetNum() <= 0 || itemCfgs.GetCfg(attachment.GetCfgId()) == nil || bags.GetBagByArg(attachment) == nil {
+			slog.Error("addAttachments drop", "pid", m.GetPlayerId(), "attachment", attachment)
+			continue
+		}
+		addCount := bags.AddItem(attachment, bagUpdate)
+		if addCount < attachment.GetNum() {
+			remain := proto.Clone(attachment).(*pb.AddElemArg)
+			remain.Num -= addCount
+			remains = append(remains, remain)
+		}
+	}
+	if len(bagUpdate.ElemOps) > 0 {
+		m.GetPlayer().Send(bagUpdate)
+	}
+	return remains
+}
+
+// 删除邮件
+//
+//	有未领取附件的邮件不能删除
+func (m *Mail) OnMailDeleteReq(req *pb.MailDeleteReq) (*pb.MailDeleteRes, error) {
+	res := &pb.MailDeleteRes{}
+	for _, mailId := range req.GetMailIds() {
+		mail, ok := m.Mails.Get(mailId)
+		if !ok {
+			continue
+		}
+		if len(mail.GetAttachments()) > 0 && !mail.IsClaimed {
+			continue
+		}
+		m.Mails.Delete(mailId)
+		res.MailIds = append(res.MailIds, mailId)
+	}
+	return res, nil
+}
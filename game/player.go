@@ -3,11 +3,13 @@ package game
 import (
 	"log/slog"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/fish-tennis/gentity"
 	. "github.com/fish-tennis/gnet"
 	"github.com/fish-tennis/gserver/cache"
+	"github.com/fish-tennis/gserver/cfg"
 	"github.com/fish-tennis/gserver/db"
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/network"
@@ -53,6 +55,14 @@ type Player struct {
 	// 进度事件映射
 	progressEventMapping *ProgressEventMapping
 	Log                  *slog.Logger // slog.With("pid", p.GetId())
+	// 消息队列里待处理的消息数量,用于统计游戏服的负载
+	pendingMessageCount atomic.Int32
+	// 生效中的处罚(禁言,冻结交易)
+	sanctions []*pb.Sanction
+	// 当前处理流程使用的配置数据,处理完一条消息或者一次定时器后清空
+	cfgs *cfg.Snapshot
+	// 进度映射等数据所使用的配置数据版本
+	cfgVersion int64
 }
 
 // 玩家名(unique)
@@ -130,6 +140,26 @@ func (p *Player) Kick() {
 	}
 }
 
+// playerDrainMessage 服务器排空内部消息,在玩家协程内消费
+type playerDrainMessage struct {
+	serverId int32
+}
+
+// Drain 服务器排空时,让玩家保存数据后到其他游戏服重新登录
+// 使用 TryPushMessage 非阻塞投递,channel 满时返回false,由调用方稍后重试
+func (p *Player) Drain(serverId int32) bool {
+	return p.TryPushMessage(&playerDrainMessage{serverId: serverId})
+}
+
+// playerCfgReloadedMessage 配置数据重新加载完成的内部消息,在玩家协程内消费
+type playerCfgReloadedMessage struct{}
+
+// CfgReloaded 通知玩家协程配置数据已经重新加载(线程安全)
+// channel 满时返回false,玩家处理下一条消息时也会检查配置版本(beginCfgStep)
+func (p *Player) CfgReloaded() bool {
+	return p.TryPushMessage(&playerCfgReloadedMessage{})
+}
+
 // PlayerDirectSendMessage 直接转发给客户端的消息,由网络协程投递,在玩家协程内消费
 // 避免 DirectSendClient 路径在网络协程中直接读 p.connection,与玩家协程的 ResetConnection 竞争
 type PlayerDirectSendMessage struct {
@@ -144,6 +174,35 @@ type playerCheckConnectionMessage struct {
 	packet     *ProtoPacket
 }
 
+// 重写消息投递接口,统计消息队列里待处理的消息数量
+func (p *Player) PushMessage(message any) {
+	p.pendingMessageCount.Add(1)
+	p.BaseRoutineEntity.PushMessage(message)
+}
+
+func (p *Player) TryPushMessage(message any) bool {
+	p.pendingMessageCount.Add(1)
+	if !p.BaseRoutineEntity.TryPushMessage(message) {
+		p.pendingMessageCount.Add(-1)
+		return false
+	}
+	return true
+}
+
+func (p *Player) PushMessageTimeout(message any, timeout time.Duration) bool {
+	p.pendingMessageCount.Add(1)
+	if !p.BaseRoutineEntity.PushMessageTimeout(message, timeout) {
+		p.pendingMessageCount.Add(-1)
+		return false
+	}
+	return true
+}
+
+// 消息队列里待处理的消息数量
+func (p *Player) GetPendingMessageCount() int32 {
+	return p.pendingMessageCount.Load()
+}
+
 // DirectSendClient 由网络协程调用,投递到玩家协程内执行 SendWithCommand
 func (p *Player) DirectSendClient(cmd PacketCommand, message proto.Message) {
 	p.PushMessage(&PlayerDirectSendMessage{Cmd: cmd, Message: message})
@@ -220,7 +279,7 @@ func (p *Player) onDisconnect(connection Connection) {
 		p.reconnectWaitTimerActive = true
 		p.reconnectWaitGen++
 		gen := p.reconnectWaitGen
-		p.GetTimerEntries().After(ReconnectWaitSeconds*time.Second, func() time.Duration {
+		p.AfterTimer(ReconnectWaitSeconds*time.Second, func() time.Duration {
 			// 检查代际:如果重连成功后再次断线,旧定时器的 gen 不匹配,直接忽略
 			if !p.reconnectWaitTimerActive || p.reconnectWaitGen != gen {
 				return 0
@@ -232,6 +291,59 @@ func (p *Player) onDisconnect(connection Connection) {
 	}
 }
 
+// GetCfg 获取当前处理流程使用的配置数据
+// 同一条消息(或者同一次定时器)的处理过程中,即使配置重新加载了,获取到的也是同一个快照
+func (p *Player) GetCfg() *cfg.Snapshot {
+	if p.cfgs == nil {
+		p.cfgs = cfg.Get()
+		// 创建玩家时,进度映射等数据使用的是这个版本的配置
+		if p.cfgVersion == 0 {
+			p.cfgVersion = p.cfgs.Version
+		}
+	}
+	return p.cfgs
+}
+
+// beginCfgStep 处理消息前获取当前的配置数据,配置版本有变化时,先更新进度映射等数据
+func (p *Player) beginCfgStep() {
+	p.cfgs = cfg.Get()
+	if p.cfgVersion == p.cfgs.Version {
+		return
+	}
+	if p.cfgVersion != 0 {
+		p.onCfgReloaded(p.cfgVersion)
+	}
+	p.cfgVersion = p.cfgs.Version
+}
+
+// AfterTimer 添加玩家的定时器
+//
+//	定时器回调前先调用beginCfgStep,和处理消息时一样,配置版本有变化时先更新进度映射等数据
+//	定时器回调之后由AfterTimerExecuteFunc调用endCfgStep
+func (p *Player) AfterTimer(d time.Duration, f func() time.Duration) {
+	p.GetTimerEntries().After(d, func() time.Duration {
+		p.beginCfgStep()
+		return f()
+	})
+}
+
+func (p *Player) endCfgStep() {
+	p.cfgs = nil
+}
+
+// onCfgReloaded 配置数据重新加载后,在玩家协程内调用
+// 进度映射里保存的是旧的配置对象,先清空,再由各模块响应EventCfgReloaded重新添加
+func (p *Player) onCfgReloaded(oldVersion int64) {
+	p.progressEventMapping.Reset()
+	p.FireEvent(&internal.EventCfgReloaded{
+		OldVersion: oldVersion,
+		Version:    p.cfgs.Version,
+	})
+	p.firePostedEvents()
+	p.SaveCache(cache.Get())
+	p.Log.Info("onCfgReloaded", "oldVersion", oldVersion, "version", p.cfgs.Version)
+}
+
 // CancelReconnectWait 取消断线保留期定时器,由重连处理器在玩家协程内调用
 func (p *Player) CancelReconnectWait() {
 	p.reconnectWaitTimerActive = false
@@ -473,6 +585,9 @@ func (p *Player) RunRoutine() bool {
 			p.firePostedEvents()
 		},
 		ProcessMessageFunc: func(routineEntity gentity.RoutineEntity, message any) {
+			p.pendingMessageCount.Add(-1)
+			p.beginCfgStep()
+			defer p.endCfgStep()
 			switch msg := message.(type) {
 			case *ProtoPacket:
 				p.processMessage(msg)
@@ -486,6 +601,18 @@ func (p *Player) RunRoutine() bool {
 			case *playerKickMessage:
 				p.ResetConnection()
 				p.Stop()
+			case *playerDrainMessage:
+				// 先保存数据库,玩家到其他游戏服登录时才能读取到最新的数据
+				if err := p.SaveDb(false); err != nil {
+					slog.Error("DrainSaveDbErr", "playerId", p.GetId(), "err", err)
+				}
+				p.Send(&pb.ServerDrainingNotify{
+					ServerId: msg.serverId,
+				})
+				p.ResetConnection()
+				p.Stop()
+			case *playerCfgReloadedMessage:
+				// beginCfgStep已经检查过配置版本,这里不需要再处理
 			case *PlayerDirectSendMessage:
 				p.SendWithCommand(msg.Cmd, msg.Message)
 			case *playerCheckConnectionMessage:
@@ -500,11 +627,12 @@ func (p *Player) RunRoutine() bool {
 			p.firePostedEvents()
 			// 如果有需要保存的数据修改了,即时保存数据库
 			p.SaveCache(cache.Get())
+			p.endCfgStep()
 		},
 	})
 	if ok {
 		// 每分钟执行一次,刷新在线时间
-		p.GetTimerEntries().After(time.Minute, func() time.Duration {
+		p.AfterTimer(time.Minute, func() time.Duration {
 			evt := &pb.EventPlayerProperty{
 				PlayerId: p.GetId(),
 				Property: "OnlineMinute",
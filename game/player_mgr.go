@@ -1,7 +1,14 @@
 package game
 
 import (
+	"log/slog"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gnet"
+	"github.com/fish-tennis/gserver/cache"
 	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/network"
+	"github.com/fish-tennis/gserver/pb"
 )
 
 var _playerMgr internal.PlayerMgr
@@ -21,3 +28,33 @@ func GetPlayer(playerId int64) *Player {
 	}
 	return player.(*Player)
 }
+
+// 把账号的在线角色踢下线(线程安全),不在本服时通过KickPlayerReq通知所在的游戏服
+//
+//	playerId>0时,只有在线的是该角色才踢,返回是否有在线的角色被踢
+func KickOnlineAccount(accountId, playerId int64) bool {
+	onlinePlayerId, gameServerId := cache.GetOnlineAccount(accountId)
+	if onlinePlayerId == 0 || gameServerId == 0 {
+		return false
+	}
+	if playerId > 0 && playerId != onlinePlayerId {
+		return false
+	}
+	if gameServerId == gentity.GetApplication().GetId() {
+		player := GetPlayer(onlinePlayerId)
+		if player == nil {
+			return false
+		}
+		player.Kick()
+		return true
+	}
+	cmd := gnet.PacketCommand(network.GetCommandByProto(new(pb.KickPlayerReq)))
+	if !internal.GetServerList().Send(gameServerId, cmd, &pb.KickPlayerReq{
+		AccountId: accountId,
+		PlayerId:  onlinePlayerId,
+	}) {
+		slog.Error("KickOnlineAccount send failed", "accountId", accountId, "playerId", onlinePlayerId, "gameServerId", gameServerId)
+		return false
+	}
+	return true
+}
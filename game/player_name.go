@@ -0,0 +1,186 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gserver/db"
+	"github.com/fish-tennis/gserver/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	// 玩家名的最大字符数
+	PlayerNameMaxLen = 20
+)
+
+var (
+	// 改名消耗的物品
+	RenameCostItemId  = int32(pb.ItemId_ItemId_Coin)
+	RenameCostItemNum = int32(100)
+)
+
+// 检查玩家名
+func CheckPlayerName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > PlayerNameMaxLen {
+		return errors.New("NameLengthError")
+	}
+	if strings.TrimSpace(name) != name || !utf8.ValidString(name) {
+		return errors.New("NameError")
+	}
+	return nil
+}
+
+func getPlayerNameCollection() *mongo.Collection {
+	return db.GetDbMgr().GetEntityDb(db.PlayerNameDbName).(*gentity.MongoCollection).GetCollection()
+}
+
+func getPlayerNameHistoryCollection() *mongo.Collection {
+	return db.GetDbMgr().GetEntityDb(db.PlayerNameHistoryDbName).(*gentity.MongoCollection).GetCollection()
+}
+
+// 占用玩家名,利用_id的唯一索引保证原子性,已经被其他玩家占用时返回false
+func ReservePlayerName(name string, playerId int64) (bool, error) {
+	_, err := getPlayerNameCollection().InsertOne(context.Background(), bson.D{
+		{db.UniqueIdName, name},
+		{db.PlayerNamePlayerId, playerId},
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		slog.Error("ReservePlayerName error", "name", name, "playerId", playerId, "error", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// 释放玩家名,只释放自己占用的
+func ReleasePlayerName(name string, playerId int64) {
+	_, err := getPlayerNameCollection().DeleteOne(context.Background(), bson.D{
+		{db.UniqueIdName, name},
+		{db.PlayerNamePlayerId, playerId},
+	})
+	if err != nil {
+		slog.Error("ReleasePlayerName error", "name", name, "playerId", playerId, "error", err)
+	}
+}
+
+// 玩家名是否已经被使用
+//
+//	占用表之前创建的角色,名字不在占用表里,所以还要查一下玩家表
+func isPlayerNameUsed(name string) (bool, error) {
+	col := db.GetDbMgr().GetEntityDb(db.PlayerDbName).(*gentity.MongoCollection).GetCollection()
+	count, err := col.CountDocuments(context.Background(), bson.D{{db.PlayerName, name}}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// 记录改名
+func AddPlayerNameHistory(record *pb.PlayerNameRecord) {
+	_, err := getPlayerNameHistoryCollection().InsertOne(context.Background(), bson.D{
+		{db.PlayerNamePlayerId, record.PlayerId},
+		{"OldName", record.OldName},
+		{"NewName", record.NewName},
+		{"Timestamp", record.Timestamp},
+	})
+	if err != nil {
+		slog.Error("AddPlayerNameHistory error", "record", record, "error", err)
+	}
+}
+
+// 查询改名记录,供GM使用
+//
+//	playerId>0时查询该玩家的改名记录,否则查询用过该名字的玩家的改名记录
+func FindPlayerNameHistory(playerId int64, name string) ([]*pb.PlayerNameRecord, error) {
+	filter := bson.D{{db.PlayerNamePlayerId, playerId}}
+	if playerId == 0 {
+		filter = bson.D{{"$or", bson.A{
+			bson.D{{"OldName", name}},
+			bson.D{{"NewName", name}},
+		}}}
+	}
+	cursor, err := getPlayerNameHistoryCollection().Find(context.Background(), filter,
+		options.Find().SetSort(bson.D{{"Timestamp", 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var records []*pb.PlayerNameRecord
+	err = cursor.All(context.Background(), &records)
+	return records, err
+}
+
+// 改名
+//
+//	先占用新名字,再修改玩家表,最后扣除物品和释放旧名字
+func (b *BaseInfo) OnPlayerRenameReq(req *pb.PlayerRenameReq) (*pb.PlayerRenameRes, error) {
+	player := b.GetPlayer()
+	player.Log.Debug("OnPlayerRenameReq", "name", req.Name)
+	if err := CheckPlayerName(req.Name); err != nil {
+		return nil, err
+	}
+	oldName := player.GetName()
+	if req.Name == oldName {
+		return nil, errors.New("SameName")
+	}
+	cost := []*pb.DelElemArg{{CfgId: RenameCostItemId, Num: RenameCostItemNum}}
+	if !player.GetBags().IsEnough(cost) {
+		return nil, errors.New("ItemNotEnough")
+	}
+	used, err := isPlayerNameUsed(req.Name)
+	if err != nil {
+		player.Log.Error("isPlayerNameUsed error", "name", req.Name, "error", err)
+		return nil, errors.New("DbError")
+	}
+	if used {
+		return nil, errors.New("NameDuplicate")
+	}
+	ok, err := ReservePlayerName(req.Name, player.GetId())
+	if err != nil {
+		return nil, errors.New("DbError")
+	}
+	if !ok {
+		return nil, errors.New("NameDuplicate")
+	}
+	col := db.GetDbMgr().GetEntityDb(db.PlayerDbName).(*gentity.MongoCollection).GetCollection()
+	_, err = col.UpdateOne(context.Background(), bson.D{{db.UniqueIdName, player.GetId()}},
+		bson.D{{"$set", bson.D{{db.PlayerName, req.Name}}}})
+	if err != nil {
+		ReleasePlayerName(req.Name, player.GetId())
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.New("NameDuplicate")
+		}
+		player.Log.Error("rename error", "name", req.Name, "error", err)
+		return nil, errors.New("DbError")
+	}
+	player.GetBags().DelItems(cost)
+	player.name = req.Name
+	ReleasePlayerName(oldName, player.GetId())
+	AddPlayerNameHistory(&pb.PlayerNameRecord{
+		PlayerId:  player.GetId(),
+		OldName:   oldName,
+		NewName:   req.Name,
+		Timestamp: time.Now().Unix(),
+	})
+	player.Log.Info("rename", "oldName", oldName, "newName", req.Name)
+	// 同步公会成员的名字,路由消息里带的玩家名就是新名字
+	if player.GetGuild().GetGuildData().GetGuildId() > 0 {
+		if err = player.GetGuild().RouteRpcToSelfGuild(&pb.GuildMemberRenameReq{}, new(pb.GuildMemberRenameRes)); err != nil {
+			// 公会服务器暂时不可用时,公会成员数据里还是旧名字,不影响改名结果,
+			// 下次上线或者发消息给公会时会再同步
+			player.Log.Warn("GuildMemberRename error", "error", err)
+		}
+	}
+	return &pb.PlayerRenameRes{
+		Name: req.Name,
+	}, nil
+}
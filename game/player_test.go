@@ -253,7 +253,7 @@ func TestQuest(t *testing.T) {
 	collectQuestId := int32(7) // 收集任务
 	collectQuest, _ := q.Quests.Get(collectQuestId)
 	if collectQuest != nil {
-		questCfg := cfg.Quests.GetCfg(collectQuestId)
+		questCfg := cfg.Get().Quests.GetCfg(collectQuestId)
 		canFinish := q.CanFinish(collectQuest, questCfg)
 		t.Logf("questId:%v canFinish:%v", collectQuestId, canFinish)
 		if !canFinish {
@@ -265,7 +265,7 @@ func TestQuest(t *testing.T) {
 
 	// 活动4:在线奖励
 	activityId := int32(4)
-	activityCfg := cfg.ActivityCfgs.GetCfg(activityId)
+	activityCfg := cfg.Get().ActivityCfgs.GetCfg(activityId)
 	activity := player.GetActivities().AddNewActivity(activityCfg, time.Now())
 	if activity != nil {
 		eventOnlineTime := &pb.EventPlayerProperty{
@@ -295,12 +295,12 @@ func TestActivity(t *testing.T) {
 	activities := player.GetActivities()
 	exchange := player.GetExchange()
 	var activityIds []int32
-	cfg.ActivityCfgs.Range(func(activityCfg *pb.ActivityCfg) bool {
+	cfg.Get().ActivityCfgs.Range(func(activityCfg *pb.ActivityCfg) bool {
 		activityIds = append(activityIds, activityCfg.CfgId)
 		return true
 	})
 	for _, activityId := range activityIds {
-		activityCfg := cfg.ActivityCfgs.GetCfg(activityId)
+		activityCfg := cfg.Get().ActivityCfgs.GetCfg(activityId)
 		if activityCfg == nil {
 			continue
 		}
@@ -557,7 +557,7 @@ func TestEvent(t *testing.T) {
 	player.GetBaseInfo().Data.Level = 2
 
 	q := player.GetQuest()
-	cfg.Quests.Range(func(questCfg *pb.QuestCfg) bool {
+	cfg.Get().Quests.Range(func(questCfg *pb.QuestCfg) bool {
 		// 排除其他模块的子任务
 		if questCfg.GetQuestType() != 0 {
 			return true
@@ -607,3 +607,18 @@ func TestEvent(t *testing.T) {
 
 	player.firePostedEvents()
 }
+
+// 任务和排行榜的cfgId相同时,删除任务进度不影响排行榜
+func TestProgressEventMappingRemove(t *testing.T) {
+	mapping := &ProgressEventMapping{}
+	progressCfg := &pb.ProgressCfg{Event: "EventFight"}
+	questData := &pb.QuestData{CfgId: 2}
+	rankCfg := &pb.RankCfg{CfgId: 2}
+	mapping.AddProgress(progressCfg, questData)
+	mapping.AddProgress(progressCfg, rankCfg)
+	mapping.RemoveProgress(progressCfg, questData)
+	progressSlice := mapping.mapping["EventFight"]
+	if len(progressSlice) != 1 || progressSlice[0] != rankCfg {
+		t.Fatalf("progress:%v", progressSlice)
+	}
+}
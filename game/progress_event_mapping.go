@@ -1,7 +1,6 @@
 package game
 
 import (
-	"github.com/fish-tennis/gserver/cfg"
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
 	"github.com/fish-tennis/gserver/util"
@@ -38,8 +37,11 @@ func (p *ProgressEventMapping) AddProgress(progressCfg *pb.ProgressCfg, progress
 	slog.Debug("AddProgress", "key", key, "cfgId", progress.GetCfgId())
 }
 
-func (p *ProgressEventMapping) RemoveProgress(progressCfg *pb.ProgressCfg, cfgId int32) {
-	if progressCfg == nil {
+// 删除进度对象
+//
+//	任务和排行榜等不同模块的进度对象共用映射表,cfgId可能重复,所以按对象删除
+func (p *ProgressEventMapping) RemoveProgress(progressCfg *pb.ProgressCfg, progress internal.CfgData) {
+	if progressCfg == nil || progress == nil {
 		return
 	}
 	// mapping 是 lazy 初始化的,未调用过 AddProgress 时为 nil
@@ -49,21 +51,26 @@ func (p *ProgressEventMapping) RemoveProgress(progressCfg *pb.ProgressCfg, cfgId
 	}
 	key := p.getKey(progressCfg)
 	progressSlice, _ := p.mapping[key]
-	// 删除所有匹配的 cfgId(理论上只有一个,防御性删除全部以防重复添加导致幽灵进度)
+	// 删除所有匹配的对象(理论上只有一个,防御性删除全部以防重复添加导致幽灵进度)
 	filtered := progressSlice[:0]
 	for _, item := range progressSlice {
-		if item.GetCfgId() != cfgId {
+		if item != progress {
 			filtered = append(filtered, item)
 		}
 	}
 	p.mapping[key] = filtered
-	slog.Debug("RemoveProgress", "event", key, "cfgId", cfgId)
+	slog.Debug("RemoveProgress", "event", key, "cfgId", progress.GetCfgId())
+}
+
+// 清空映射表,重新加载配置后由各模块用新的配置重新添加
+func (p *ProgressEventMapping) Reset() {
+	p.mapping = nil
 }
 
 func (p *ProgressEventMapping) UpdateProgress(event any, progress internal.CfgData) bool {
 	switch v := progress.(type) {
 	case *pb.QuestData:
-		questCfg := cfg.Quests.GetCfg(v.GetCfgId())
+		questCfg := p.player.GetCfg().Quests.GetCfg(v.GetCfgId())
 		if questCfg == nil {
 			slog.Error("UpdateProgress questCfg nil", "cfgId", v.GetCfgId())
 			return false
@@ -77,6 +84,9 @@ func (p *ProgressEventMapping) UpdateProgress(event any, progress internal.CfgDa
 			slog.Debug("QuestProgressUpdate", "name", questCfg.GetName(), "questId", v.GetCfgId(), "progress", v.GetProgress(), "activityId", v.GetActivityId())
 			return true
 		}
+	case *pb.RankCfg:
+		// 排行榜的分数更新
+		return p.player.GetRank().UpdateScore(event, v)
 	default:
 		slog.Error("CheckProgressErr", "progress", progress)
 	}
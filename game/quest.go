@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/fish-tennis/gentity"
-	"github.com/fish-tennis/gserver/cfg"
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
 )
@@ -44,9 +43,13 @@ func (p *Player) GetQuest() *Quest {
 }
 
 func (q *Quest) OnDataLoad() {
-	// 把已有任务加入到进度更新映射表中
+	q.addAllProgress()
+}
+
+// 把已有任务加入到进度更新映射表中
+func (q *Quest) addAllProgress() {
 	q.Quests.Range(func(k int32, questData *pb.QuestData) bool {
-		questCfg := cfg.Quests.GetCfg(questData.GetCfgId())
+		questCfg := q.GetPlayer().GetCfg().Quests.GetCfg(questData.GetCfgId())
 		if questCfg == nil {
 			slog.Error("questCfg nil", "cfgId", questData.GetCfgId())
 			return true
@@ -56,6 +59,11 @@ func (q *Quest) OnDataLoad() {
 	})
 }
 
+// 重新加载配置后,用新的任务配置更新进度映射
+func (q *Quest) TriggerCfgReloaded(event *internal.EventCfgReloaded) {
+	q.addAllProgress()
+}
+
 func (q *Quest) SyncDataToClient() {
 	q.GetPlayer().Send(&pb.QuestSync{
 		Finished: q.Finished.Data,
@@ -64,11 +72,14 @@ func (q *Quest) SyncDataToClient() {
 }
 
 func (q *Quest) AddQuest(questData *pb.QuestData) {
-	questCfg := cfg.Quests.GetCfg(questData.GetCfgId())
+	cfgs := q.GetPlayer().GetCfg()
+	questCfg := cfgs.Quests.GetCfg(questData.GetCfgId())
 	if questCfg == nil {
 		slog.Error("AddQuestErr", "questData", questData)
 		return
 	}
+	// 记录接任务时的配置数据版本
+	questData.CfgVersion = cfgs.Version
 	q.Quests.Set(questData.CfgId, questData)
 	// 初始化进度
 	if questCfg.Progress != nil {
@@ -85,10 +96,11 @@ func (q *Quest) AddQuest(questData *pb.QuestData) {
 }
 
 func (q *Quest) RemoveQuest(questCfgId int32) {
+	questData, ok := q.Quests.Get(questCfgId)
 	q.Quests.Delete(questCfgId)
-	questCfg := cfg.Quests.GetCfg(questCfgId)
-	if questCfg != nil && questCfg.Progress != nil {
-		q.GetPlayer().progressEventMapping.RemoveProgress(questCfg.Progress, questCfgId)
+	questCfg := q.GetPlayer().GetCfg().Quests.GetCfg(questCfgId)
+	if ok && questCfg != nil && questCfg.Progress != nil {
+		q.GetPlayer().progressEventMapping.RemoveProgress(questCfg.Progress, questData)
 	}
 	q.GetPlayer().Send(&pb.QuestRemoveRes{
 		QuestCfgId: questCfgId,
@@ -115,7 +127,7 @@ func (q *Quest) CanAccept(obj any, questCfg *pb.QuestCfg) bool {
 
 // 玩家等级更新时,自动接任务
 func (q *Quest) WhenPlayerLevelup(level int32) {
-	if quests, ok := cfg.QuestsByLevel[level]; ok {
+	if quests, ok := q.GetPlayer().GetCfg().QuestsByLevel[level]; ok {
 		quests.Range(func(questCfg *pb.QuestCfg) bool {
 			// 排除其他模块的子任务
 			if questCfg.GetQuestType() != 0 {
@@ -153,7 +165,7 @@ func (q *Quest) OnEvent(event interface{}) {
 
 func (q *Quest) Refresh(oldDate time.Time, curDate time.Time) {
 	q.Finished.Range(func(questCfgId int32, v *pb.FinishedQuestData) bool {
-		questCfg := cfg.Quests.GetCfg(questCfgId)
+		questCfg := q.GetPlayer().GetCfg().Quests.GetCfg(questCfgId)
 		if questCfg == nil {
 			return true
 		}
@@ -167,7 +179,7 @@ func (q *Quest) Refresh(oldDate time.Time, curDate time.Time) {
 		return true
 	})
 	q.Quests.Range(func(questCfgId int32, v *pb.QuestData) bool {
-		questCfg := cfg.Quests.GetCfg(questCfgId)
+		questCfg := q.GetPlayer().GetCfg().Quests.GetCfg(questCfgId)
 		// 活动的子任务由活动接口去处理
 		if questCfg == nil || v.ActivityId > 0 {
 			return true
@@ -176,7 +188,7 @@ func (q *Quest) Refresh(oldDate time.Time, curDate time.Time) {
 		return true
 	})
 	// 重新接取日常任务,实际项目可能还涉及到随机等额外逻辑,这里简单演示一下,接取所有的满足接取条件的日常任务
-	cfg.QuestsDay.Range(func(questCfg *pb.QuestCfg) bool {
+	q.GetPlayer().GetCfg().QuestsDay.Range(func(questCfg *pb.QuestCfg) bool {
 		if !q.CanAccept(q.GetPlayer(), questCfg) {
 			return true
 		}
@@ -209,7 +221,7 @@ func (q *Quest) OnFinishQuestReq(req *pb.FinishQuestReq) (*pb.FinishQuestRes, er
 	res := &pb.FinishQuestRes{}
 	for _, questCfgId := range req.GetQuestCfgIds() {
 		if questData, ok := q.Quests.Data[questCfgId]; ok {
-			questCfg := cfg.Quests.GetCfg(questData.GetCfgId())
+			questCfg := q.GetPlayer().GetCfg().Quests.GetCfg(questData.GetCfgId())
 			if questCfg == nil {
 				slog.Error("OnFinishQuestReq questCfg nil", "questCfgId", questData.GetCfgId())
 				continue
@@ -220,7 +232,7 @@ func (q *Quest) OnFinishQuestReq(req *pb.FinishQuestReq) (*pb.FinishQuestRes, er
 					Timestamp: int32(q.GetPlayer().GetTimerEntries().Now().Unix()),
 				}
 				q.Finished.Set(questData.GetCfgId(), finishedData)
-				q.GetPlayer().progressEventMapping.RemoveProgress(questCfg.Progress, questData.GetCfgId())
+				q.GetPlayer().progressEventMapping.RemoveProgress(questCfg.Progress, questData)
 				// 任务收集物品删除
 				if len(questCfg.GetCollects()) > 0 {
 					q.GetPlayer().GetBags().DelItemsByItemNums(questCfg.GetCollects())
@@ -231,7 +243,7 @@ func (q *Quest) OnFinishQuestReq(req *pb.FinishQuestReq) (*pb.FinishQuestRes, er
 				res.FinishedQuestDatas = append(res.FinishedQuestDatas, finishedData)
 				// 任务链
 				for _, nextQuestId := range questCfg.GetNextQuests() {
-					nextQuestCfg := cfg.Quests.GetCfg(nextQuestId)
+					nextQuestCfg := q.GetPlayer().GetCfg().Quests.GetCfg(nextQuestId)
 					if nextQuestCfg == nil {
 						slog.Error("nextQuestCfg nil", "nextQuestId", nextQuestId)
 						continue
@@ -0,0 +1,200 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gserver/cfg"
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/pb"
+	"github.com/fish-tennis/gserver/rank"
+)
+
+const (
+	// 组件名
+	ComponentNameRank = "Rank"
+	// 排行榜每页的人数
+	RankPageSize = 20
+)
+
+// 利用go的init进行组件的自动注册
+func init() {
+	_playerComponentRegister.Register(ComponentNameRank, 0, func(player *Player, _ any) gentity.Component {
+		component := &Rank{
+			BasePlayerComponent: BasePlayerComponent{
+				player: player,
+				name:   ComponentNameRank,
+			},
+		}
+		component.addAllProgress()
+		return component
+	})
+}
+
+// 排行榜模块
+//
+//	排行榜数据保存在redis,玩家身上不保存数据
+type Rank struct {
+	BasePlayerComponent
+}
+
+func (p *Player) GetRank() *Rank {
+	return p.GetComponentByName(ComponentNameRank).(*Rank)
+}
+
+// 用于计算分数的进度值
+type rankScoreHolder struct {
+	progress int32
+}
+
+func (h *rankScoreHolder) GetProgress() int32 {
+	return h.progress
+}
+
+func (h *rankScoreHolder) SetProgress(progress int32) {
+	h.progress = progress
+}
+
+// 检查事件是否更新排行榜的分数
+func (r *Rank) UpdateScore(event any, rankCfg *pb.RankCfg) bool {
+	holder := &rankScoreHolder{}
+	if !internal.UpdateProgress(r.GetPlayer(), holder, event, rankCfg.GetProgress()) {
+		return false
+	}
+	if rankCfg.GetScoreType() == int32(pb.RankScoreType_RankScoreType_Set) {
+		score := holder.progress
+		// 以属性当前值作为分数,如等级
+		if rankCfg.GetProgress().GetNeedInit() {
+			initHolder := &rankScoreHolder{}
+			internal.InitProgress(r.GetPlayer(), initHolder, rankCfg.GetProgress())
+			score = initHolder.progress
+		}
+		return rank.UpdateScore(rankCfg, r.GetPlayerId(), r.GetPlayer().GetName(), int64(score)) == nil
+	}
+	_, err := rank.IncScore(rankCfg, r.GetPlayerId(), r.GetPlayer().GetName(), int64(holder.progress))
+	return err == nil
+}
+
+// 排行榜的分数更新和任务进度一样,由事件驱动
+func (r *Rank) addAllProgress() {
+	rankCfgs := r.GetPlayer().GetCfg().RankCfgs
+	if rankCfgs == nil {
+		return
+	}
+	rankCfgs.Range(func(rankCfg *pb.RankCfg) bool {
+		r.GetPlayer().progressEventMapping.AddProgress(rankCfg.GetProgress(), rankCfg)
+		return true
+	})
+}
+
+// 事件接口
+func (r *Rank) TriggerPlayerEntryGame(event *internal.EventPlayerEntryGame) {
+	rankCfgs := r.GetPlayer().GetCfg().RankCfgs
+	if rankCfgs == nil {
+		return
+	}
+	// 覆盖分数的排行榜,上线时同步一次当前值
+	rankCfgs.Range(func(rankCfg *pb.RankCfg) bool {
+		if rankCfg.GetScoreType() != int32(pb.RankScoreType_RankScoreType_Set) || !rankCfg.GetProgress().GetNeedInit() {
+			return true
+		}
+		holder := &rankScoreHolder{}
+		if internal.InitProgress(r.GetPlayer(), holder, rankCfg.GetProgress()) {
+			rank.UpdateScore(rankCfg, r.GetPlayerId(), r.GetPlayer().GetName(), int64(holder.progress))
+		}
+		return true
+	})
+}
+
+// 重新加载配置后,用新的排行榜配置更新进度映射
+func (r *Rank) TriggerCfgReloaded(event *internal.EventCfgReloaded) {
+	r.addAllProgress()
+}
+
+// 分页查询排行榜
+func (r *Rank) OnRankListReq(req *pb.RankListReq) (*pb.RankListRes, error) {
+	l := r.GetPlayer().Log
+	l.Debug("OnRankListReq", "req", req)
+	rankCfg := r.GetPlayer().GetCfg().RankCfgs.GetCfg(req.GetRankCfgId())
+	if rankCfg == nil {
+		return nil, errors.New("RankNotExists")
+	}
+	if req.GetPageIndex() < 0 {
+		return nil, errors.New("PageIndexError")
+	}
+	items, total, err := rank.GetPage(rankCfg.GetName(), req.GetPageIndex(), RankPageSize)
+	if err != nil {
+		return nil, errors.New("RankError")
+	}
+	self, err := rank.GetPlayerRank(rankCfg.GetName(), r.GetPlayerId())
+	if err != nil {
+		return nil, errors.New("RankError")
+	}
+	self.Name = r.GetPlayer().GetName()
+	return &pb.RankListRes{
+		RankCfgId: req.GetRankCfgId(),
+		PageIndex: req.GetPageIndex(),
+		PageCount: int32((total + RankPageSize - 1) / RankPageSize),
+		Items:     items,
+		Self:      self,
+	}, nil
+}
+
+// 查询玩家的排名
+func (r *Rank) OnRankPlayerReq(req *pb.RankPlayerReq) (*pb.RankPlayerRes, error) {
+	l := r.GetPlayer().Log
+	l.Debug("OnRankPlayerReq", "req", req)
+	rankCfg := r.GetPlayer().GetCfg().RankCfgs.GetCfg(req.GetRankCfgId())
+	if rankCfg == nil {
+		return nil, errors.New("RankNotExists")
+	}
+	playerId := req.GetPlayerId()
+	if playerId == 0 {
+		playerId = r.GetPlayerId()
+	}
+	item, err := rank.GetPlayerRank(rankCfg.GetName(), playerId)
+	if err != nil {
+		return nil, errors.New("RankError")
+	}
+	return &pb.RankPlayerRes{
+		RankCfgId: req.GetRankCfgId(),
+		Item:      item,
+	}, nil
+}
+
+// 排行榜赛季结算(线程安全)
+//
+//	先快照并重置排行榜,再按RankRewardCfg的名次区间给快照中的玩家发奖励邮件
+//	同一个赛季只能结算一次,多个服务器同时调用时,只有1个会成功
+func SettleRankSeason(rankCfgId int32, seasonId int32) error {
+	cfgs := cfg.Get()
+	rankCfg := cfgs.RankCfgs.GetCfg(rankCfgId)
+	if rankCfg == nil {
+		return errors.New("RankNotExists")
+	}
+	if err := rank.SnapshotAndReset(rankCfg.GetName(), seasonId); err != nil {
+		return err
+	}
+	for _, rewardCfg := range cfgs.RankRewardsByRank[rankCfgId] {
+		items, err := rank.GetSnapshotRange(rankCfg.GetName(), seasonId, rewardCfg.GetMinRank(), rewardCfg.GetMaxRank())
+		if err != nil {
+			// 快照会保留一段时间,可以人工补发
+			slog.Error("SettleRankSeason error", "rankCfgId", rankCfgId, "seasonId", seasonId, "rewardCfgId", rewardCfg.GetCfgId(), "error", err)
+			continue
+		}
+		for _, item := range items {
+			err = SendMail(item.GetPlayerId(), &pb.MailData{
+				Title:       "rank season reward",
+				Content:     fmt.Sprintf("rank:%v season:%v rank:%v score:%v", rankCfg.GetName(), seasonId, item.GetRank(), item.GetScore()),
+				Attachments: rewardCfg.GetRewards(),
+			})
+			if err != nil {
+				slog.Error("SettleRankSeason SendMail error", "rankCfgId", rankCfgId, "seasonId", seasonId, "playerId", item.GetPlayerId(), "error", err)
+			}
+		}
+	}
+	slog.Info("SettleRankSeason", "rankCfgId", rankCfgId, "seasonId", seasonId)
+	return nil
+}
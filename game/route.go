@@ -1,6 +1,7 @@
 package game
 
 import (
+	"errors"
 	"github.com/fish-tennis/gentity/util"
 	. "github.com/fish-tennis/gnet"
 	"github.com/fish-tennis/gserver/cache"
@@ -99,6 +100,22 @@ func WithError(err error) RouteOption {
 // 举例:
 // 公会会长同意了玩家A的入会申请,此时玩家A可能不在线,就把该消息存入玩家的数据库,待玩家下次上线时,从数据库取出该消息,并进行相应的逻辑处理
 func RoutePlayerPacket(playerId int64, packet Packet, opts ...RouteOption) bool {
+	return routePlayerPacket(playerId, packet, opts...) == nil
+}
+
+var (
+	// 路由消息时保存数据库失败
+	ErrRouteSaveDb = errors.New("RouteSaveDbError")
+	// 目标玩家不在线
+	ErrRoutePlayerOffline = errors.New("PlayerOffline")
+	// 路由消息发送失败
+	ErrRouteSend = errors.New("RouteSendError")
+)
+
+// 路由玩家消息,返回失败的原因
+//
+//	使用WithSaveDb选项时,ErrRouteSaveDb之外的错误,消息都已经保存到数据库了
+func routePlayerPacket(playerId int64, packet Packet, opts ...RouteOption) error {
 	log := slog.Default().With("playerId", playerId, "message", proto.MessageName(packet.Message()))
 	routeOpts := defaultRouteOptions()
 	for _, opt := range opts {
@@ -111,7 +128,7 @@ func RoutePlayerPacket(playerId int64, packet Packet, opts ...RouteOption) bool
 		anyPacket, err = anypb.New(packet.Message())
 		if err != nil {
 			log.Error("RoutePlayerPacketErr anypb.New", "err", err)
-			return false
+			return err
 		}
 	}
 	pendingMessageId := int64(0)
@@ -126,13 +143,13 @@ func RoutePlayerPacket(playerId int64, packet Packet, opts ...RouteOption) bool
 		pendingMessageBytes, err := proto.Marshal(pendingMessage)
 		if err != nil {
 			log.Error("RoutePlayerPacketErr", "err", err)
-			return false
+			return ErrRouteSaveDb
 		}
 		err = db.GetPlayerDb().SaveComponentField(playerId, ComponentNamePendingMessages,
 			util.Itoa(pendingMessage.MessageId), pendingMessageBytes)
 		if err != nil {
 			log.Error("RoutePlayerPacketErr", "err", err)
-			return false
+			return ErrRouteSaveDb
 		}
 		log.Debug("save PendingMessage", "MessageId", pendingMessage.MessageId, "cmd", packet.Command())
 	}
@@ -143,13 +160,13 @@ func RoutePlayerPacket(playerId int64, packet Packet, opts ...RouteOption) bool
 			_, toServerId = cache.GetOnlinePlayer(playerId)
 			if toServerId == 0 {
 				log.Error("RoutePlayerPacketErr player offline", "cmd", packet.Command())
-				return false
+				return ErrRoutePlayerOffline
 			}
 		}
 		conn = internal.GetServerList().GetServerConnection(toServerId)
 		if conn == nil {
 			log.Error("RoutePlayerPacketErr server connection nil", "cmd", packet.Command(), "toServerId", toServerId)
-			return false
+			return ErrRouteSend
 		}
 	}
 	if anyPacket == nil {
@@ -170,7 +187,10 @@ func RoutePlayerPacket(playerId int64, packet Packet, opts ...RouteOption) bool
 	if protoPacket, ok := packet.(*ProtoPacket); ok {
 		routePacket.SetRpcCallId(protoPacket.RpcCallId())
 	}
-	return conn.SendPacket(routePacket)
+	if !conn.SendPacket(routePacket) {
+		return ErrRouteSend
+	}
+	return nil
 }
 
 // RoutePlayerPackets 批量路由同一消息给多个玩家
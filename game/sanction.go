@@ -0,0 +1,73 @@
+package game
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/fish-tennis/gserver/db"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+// 进游戏时加载的处罚记录
+func (p *Player) SetSanctions(sanctions []*pb.Sanction) {
+	p.sanctions = sanctions
+}
+
+// 对该角色生效的处罚,没有时返回nil
+func (p *Player) GetSanction(sanctionType pb.SanctionType) *pb.Sanction {
+	return db.MatchSanction(p.sanctions, sanctionType, p.GetId(), p.GetTimerEntries().Now().Unix())
+}
+
+// 是否被禁言
+func (p *Player) IsMuted() bool {
+	return p.GetSanction(pb.SanctionType_SanctionType_Mute) != nil
+}
+
+// 是否被冻结交易
+func (p *Player) IsTradeFrozen() bool {
+	return p.GetSanction(pb.SanctionType_SanctionType_FreezeTrade) != nil
+}
+
+// 处罚账号或角色(线程安全)
+//
+//	sanction.PlayerId为0时处罚账号下的所有角色,EndTimestamp为0表示永久
+//	处罚的角色在线时,通过KickPlayerReq踢下线,重新进游戏时加载新的处罚
+func AddSanction(sanction *pb.Sanction) error {
+	if sanction.AccountId <= 0 {
+		return errors.New("AccountIdError")
+	}
+	if sanction.Type <= int32(pb.SanctionType_SanctionType_None) || sanction.Type > int32(pb.SanctionType_SanctionType_FreezeTrade) {
+		return errors.New("SanctionTypeError")
+	}
+	if sanction.Reason == "" || sanction.Operator == "" {
+		return errors.New("NeedReasonAndOperator")
+	}
+	sanction.Timestamp = time.Now().Unix()
+	sanction.RevokeTimestamp = 0
+	if err := db.InsertSanction(sanction); err != nil {
+		slog.Error("InsertSanction error", "sanction", sanction, "error", err)
+		return errors.New("DbError")
+	}
+	slog.Info("AddSanction", "sanction", sanction)
+	KickOnlineAccount(sanction.AccountId, sanction.PlayerId)
+	return nil
+}
+
+// 撤销处罚(线程安全),被撤销的角色在线时同样踢下线
+func RevokeSanction(accountId, playerId int64, sanctionType pb.SanctionType, operator, reason string) (int64, error) {
+	if operator == "" || reason == "" {
+		return 0, errors.New("NeedReasonAndOperator")
+	}
+	count, err := db.RevokeSanctions(accountId, playerId, sanctionType, operator, reason, time.Now().Unix())
+	if err != nil {
+		slog.Error("RevokeSanctions error", "accountId", accountId, "playerId", playerId, "type", sanctionType, "error", err)
+		return 0, errors.New("DbError")
+	}
+	slog.Info("RevokeSanction", "accountId", accountId, "playerId", playerId, "type", sanctionType,
+		"operator", operator, "reason", reason, "count", count)
+	if count > 0 {
+		KickOnlineAccount(accountId, playerId)
+	}
+	return count, nil
+}
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"log/slog"
+
 	. "github.com/fish-tennis/gnet"
 	"github.com/fish-tennis/gserver/network"
 	"github.com/fish-tennis/gserver/pb"
@@ -17,7 +19,15 @@ func GetGlobalEntity() *GlobalEntity {
 type Hook struct {
 }
 
-func (h *Hook) OnRegisterServerHandler(_ any) {
+func (h *Hook) OnRegisterServerHandler(arg any) {
+	// 系统邮件交给GlobalEntity协程处理
+	network.RegisterPacketHandler(arg.(PacketHandlerRegister), new(pb.SystemMailAdd), func(connection Connection, packet Packet) {
+		if GetGlobalEntity() == nil {
+			slog.Error("SystemMailAdd GlobalEntity nil")
+			return
+		}
+		GetGlobalEntity().PushMessage(packet)
+	})
 }
 
 // 服务器初始化回调
@@ -0,0 +1,198 @@
+package game
+
+import (
+	"cmp"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gserver/cfg"
+	"github.com/fish-tennis/gserver/db"
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/network"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+const (
+	// 组件名
+	ComponentNameSystemMail = "SystemMail"
+)
+
+// 利用go的init进行组件的自动注册
+func init() {
+	_globalEntityComponentRegister.Register(ComponentNameSystemMail, 0, func(globalEntity *GlobalEntity, _ any) gentity.Component {
+		return &SystemMail{
+			BaseComponent: gentity.NewBaseComponent(globalEntity, ComponentNameSystemMail),
+			Mails:         gentity.NewMapData[int64, *pb.SystemMailData](),
+		}
+	})
+}
+
+// 系统邮件(全服邮件)组件
+//
+//	系统邮件保存在GlobalEntity的数据里,玩家上线时按自己的拉取水位把新的系统邮件拉取到自己的邮件模块
+//	Mails只在GlobalEntity协程中修改,玩家协程通过sortedMails读取
+type SystemMail struct {
+	*gentity.BaseComponent
+	Mails *gentity.MapData[int64, *pb.SystemMailData] `db:""`
+	// 按SystemMailId排序的系统邮件,供玩家协程读取
+	sortedMails []*pb.SystemMailData
+	mutex       sync.RWMutex
+}
+
+func (this *GlobalEntity) GetSystemMail() *SystemMail {
+	return this.GetComponentByName(ComponentNameSystemMail).(*SystemMail)
+}
+
+func (this *SystemMail) OnDataLoad() {
+	this.updateSortedMails()
+}
+
+// 重新生成排序列表,在GlobalEntity协程中调用
+func (this *SystemMail) updateSortedMails() {
+	sortedMails := make([]*pb.SystemMailData, 0, len(this.Mails.Data))
+	for _, systemMail := range this.Mails.Data {
+		sortedMails = append(sortedMails, systemMail)
+	}
+	slices.SortFunc(sortedMails, func(a, b *pb.SystemMailData) int {
+		return cmp.Compare(a.GetSystemMailId(), b.GetSystemMailId())
+	})
+	this.mutex.Lock()
+	this.sortedMails = sortedMails
+	this.mutex.Unlock()
+}
+
+// 获取id大于lastSystemMailId的系统邮件,按SystemMailId排序(线程安全)
+//
+//	返回的数据是只读的,不能修改
+func (this *SystemMail) GetMailsAfter(lastSystemMailId int64) []*pb.SystemMailData {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	index, _ := slices.BinarySearchFunc(this.sortedMails, lastSystemMailId, func(systemMail *pb.SystemMailData, id int64) int {
+		if systemMail.GetSystemMailId() <= id {
+			return -1
+		}
+		return 1
+	})
+	if index >= len(this.sortedMails) {
+		return nil
+	}
+	return slices.Clone(this.sortedMails[index:])
+}
+
+func isSystemMailExpired(systemMail *pb.SystemMailData, now int32) bool {
+	expireTime := systemMail.GetMail().GetExpireTime()
+	return expireTime > 0 && expireTime <= now
+}
+
+// 删除过期的系统邮件
+func (this *SystemMail) removeExpired(now int32) {
+	var expiredIds []int64
+	this.Mails.Range(func(systemMailId int64, systemMail *pb.SystemMailData) bool {
+		if isSystemMailExpired(systemMail, now) {
+			expiredIds = append(expiredIds, systemMailId)
+		}
+		return true
+	})
+	for _, systemMailId := range expiredIds {
+		this.Mails.Delete(systemMailId)
+		slog.Debug("SystemMail.removeExpired", "systemMailId", systemMailId)
+	}
+}
+
+// 新增系统邮件
+func (this *SystemMail) HandleSystemMailAdd(req *pb.SystemMailAdd) {
+	systemMail := req.GetData()
+	if systemMail.GetSystemMailId() <= 0 || systemMail.GetMail() == nil {
+		slog.Error("HandleSystemMailAdd invalid data", "data", systemMail)
+		return
+	}
+	this.removeExpired(int32(time.Now().Unix()))
+	this.Mails.Set(systemMail.GetSystemMailId(), systemMail)
+	this.updateSortedMails()
+	slog.Debug("HandleSystemMailAdd", "systemMailId", systemMail.GetSystemMailId(), "title", systemMail.GetMail().GetTitle())
+}
+
+// 检查玩家是否满足系统邮件的过滤条件
+func (this *SystemMail) CheckFilter(player *Player, systemMail *pb.SystemMailData) bool {
+	createTime := player.GetBaseInfo().Data.GetCreateTimestamp()
+	// 新角色不会收到创角之前的系统邮件
+	if int64(systemMail.GetMail().GetSendTime()) < createTime {
+		return false
+	}
+	filter := systemMail.GetFilter()
+	if filter == nil {
+		return true
+	}
+	level := player.GetLevel()
+	if filter.MinLevel > 0 && level < filter.MinLevel {
+		return false
+	}
+	if filter.MaxLevel > 0 && level > filter.MaxLevel {
+		return false
+	}
+	if filter.MinCreateTime > 0 && createTime < filter.MinCreateTime {
+		return false
+	}
+	if filter.MaxCreateTime > 0 && createTime > filter.MaxCreateTime {
+		return false
+	}
+	if filter.RegionId > 0 && player.GetRegionId() != filter.RegionId {
+		return false
+	}
+	return true
+}
+
+// 发送系统邮件(线程安全)
+//
+//	系统邮件会发给所有game服的GlobalEntity,玩家上线时再拉取
+//	NOTE:发送时不在线的game服不会收到该系统邮件
+func SendSystemMail(mail *pb.MailData, filter *pb.SystemMailFilter) error {
+	// 系统邮件会发给所有玩家,附件有错误的话影响所有玩家
+	if err := CheckMailAttachments(cfg.Get(), mail.GetAttachments()); err != nil {
+		return err
+	}
+	newIdValue, err := db.GetKvDb().Inc(db.SystemMailIdKeyName, int64(1), true)
+	if err != nil {
+		slog.Error("SendSystemMail id error", "error", err)
+		return errors.New("IdError")
+	}
+	// BSON数值可能是int32/int64/float64等类型,用安全转换
+	var systemMailId int64
+	switch idVal := newIdValue.(type) {
+	case int64:
+		systemMailId = idVal
+	case int32:
+		systemMailId = int64(idVal)
+	case float64:
+		systemMailId = int64(idVal)
+	default:
+		slog.Error("SendSystemMail invalid id type", "newIdValue", newIdValue)
+		return errors.New("IdError")
+	}
+	now := time.Now()
+	if mail.SendTime == 0 {
+		mail.SendTime = int32(now.Unix())
+	}
+	if mail.ExpireTime == 0 {
+		mail.ExpireTime = int32(now.Add(MailDefaultExpireDuration).Unix())
+	}
+	req := &pb.SystemMailAdd{
+		Data: &pb.SystemMailData{
+			SystemMailId: systemMailId,
+			Mail:         mail,
+			Filter:       filter,
+		},
+	}
+	packet := network.NewPacket(req)
+	for _, serverInfo := range internal.GetServerList().GetServersByType(internal.ServerType_Game) {
+		if !internal.GetServerList().SendPacket(serverInfo.GetServerId(), packet) {
+			slog.Error("SendSystemMail send error", "serverId", serverInfo.GetServerId(), "systemMailId", systemMailId)
+		}
+	}
+	slog.Info("SendSystemMail", "systemMailId", systemMailId, "title", mail.GetTitle(), "filter", filter)
+	return nil
+}
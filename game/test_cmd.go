@@ -5,7 +5,6 @@ import (
 	"github.com/fish-tennis/gentity"
 	"github.com/fish-tennis/gentity/util"
 	"github.com/fish-tennis/gnet"
-	"github.com/fish-tennis/gserver/cfg"
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/network"
 	"github.com/fish-tennis/gserver/pb"
@@ -14,6 +13,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // 客户端输入的测试命令
@@ -55,7 +55,7 @@ func (p *Player) OnTestCmd(req *pb.TestCmd) {
 			return
 		}
 		itemCfgId := int32(util.Atoi(cmdArgs[0]))
-		itemCfg := cfg.ItemCfgs.GetCfg(itemCfgId)
+		itemCfg := p.GetCfg().ItemCfgs.GetCfg(itemCfgId)
 		if itemCfg == nil {
 			p.SendErrorRes(cmd, "AddItem itemCfgId error")
 			return
@@ -143,7 +143,7 @@ func (p *Player) OnTestCmd(req *pb.TestCmd) {
 			p.GetActivities().AddAllActivitiesCanJoin(p.GetTimerEntries().Now())
 		} else {
 			activityId := int32(util.Atoi(arg))
-			activityCfg := cfg.ActivityCfgs.GetCfg(activityId)
+			activityCfg := p.GetCfg().ActivityCfgs.GetCfg(activityId)
 			if activityCfg == nil {
 				p.SendErrorRes(cmd, "AddActivity invalid activityId")
 				return
@@ -171,6 +171,54 @@ func (p *Player) OnTestCmd(req *pb.TestCmd) {
 		}
 		p.GetExchange().OnExchangeReq(exchangeReq)
 
+	case strings.ToLower("SendMail"):
+		// 给自己发一封带附件的邮件 SendMail 物品id1 数量1 物品id2 数量2
+		mail := &pb.MailData{
+			Title:   "test mail",
+			Content: "test mail content",
+		}
+		for i := 0; i+1 < len(cmdArgs); i += 2 {
+			mail.Attachments = append(mail.Attachments, &pb.AddElemArg{
+				CfgId: int32(util.Atoi(cmdArgs[i])),
+				Num:   int32(util.Atoi(cmdArgs[i+1])),
+			})
+		}
+		if err := SendMail(p.GetId(), mail); err != nil {
+			p.SendErrorRes(cmd, err.Error())
+		}
+
+	case strings.ToLower("SendSystemMail"):
+		// 发一封带附件的系统邮件 SendSystemMail 最小等级 物品id1 数量1 物品id2 数量2
+		if len(cmdArgs) < 1 {
+			p.SendErrorRes(cmd, "SendSystemMail cmdArgs error")
+			return
+		}
+		mail := &pb.MailData{
+			Title:   "test system mail",
+			Content: "test system mail content",
+		}
+		for i := 1; i+1 < len(cmdArgs); i += 2 {
+			mail.Attachments = append(mail.Attachments, &pb.AddElemArg{
+				CfgId: int32(util.Atoi(cmdArgs[i])),
+				Num:   int32(util.Atoi(cmdArgs[i+1])),
+			})
+		}
+		if err := SendSystemMail(mail, &pb.SystemMailFilter{MinLevel: int32(util.Atoi(cmdArgs[0]))}); err != nil {
+			p.SendErrorRes(cmd, err.Error())
+			return
+		}
+
+	case strings.ToLower("RankSettle"):
+		// 排行榜赛季结算 RankSettle 排行榜配置id 赛季id
+		if len(cmdArgs) < 2 {
+			p.SendErrorRes(cmd, "RankSettle cmdArgs error")
+			return
+		}
+		if err := SettleRankSeason(int32(util.Atoi(cmdArgs[0])), int32(util.Atoi(cmdArgs[1]))); err != nil {
+			p.SendErrorRes(cmd, err.Error())
+			return
+		}
+
 	case strings.ToLower("GuildRouteError"):
 		// 模拟一个rpc错误,向一个不存在的公会发送rpc消息
 		reply := new(pb.GuildJoinRes)
@@ -182,6 +230,74 @@ func (p *Player) OnTestCmd(req *pb.TestCmd) {
 		}
 		slog.Debug("GuildRouteError reply", "reply", reply)
 
+	case strings.ToLower("DrainServer"):
+		// 游戏服进入排空状态 DrainServer 游戏服id(默认本服)
+		serverId := internal.GetServerList().GetLocalServerInfo().GetServerId()
+		if len(cmdArgs) > 0 {
+			serverId = int32(util.Atoi(cmdArgs[0]))
+		}
+		drainCmd := gnet.PacketCommand(network.GetCommandByProto(new(pb.DrainServerReq)))
+		if !internal.GetServerList().Send(serverId, drainCmd, &pb.DrainServerReq{ServerId: serverId}) {
+			p.SendErrorRes(cmd, "DrainServer send error")
+			return
+		}
+
+	case strings.ToLower("NameHistory"):
+		// 查询改名记录 NameHistory 玩家id或者玩家名(默认自己)
+		var playerId int64
+		var name string
+		if len(cmdArgs) == 0 {
+			playerId = p.GetId()
+		} else if id, err := strconv.ParseInt(cmdArgs[0], 10, 64); err == nil {
+			playerId = id
+		} else {
+			name = cmdArgs[0]
+		}
+		records, err := FindPlayerNameHistory(playerId, name)
+		if err != nil {
+			p.SendErrorRes(cmd, err.Error())
+			return
+		}
+		// 改名记录通过TestRes返回给客户端,每行一条记录
+		var result strings.Builder
+		for _, record := range records {
+			result.WriteString(fmt.Sprintf("%v %v->%v %v\n", record.GetPlayerId(), record.GetOldName(), record.GetNewName(),
+				time.Unix(record.GetTimestamp(), 0).Format(time.DateTime)))
+		}
+		p.Send(&pb.TestRes{Result: result.String()})
+
+	case strings.ToLower("Sanction"):
+		// 处罚自己 Sanction 处罚类型(1封号 2禁言 3冻结交易) 持续秒数(0表示永久) 原因
+		if len(cmdArgs) < 3 {
+			p.SendErrorRes(cmd, "Sanction cmdArgs error")
+			return
+		}
+		sanction := &pb.Sanction{
+			AccountId: p.GetAccountId(),
+			PlayerId:  p.GetId(),
+			Type:      int32(util.Atoi(cmdArgs[0])),
+			Reason:    strings.Join(cmdArgs[2:], " "),
+			Operator:  "TestCmd",
+		}
+		if seconds := util.Atoi(cmdArgs[1]); seconds > 0 {
+			sanction.EndTimestamp = time.Now().Unix() + int64(seconds)
+		}
+		if err := AddSanction(sanction); err != nil {
+			p.SendErrorRes(cmd, err.Error())
+			return
+		}
+
+	case strings.ToLower("RevokeSanction"):
+		// 撤销自己的处罚 RevokeSanction 处罚类型
+		if len(cmdArgs) < 1 {
+			p.SendErrorRes(cmd, "RevokeSanction cmdArgs error")
+			return
+		}
+		if _, err := RevokeSanction(p.GetAccountId(), p.GetId(), pb.SanctionType(util.Atoi(cmdArgs[0])), "TestCmd", "test"); err != nil {
+			p.SendErrorRes(cmd, err.Error())
+			return
+		}
+
 	case strings.ToLower("FireEvent"):
 		// 通用的事件分发消息 FireEvent eventName 字段名1 字段值1 字段名2 字段值2
 		// 如 FireEvent EventFight IsPvp true IsWin true RoomType 1 RoomLevel 1 Score 10
@@ -0,0 +1,204 @@
+package gameserver
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fish-tennis/gserver/cache"
+	"github.com/fish-tennis/gserver/db"
+	"github.com/fish-tennis/gserver/game"
+	. "github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+// 管理后台接口的请求参数
+type (
+	adminKickReq struct {
+		AccountId int64
+		PlayerId  int64
+	}
+	adminMailReq struct {
+		PlayerId    int64 // 0表示发系统邮件
+		MinLevel    int32 // 系统邮件的等级限制
+		Title       string
+		Content     string
+		Attachments []*pb.AddElemArg
+	}
+	adminGrantItemsReq struct {
+		PlayerId int64
+		Items    []*pb.AddElemArg
+		Reason   string
+		Operator string
+	}
+	adminSanctionReq struct {
+		AccountId int64
+		PlayerId  int64 // 0表示账号下的所有角色
+		Type      int32 // SanctionType,默认封号
+		Seconds   int64 // 持续时间,0表示永久
+		Reason    string
+		Operator  string
+	}
+	adminNameHistoryReq struct {
+		PlayerId int64  // 大于0时查询该玩家的改名记录
+		Name     string // PlayerId为0时查询用过该名字的玩家的改名记录
+	}
+)
+
+// 注册游戏服的管理后台接口
+func (this *GameServer) registerAdminHandlers() {
+	this.RegisterAdminHandler("online", this.onAdminOnline)
+	this.RegisterAdminHandler("kick", this.onAdminKick)
+	this.RegisterAdminHandler("mail", this.onAdminMail)
+	this.RegisterAdminHandler("grant", this.onAdminGrantItems)
+	this.RegisterAdminHandler("ban", this.onAdminBan)
+	this.RegisterAdminHandler("unban", this.onAdminUnban)
+	this.RegisterAdminHandler("sanctions", this.onAdminSanctions)
+	this.RegisterAdminHandler("namehistory", this.onAdminNameHistory)
+	this.RegisterAdminHandler("reload", this.onAdminReload)
+	this.RegisterAdminHandler("drain", this.onAdminDrain)
+}
+
+// 在线人数,包括本服和所有游戏服
+func (this *GameServer) onAdminOnline(r *http.Request) (any, error) {
+	var onlineCount int32
+	this.playerMap.Range(func(key, value any) bool {
+		onlineCount++
+		return true
+	})
+	var totalOnlineCount int32
+	for _, serverInfo := range this.GetServerList().GetServersByType(ServerType_Game) {
+		if serverInfo.GetServerId() != this.GetId() {
+			totalOnlineCount += serverInfo.GetOnlineCount()
+		}
+	}
+	return map[string]int32{
+		"OnlineCount":      onlineCount,
+		"TotalOnlineCount": totalOnlineCount + onlineCount,
+	}, nil
+}
+
+// 踢玩家下线,玩家在其他游戏服时转发给对应的游戏服
+func (this *GameServer) onAdminKick(r *http.Request) (any, error) {
+	req := new(adminKickReq)
+	if err := ParseAdminRequest(r, req); err != nil {
+		return nil, err
+	}
+	if req.AccountId == 0 && req.PlayerId > 0 {
+		req.AccountId, _ = cache.GetOnlinePlayer(req.PlayerId)
+	}
+	if req.AccountId == 0 {
+		return nil, errors.New("PlayerOffline")
+	}
+	return map[string]bool{
+		"Kicked": game.KickOnlineAccount(req.AccountId, req.PlayerId),
+	}, nil
+}
+
+// 发邮件
+func (this *GameServer) onAdminMail(r *http.Request) (any, error) {
+	req := new(adminMailReq)
+	if err := ParseAdminRequest(r, req); err != nil {
+		return nil, err
+	}
+	if req.Title == "" {
+		return nil, errors.New("TitleEmpty")
+	}
+	mail := &pb.MailData{
+		Title:       req.Title,
+		Content:     req.Content,
+		Attachments: req.Attachments,
+	}
+	// 附件的检查和GrantItems一样(物品存在,数量大于0),有错误时不发送
+	if req.PlayerId > 0 {
+		return nil, game.SendMail(req.PlayerId, mail)
+	}
+	return nil, game.SendSystemMail(mail, &pb.SystemMailFilter{MinLevel: req.MinLevel})
+}
+
+// 发放物品,玩家离线时直接修改数据库
+func (this *GameServer) onAdminGrantItems(r *http.Request) (any, error) {
+	req := new(adminGrantItemsReq)
+	if err := ParseAdminRequest(r, req); err != nil {
+		return nil, err
+	}
+	return nil, game.GrantItems(req.PlayerId, &pb.GrantItems{
+		Items:    req.Items,
+		Reason:   req.Reason,
+		Operator: req.Operator,
+	})
+}
+
+// 处罚(封号,禁言,冻结交易)
+func (this *GameServer) onAdminBan(r *http.Request) (any, error) {
+	req := new(adminSanctionReq)
+	if err := ParseAdminRequest(r, req); err != nil {
+		return nil, err
+	}
+	if req.Type == 0 {
+		req.Type = int32(pb.SanctionType_SanctionType_Ban)
+	}
+	sanction := &pb.Sanction{
+		AccountId: req.AccountId,
+		PlayerId:  req.PlayerId,
+		Type:      req.Type,
+		Reason:    req.Reason,
+		Operator:  req.Operator,
+	}
+	if req.Seconds > 0 {
+		sanction.EndTimestamp = time.Now().Unix() + req.Seconds
+	}
+	return nil, game.AddSanction(sanction)
+}
+
+// 撤销处罚
+func (this *GameServer) onAdminUnban(r *http.Request) (any, error) {
+	req := new(adminSanctionReq)
+	if err := ParseAdminRequest(r, req); err != nil {
+		return nil, err
+	}
+	if req.Type == 0 {
+		req.Type = int32(pb.SanctionType_SanctionType_Ban)
+	}
+	count, err := game.RevokeSanction(req.AccountId, req.PlayerId, pb.SanctionType(req.Type), req.Operator, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{
+		"RevokeCount": count,
+	}, nil
+}
+
+// 查询账号的处罚记录
+func (this *GameServer) onAdminSanctions(r *http.Request) (any, error) {
+	req := new(adminSanctionReq)
+	if err := ParseAdminRequest(r, req); err != nil {
+		return nil, err
+	}
+	return db.FindSanctionHistory(req.AccountId)
+}
+
+// 查询改名记录
+func (this *GameServer) onAdminNameHistory(r *http.Request) (any, error) {
+	req := new(adminNameHistoryReq)
+	if err := ParseAdminRequest(r, req); err != nil {
+		return nil, err
+	}
+	if req.PlayerId == 0 && req.Name == "" {
+		return nil, errors.New("ArgError")
+	}
+	return game.FindPlayerNameHistory(req.PlayerId, req.Name)
+}
+
+// 重新加载配置数据
+func (this *GameServer) onAdminReload(r *http.Request) (any, error) {
+	return nil, this.reloadCfgs()
+}
+
+// 游戏服进入排空状态,在线玩家全部下线后自动关闭服务器
+func (this *GameServer) onAdminDrain(r *http.Request) (any, error) {
+	if !this.StartDraining() {
+		return nil, errors.New("NotRunning")
+	}
+	return nil, nil
+}
@@ -0,0 +1,86 @@
+package gameserver
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// 导表工具最后生成的md5文件,记录了每个数据文件的md5
+const CfgMd5FileName = "md5.json"
+
+// 定时检查配置数据是否有更新,有更新时自动重新加载
+//
+//	md5.json有变化,并且记录的md5和数据文件都一致时(导表完成),才重新加载
+func (this *GameServer) startCfgWatcher() {
+	interval := this.GetConfig().CfgWatchInterval
+	if interval <= 0 {
+		return
+	}
+	lastMd5, _ := this.checkCfgMd5()
+	this.GetWaitGroup().Add(1)
+	go func() {
+		defer this.GetWaitGroup().Done()
+		this.cfgWatchLoop(time.Duration(interval)*time.Second, lastMd5)
+	}()
+}
+
+func (this *GameServer) cfgWatchLoop(interval time.Duration, lastMd5 string) {
+	slog.Info("cfgWatchLoop begin", "interval", interval, "lastMd5", lastMd5)
+	ticker := time.NewTicker(interval)
+	defer func() {
+		ticker.Stop()
+		slog.Info("cfgWatchLoop end")
+	}()
+	for {
+		select {
+		case <-this.GetContext().Done():
+			return
+		case <-ticker.C:
+			md5Str, ok := this.checkCfgMd5()
+			if !ok || md5Str == lastMd5 {
+				continue
+			}
+			slog.Info("cfgWatchLoop md5 changed", "lastMd5", lastMd5, "md5", md5Str)
+			// 加载失败时不更新lastMd5,修正数据后重新导表会再次触发
+			if this.reloadCfgs() == nil {
+				lastMd5 = md5Str
+			}
+		}
+	}
+}
+
+// 检查md5.json和数据文件是否一致,返回md5.json的md5
+//
+//	导表过程中数据文件和md5.json不一致,返回false,等待下次检查
+func (this *GameServer) checkCfgMd5() (string, bool) {
+	md5FileData, err := os.ReadFile(this.GetCfgDir() + CfgMd5FileName)
+	if err != nil {
+		return "", false
+	}
+	fileMd5s := make(map[string]string)
+	if err = json.Unmarshal(md5FileData, &fileMd5s); err != nil {
+		slog.Debug("checkCfgMd5 unmarshal error", "error", err)
+		return "", false
+	}
+	for fileName, fileMd5 := range fileMd5s {
+		fileData, err := os.ReadFile(this.GetCfgDir() + fileName)
+		if err != nil {
+			slog.Debug("checkCfgMd5 read error", "fileName", fileName, "error", err)
+			return "", false
+		}
+		if fileMd5 != md5Sum(fileData) {
+			slog.Debug("checkCfgMd5 mismatch", "fileName", fileName)
+			return "", false
+		}
+	}
+	return md5Sum(md5FileData), true
+}
+
+func md5Sum(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
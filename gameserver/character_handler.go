@@ -0,0 +1,327 @@
+package gameserver
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/fish-tennis/gentity"
+	. "github.com/fish-tennis/gnet"
+	"github.com/fish-tennis/gserver/cache"
+	"github.com/fish-tennis/gserver/db"
+	"github.com/fish-tennis/gserver/game"
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/network"
+	"github.com/fish-tennis/gserver/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// 一个账号可以在每个区服创建多个角色,删除角色是软删除,恢复期内可以恢复
+
+// 角色列表只需要这几个字段
+var _characterProjection = bson.D{
+	{db.UniqueIdName, 1},
+	{db.PlayerName, 1},
+	{db.PlayerAccountId, 1},
+	{db.PlayerRegionId, 1},
+	{game.ComponentNameBaseInfo, 1},
+	{db.PlayerDeleteTimestamp, 1},
+}
+
+func getPlayerCollection() *mongo.Collection {
+	return db.GetDbMgr().GetEntityDb(db.PlayerDbName).(*gentity.MongoCollection).GetCollection()
+}
+
+func getCharacterConfig() *internal.CharacterConfig {
+	return &gentity.GetApplication().(*GameServer).GetConfig().Character
+}
+
+// 查询账号的所有角色(包含已删除的)
+func findCharacters(accountId int64) ([]*pb.PlayerData, error) {
+	cursor, err := getPlayerCollection().Find(context.Background(), bson.D{{db.PlayerAccountId, accountId}},
+		options.Find().SetProjection(_characterProjection))
+	if err != nil {
+		return nil, err
+	}
+	var characters []*pb.PlayerData
+	defer cursor.Close(context.Background())
+	for cursor.Next(context.Background()) {
+		playerData := &pb.PlayerData{}
+		if err = cursor.Decode(playerData); err != nil {
+			return nil, err
+		}
+		// _id无法直接解析到XId,参考processPlayerEntryGameReq
+		if playerData.XId == 0 {
+			idValue, lookupErr := cursor.Current.LookupErr(db.UniqueIdName)
+			if lookupErr != nil {
+				return nil, lookupErr
+			}
+			playerData.XId = idValue.Int64()
+		}
+		characters = append(characters, playerData)
+	}
+	return characters, cursor.Err()
+}
+
+// 已删除的角色的恢复截止时间
+func getRecoverDeadline(playerData *pb.PlayerData) int64 {
+	if playerData.GetDeleteTimestamp() == 0 {
+		return 0
+	}
+	return playerData.GetDeleteTimestamp() + int64(getCharacterConfig().GetRecoverDuration().Seconds())
+}
+
+// 已删除且超过恢复期的角色,客户端不再显示
+func isCharacterExpired(playerData *pb.PlayerData, now int64) bool {
+	return playerData.GetDeleteTimestamp() > 0 && now >= getRecoverDeadline(playerData)
+}
+
+// 区服内未删除的角色数
+func countAliveCharacters(characters []*pb.PlayerData, regionId int32) int32 {
+	count := int32(0)
+	for _, playerData := range characters {
+		if playerData.GetRegionId() == regionId && playerData.GetDeleteTimestamp() == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func findCharacter(characters []*pb.PlayerData, playerId int64) *pb.PlayerData {
+	for _, playerData := range characters {
+		if playerData.GetXId() == playerId {
+			return playerData
+		}
+	}
+	return nil
+}
+
+// 选择进游戏的角色
+//
+//	playerId>0时,检查角色属于该账号且没有删除,否则选择该区服最近登录的角色
+func selectEntryPlayerId(accountId int64, regionId int32, playerId int64) (int64, pb.ErrorCode) {
+	characters, err := findCharacters(accountId)
+	if err != nil {
+		slog.Error("findCharacters error", "accountId", accountId, "error", err)
+		return 0, pb.ErrorCode_ErrorCode_DbErr
+	}
+	if playerId > 0 {
+		playerData := findCharacter(characters, playerId)
+		if playerData == nil || playerData.GetRegionId() != regionId {
+			return 0, pb.ErrorCode_ErrorCode_NoPlayer
+		}
+		if playerData.GetDeleteTimestamp() > 0 {
+			return 0, pb.ErrorCode_ErrorCode_PlayerDeleted
+		}
+		return playerId, 0
+	}
+	var selected *pb.PlayerData
+	for _, playerData := range characters {
+		if playerData.GetRegionId() != regionId || playerData.GetDeleteTimestamp() > 0 {
+			continue
+		}
+		if selected == nil || playerData.GetBaseInfo().GetLastLoginTimestamp() > selected.GetBaseInfo().GetLastLoginTimestamp() {
+			selected = playerData
+		}
+	}
+	if selected == nil {
+		return 0, pb.ErrorCode_ErrorCode_NoPlayer
+	}
+	return selected.GetXId(), 0
+}
+
+// 设置角色的删除时间戳,0表示恢复
+func setCharacterDeleteTimestamp(accountId, playerId, deleteTimestamp int64) error {
+	_, err := getPlayerCollection().UpdateOne(context.Background(),
+		bson.D{{db.UniqueIdName, playerId}, {db.PlayerAccountId, accountId}},
+		bson.D{{"$set", bson.D{{db.PlayerDeleteTimestamp, deleteTimestamp}}}})
+	return err
+}
+
+// 角色列表
+func onCharacterListReq(connection Connection, packet Packet) {
+	req := packet.Message().(*pb.CharacterListReq)
+	if !internal.SubmitDbTask(req.GetAccountId(), func() {
+		processCharacterListReq(connection, packet, req)
+	}) {
+		network.SendPacketAdaptWithError(connection, packet, &pb.CharacterListRes{
+			AccountId: req.GetAccountId(),
+		}, int32(pb.ErrorCode_ErrorCode_TryLater))
+		slog.Warn("DbWorkerPool full for onCharacterListReq", "accountId", req.GetAccountId())
+	}
+}
+
+// processCharacterListReq 在DB协程池中执行
+func processCharacterListReq(connection Connection, packet Packet, req *pb.CharacterListReq) {
+	res := &pb.CharacterListRes{
+		AccountId:              req.GetAccountId(),
+		MaxCharactersPerRegion: getCharacterConfig().GetMaxPerRegion(),
+	}
+	var errorCode pb.ErrorCode
+	defer func() {
+		network.SendPacketAdaptWithError(connection, packet, res, int32(errorCode))
+	}()
+	if !connection.IsConnected() {
+		return
+	}
+	if !cache.VerifyLoginSession(req.GetAccountId(), req.GetLoginSession()) {
+		errorCode = pb.ErrorCode_ErrorCode_SessionError
+		return
+	}
+	characters, err := findCharacters(req.GetAccountId())
+	if err != nil {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		slog.Error("findCharacters error", "accountId", req.GetAccountId(), "error", err)
+		return
+	}
+	now := time.Now().Unix()
+	for _, playerData := range characters {
+		if isCharacterExpired(playerData, now) {
+			continue
+		}
+		res.Characters = append(res.Characters, &pb.CharacterSummary{
+			PlayerId:           playerData.GetXId(),
+			Name:               playerData.GetName(),
+			RegionId:           playerData.GetRegionId(),
+			Level:              playerData.GetBaseInfo().GetLevel(),
+			LastLoginTimestamp: playerData.GetBaseInfo().GetLastLoginTimestamp(),
+			DeleteTimestamp:    playerData.GetDeleteTimestamp(),
+			RecoverDeadline:    getRecoverDeadline(playerData),
+		})
+	}
+	// 按区服排序,同一个区服内最近登录的在前
+	slices.SortFunc(res.Characters, func(a, b *pb.CharacterSummary) int {
+		if a.RegionId != b.RegionId {
+			return int(a.RegionId - b.RegionId)
+		}
+		if a.LastLoginTimestamp > b.LastLoginTimestamp {
+			return -1
+		} else if a.LastLoginTimestamp < b.LastLoginTimestamp {
+			return 1
+		}
+		return 0
+	})
+}
+
+// 删除角色
+func onDeletePlayerReq(connection Connection, packet Packet) {
+	req := packet.Message().(*pb.DeletePlayerReq)
+	if !internal.SubmitDbTask(req.GetAccountId(), func() {
+		processDeletePlayerReq(connection, packet, req)
+	}) {
+		network.SendPacketAdaptWithError(connection, packet, &pb.DeletePlayerRes{
+			AccountId: req.GetAccountId(),
+			PlayerId:  req.GetPlayerId(),
+		}, int32(pb.ErrorCode_ErrorCode_TryLater))
+		slog.Warn("DbWorkerPool full for onDeletePlayerReq", "accountId", req.GetAccountId())
+	}
+}
+
+// processDeletePlayerReq 在DB协程池中执行
+func processDeletePlayerReq(connection Connection, packet Packet, req *pb.DeletePlayerReq) {
+	res := &pb.DeletePlayerRes{
+		AccountId: req.GetAccountId(),
+		PlayerId:  req.GetPlayerId(),
+	}
+	var errorCode pb.ErrorCode
+	defer func() {
+		network.SendPacketAdaptWithError(connection, packet, res, int32(errorCode))
+		slog.Info("onDeletePlayerReq", "res", res, "error", errorCode)
+	}()
+	if !connection.IsConnected() {
+		return
+	}
+	if !cache.VerifyLoginSession(req.GetAccountId(), req.GetLoginSession()) {
+		errorCode = pb.ErrorCode_ErrorCode_SessionError
+		return
+	}
+	characters, err := findCharacters(req.GetAccountId())
+	if err != nil {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		slog.Error("findCharacters error", "accountId", req.GetAccountId(), "error", err)
+		return
+	}
+	playerData := findCharacter(characters, req.GetPlayerId())
+	if playerData == nil {
+		errorCode = pb.ErrorCode_ErrorCode_NoPlayer
+		return
+	}
+	if playerData.GetDeleteTimestamp() > 0 {
+		errorCode = pb.ErrorCode_ErrorCode_PlayerDeleted
+		return
+	}
+	// 在线(包括掉线保留期)的角色不能删除
+	onlinePlayerId, _ := cache.GetOnlineAccount(req.GetAccountId())
+	if onlinePlayerId == req.GetPlayerId() || game.GetPlayer(req.GetPlayerId()) != nil {
+		errorCode = pb.ErrorCode_ErrorCode_PlayerOnline
+		return
+	}
+	playerData.DeleteTimestamp = time.Now().Unix()
+	if err = setCharacterDeleteTimestamp(req.GetAccountId(), req.GetPlayerId(), playerData.DeleteTimestamp); err != nil {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		slog.Error("DeletePlayer error", "playerId", req.GetPlayerId(), "error", err)
+		return
+	}
+	res.RecoverDeadline = getRecoverDeadline(playerData)
+}
+
+// 恢复已删除的角色
+func onRecoverPlayerReq(connection Connection, packet Packet) {
+	req := packet.Message().(*pb.RecoverPlayerReq)
+	if !internal.SubmitDbTask(req.GetAccountId(), func() {
+		processRecoverPlayerReq(connection, packet, req)
+	}) {
+		network.SendPacketAdaptWithError(connection, packet, &pb.RecoverPlayerRes{
+			AccountId: req.GetAccountId(),
+			PlayerId:  req.GetPlayerId(),
+		}, int32(pb.ErrorCode_ErrorCode_TryLater))
+		slog.Warn("DbWorkerPool full for onRecoverPlayerReq", "accountId", req.GetAccountId())
+	}
+}
+
+// processRecoverPlayerReq 在DB协程池中执行
+func processRecoverPlayerReq(connection Connection, packet Packet, req *pb.RecoverPlayerReq) {
+	res := &pb.RecoverPlayerRes{
+		AccountId: req.GetAccountId(),
+		PlayerId:  req.GetPlayerId(),
+	}
+	var errorCode pb.ErrorCode
+	defer func() {
+		network.SendPacketAdaptWithError(connection, packet, res, int32(errorCode))
+		slog.Info("onRecoverPlayerReq", "res", res, "error", errorCode)
+	}()
+	if !connection.IsConnected() {
+		return
+	}
+	if !cache.VerifyLoginSession(req.GetAccountId(), req.GetLoginSession()) {
+		errorCode = pb.ErrorCode_ErrorCode_SessionError
+		return
+	}
+	characters, err := findCharacters(req.GetAccountId())
+	if err != nil {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		slog.Error("findCharacters error", "accountId", req.GetAccountId(), "error", err)
+		return
+	}
+	playerData := findCharacter(characters, req.GetPlayerId())
+	// 超过恢复期的角色,当作不存在
+	if playerData == nil || isCharacterExpired(playerData, time.Now().Unix()) {
+		errorCode = pb.ErrorCode_ErrorCode_NoPlayer
+		return
+	}
+	if playerData.GetDeleteTimestamp() == 0 {
+		return
+	}
+	if countAliveCharacters(characters, playerData.GetRegionId()) >= getCharacterConfig().GetMaxPerRegion() {
+		errorCode = pb.ErrorCode_ErrorCode_PlayerCountLimit
+		return
+	}
+	if err = setCharacterDeleteTimestamp(req.GetAccountId(), req.GetPlayerId(), 0); err != nil {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		slog.Error("RecoverPlayer error", "playerId", req.GetPlayerId(), "error", err)
+		return
+	}
+}
@@ -0,0 +1,89 @@
+package gameserver
+
+import (
+	"log/slog"
+	"time"
+
+	. "github.com/fish-tennis/gnet"
+	"github.com/fish-tennis/gserver/game"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+var (
+	// 排空时每秒通知下线的玩家数量,防止大量玩家同时到其他游戏服登录
+	DrainPlayerCountPerSecond = 100
+)
+
+// 开始排空
+//
+//	不再接收新玩家,在线玩家分批保存数据后通知客户端重新登录
+//	本服的状态同步给其他服务器后,公会会重新分配到其他游戏服(DistributedEntityMgr.ReBalance)
+//	排空完成后,再正常关闭服务器
+func (this *GameServer) StartDraining() bool {
+	if !this.BaseServer.StartDraining() {
+		return false
+	}
+	this.GetWaitGroup().Add(1)
+	go func() {
+		defer this.GetWaitGroup().Done()
+		this.drainLoop()
+	}()
+	return true
+}
+
+func (this *GameServer) drainLoop() {
+	slog.Info("drainLoop begin")
+	ticker := time.NewTicker(time.Second)
+	defer func() {
+		ticker.Stop()
+		slog.Info("drainLoop end")
+	}()
+	// 已经通知过的玩家
+	drainedPlayers := make(map[int64]struct{})
+	for {
+		select {
+		case <-this.GetContext().Done():
+			return
+		case <-ticker.C:
+			if !this.IsDraining() {
+				return
+			}
+			drainCount := 0
+			onlineCount := 0
+			this.playerMap.Range(func(key, value any) bool {
+				onlineCount++
+				player := value.(*game.Player)
+				if _, ok := drainedPlayers[player.GetId()]; ok {
+					return true
+				}
+				if drainCount >= DrainPlayerCountPerSecond {
+					return true
+				}
+				if player.Drain(this.GetId()) {
+					drainedPlayers[player.GetId()] = struct{}{}
+					drainCount++
+				}
+				return true
+			})
+			if onlineCount == 0 {
+				// 排空完成,走正常的关闭流程(设置退出状态,注销服务器信息,关闭服务器)
+				slog.Info("drain players complete")
+				this.RequestExit()
+				return
+			}
+			slog.Info("draining", "onlineCount", onlineCount, "drainCount", drainCount)
+		}
+	}
+}
+
+// 其他服务器发来的排空请求
+func (this *GameServer) onDrainServer(connection Connection, packet Packet) {
+	req := packet.Message().(*pb.DrainServerReq)
+	if req.GetServerId() != this.GetId() {
+		slog.Error("onDrainServer serverId error", "serverId", req.GetServerId())
+		return
+	}
+	if !this.StartDraining() {
+		slog.Warn("onDrainServer failed", "status", this.GetStatus())
+	}
+}
@@ -76,15 +76,21 @@ func processPlayerEntryGameReq(connection Connection, packet Packet, req *pb.Pla
 		errorCode = pb.ErrorCode_ErrorCode_SessionError
 		return
 	}
-	playerId, err := db.GetPlayerDb().FindPlayerIdByAccountId(accountId, req.GetRegionId())
-	//hasData,err := db.GetPlayerDb().FindPlayerByAccountId(req.GetAccountId(), req.GetRegionId(), playerData)
+	// 一个账号在一个区服可以有多个角色
+	playerId, errorCode := selectEntryPlayerId(accountId, req.GetRegionId(), req.GetPlayerId())
+	if errorCode != 0 {
+		return
+	}
+	// 封号检查,登录服只检查了账号级别的封号
+	now := time.Now().Unix()
+	sanctions, err := db.FindActiveSanctions(accountId, now)
 	if err != nil {
 		errorCode = pb.ErrorCode_ErrorCode_DbErr
-		slog.Error("db error", "error", err)
+		slog.Error("FindActiveSanctions error", "accountId", accountId, "error", err)
 		return
 	}
-	if playerId == 0 {
-		errorCode = pb.ErrorCode_ErrorCode_NoPlayer
+	if db.MatchSanction(sanctions, pb.SanctionType_SanctionType_Ban, playerId, now) != nil {
+		errorCode = pb.ErrorCode_ErrorCode_AccountBanned
 		return
 	}
 	// 检查该账号是否已经有对应的在线玩家
@@ -163,6 +169,8 @@ func processPlayerEntryGameReq(connection Connection, packet Packet, req *pb.Pla
 		errorCode = pb.ErrorCode_ErrorCode_NoPlayer
 		return
 	}
+	// 禁言,冻结交易等处罚,在线期间新增的处罚会把玩家踢下线,重新进游戏时再加载
+	entryPlayer.SetSanctions(sanctions)
 	// 加入在线玩家表
 	game.GetPlayerMgr().AddPlayer(entryPlayer)
 	entryPlayer.SetConnection(connection, network.IsGatePacket(packet))
@@ -257,6 +265,21 @@ func processCreatePlayerReq(connection Connection, packet Packet, req *pb.Create
 		errorCode = pb.ErrorCode_ErrorCode_SessionError
 		return
 	}
+	if game.CheckPlayerName(req.Name) != nil {
+		errorCode = pb.ErrorCode_ErrorCode_PlayerNameInvalid
+		return
+	}
+	// 区服内的角色数限制,恢复期内的已删除角色不计入
+	characters, err := findCharacters(req.GetAccountId())
+	if err != nil {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		slog.Error("findCharacters error", "accountId", req.GetAccountId(), "error", err)
+		return
+	}
+	if countAliveCharacters(characters, req.GetRegionId()) >= getCharacterConfig().GetMaxPerRegion() {
+		errorCode = pb.ErrorCode_ErrorCode_PlayerCountLimit
+		return
+	}
 	newPlayerIdValue, err := db.GetKvDb().Inc(db.PlayerIdKeyName, int64(1), true)
 	if err != nil {
 		errorCode = pb.ErrorCode_ErrorCode_DbErr
@@ -295,6 +318,16 @@ func processCreatePlayerReq(connection Connection, packet Packet, req *pb.Create
 		slog.Error("CreatePlayerFromDataErr", "accountId", req.AccountId, "playerData", playerData)
 		return
 	}
+	// 先占用名字,插入失败时释放
+	reserved, err := game.ReservePlayerName(playerData.Name, playerData.XId)
+	if err != nil {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		return
+	}
+	if !reserved {
+		errorCode = pb.ErrorCode_ErrorCode_NameDuplicate
+		return
+	}
 	newPlayerSaveData := make(map[string]interface{})
 	newPlayerSaveData[db.UniqueIdName] = playerData.XId
 	newPlayerSaveData[db.PlayerName] = playerData.Name
@@ -307,9 +340,11 @@ func processCreatePlayerReq(connection Connection, packet Packet, req *pb.Create
 		if isDuplicateKey {
 			errorCode = pb.ErrorCode_ErrorCode_NameDuplicate
 		}
+		game.ReleasePlayerName(playerData.Name, playerData.XId)
 		slog.Error("CreatePlayer error", "errorCode", errorCode, "error", err, "playerData", playerData)
 		return
 	}
+	res.PlayerId = playerData.XId
 }
 
 // gate转发的客户端掉线消息
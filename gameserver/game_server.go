@@ -19,7 +19,8 @@ import (
 )
 
 var (
-	_ gentity.Application = (*GameServer)(nil)
+	_ gentity.Application  = (*GameServer)(nil)
+	_ game.GateBroadcaster = (*GameServer)(nil)
 )
 
 // 游戏服
@@ -57,6 +58,9 @@ func (this *GameServer) Init(ctx context.Context, configFile string) bool {
 	this.initDb()
 	this.initCache()
 	this.initNetwork()
+	this.registerAdminHandlers()
+	// 上传服务器信息时附带负载信息,供登录服分配游戏服
+	this.AddServerInfoUpdateHook(this.updateServerLoad)
 	// 初始化DB操作协程池,将进游/创角等含DB查询的请求从收包goroutine卸载
 	InitDbWorkerPool()
 
@@ -70,6 +74,7 @@ func (this *GameServer) Init(ctx context.Context, configFile string) bool {
 // 运行
 func (this *GameServer) Run(ctx context.Context) {
 	this.BaseServer.Run(ctx)
+	this.startCfgWatcher()
 	slog.Info("GameServer.Run")
 }
 
@@ -110,6 +115,28 @@ func (this *GameServer) loadCfgs() {
 	}
 }
 
+// 重新加载配置数据
+//
+//	加载失败时继续使用旧的配置数据,加载成功后通知所有在线玩家
+func (this *GameServer) reloadCfgs() error {
+	err := cfg.Load(this.GetCfgDir(), nil)
+	if err != nil {
+		slog.Error("reloadCfgs error", "error", err)
+		return err
+	}
+	var playerCount, failedCount int32
+	this.playerMap.Range(func(key, value any) bool {
+		playerCount++
+		if !value.(*game.Player).CfgReloaded() {
+			failedCount++
+			slog.Warn("reloadCfgs notify player failed", "playerId", key)
+		}
+		return true
+	})
+	slog.Info("reloadCfgs", "playerCount", playerCount, "failedCount", failedCount)
+	return nil
+}
+
 // 初始化数据库
 func (this *GameServer) initDb() {
 	// 使用mongodb来演示
@@ -120,14 +147,24 @@ func (this *GameServer) initDb() {
 	mongoDb.RegisterEntityDb(db.GuildDbName, true, db.UniqueIdName)
 	// 全局对象数据库(如GlobalEntity)
 	mongoDb.RegisterEntityDb(db.GlobalDbName, true, db.GlobalDbKeyName)
+	// 玩家名占用表,_id就是玩家名
+	mongoDb.RegisterEntityDb(db.PlayerNameDbName, true, db.UniqueIdName)
+	// 改名记录
+	playerNameHistoryDb := mongoDb.RegisterEntityDb(db.PlayerNameHistoryDbName, true, db.UniqueIdName)
+	// 处罚记录
+	mongoDb.RegisterEntityDb(db.SanctionDbName, true, db.UniqueIdName)
 	// kv数据库
 	mongoDb.RegisterKvDb(db.GlobalDbName, true, db.GlobalDbKeyName, db.GlobalDbValueName)
 	if !mongoDb.Connect() {
 		panic("connect db error")
 	}
+	// 改名记录按玩家id和旧名字查询
+	playerNameHistoryDb.(*gentity.MongoCollection).CreateIndex(db.PlayerNamePlayerId, false)
+	playerNameHistoryDb.(*gentity.MongoCollection).CreateIndex("OldName", false)
 	// 玩家数据库设置分片
 	mongoDb.ShardDatabase(this.GetConfig().Mongo.Db)
 	db.SetDbMgr(mongoDb)
+	db.CreateSanctionIndex()
 }
 
 // 初始化redis缓存
@@ -196,44 +233,34 @@ func (this *GameServer) repairPlayerCache(playerId, accountId int64) error {
 // 注册客户端消息回调
 func (this *GameServer) registerClientPacket(handler *DefaultConnectionHandler) {
 	// 状态检查包装器:非Running状态时拒绝客户端请求,避免在服务器初始化或退出阶段处理进游/创角等请求
+	// 排空状态不再接收新玩家,只允许在线玩家重连
 	checkRunning := func(handler PacketHandler) PacketHandler {
 		return func(connection Connection, packet Packet) {
 			if this.IsRunning() {
 				handler(connection, packet)
 				return
 			}
-			// 服务器正在关闭时告知客户端ServerClosing,其他非Running状态(如初始化中)告知TryLater
-			status := this.GetStatus()
-			var errCode pb.ErrorCode
-			if status == ServerStatus_Exit {
-				errCode = pb.ErrorCode_ErrorCode_ServerClosing
-			} else {
-				errCode = pb.ErrorCode_ErrorCode_TryLater
+			if this.IsDraining() {
+				if req, ok := packet.Message().(*pb.PlayerReconnectGameReq); ok && game.GetPlayer(req.GetPlayerId()) != nil {
+					handler(connection, packet)
+					return
+				}
 			}
-			network.SendPacketAdaptWithError(connection, packet, &pb.ErrorRes{
-				Command:  int32(packet.Command()),
-				ResultId: int32(errCode),
-			}, int32(errCode))
+			this.sendStatusErrorRes(connection, packet)
 		}
 	}
 	// 手动注册特殊的消息回调,用checkRunning包装以拦截非Running状态的请求
 	network.RegisterPacketHandler(handler, new(pb.PlayerEntryGameReq), checkRunning(onPlayerEntryGameReq))
 	network.RegisterPacketHandler(handler, new(pb.PlayerReconnectGameReq), checkRunning(onPlayerReconnectGameReq))
 	network.RegisterPacketHandler(handler, new(pb.CreatePlayerReq), checkRunning(onCreatePlayerReq))
+	network.RegisterPacketHandler(handler, new(pb.CharacterListReq), checkRunning(onCharacterListReq))
+	network.RegisterPacketHandler(handler, new(pb.DeletePlayerReq), checkRunning(onDeletePlayerReq))
+	network.RegisterPacketHandler(handler, new(pb.RecoverPlayerReq), checkRunning(onRecoverPlayerReq))
 	handler.SetUnRegisterHandler(func(connection Connection, packet Packet) {
 		// 非Running状态时拒绝请求,防止服务器退出阶段继续路由消息到玩家协程
-		if !this.IsRunning() {
-			status := this.GetStatus()
-			var errCode pb.ErrorCode
-			if status == ServerStatus_Exit {
-				errCode = pb.ErrorCode_ErrorCode_ServerClosing
-			} else {
-				errCode = pb.ErrorCode_ErrorCode_TryLater
-			}
-			network.SendPacketAdaptWithError(connection, packet, &pb.ErrorRes{
-				Command:  int32(packet.Command()),
-				ResultId: int32(errCode),
-			}, int32(errCode))
+		// 排空状态下,在线玩家的消息仍然正常处理
+		if !this.IsRunning() && !this.IsDraining() {
+			this.sendStatusErrorRes(connection, packet)
 			return
 		}
 		var playerId int64
@@ -280,6 +307,25 @@ func (this *GameServer) registerClientPacket(handler *DefaultConnectionHandler)
 	game.AutoRegisterPlayerPacketHandler(handler)
 }
 
+// 非Running状态时,告知客户端拒绝请求的原因
+//
+//	服务器正在关闭告知ServerClosing,正在排空告知ServerDraining,其他非Running状态(如初始化中)告知TryLater
+func (this *GameServer) sendStatusErrorRes(connection Connection, packet Packet) {
+	var errCode pb.ErrorCode
+	switch this.GetStatus() {
+	case ServerStatus_Exit:
+		errCode = pb.ErrorCode_ErrorCode_ServerClosing
+	case ServerStatus_Draining:
+		errCode = pb.ErrorCode_ErrorCode_ServerDraining
+	default:
+		errCode = pb.ErrorCode_ErrorCode_TryLater
+	}
+	network.SendPacketAdaptWithError(connection, packet, &pb.ErrorRes{
+		Command:  int32(packet.Command()),
+		ResultId: int32(errCode),
+	}, int32(errCode))
+}
+
 // 注册网关消息回调
 func (this *GameServer) registerGatePacket(handler *DefaultConnectionHandler) {
 	this.registerClientPacket(handler)
@@ -291,6 +337,14 @@ func (this *GameServer) registerGatePacket(handler *DefaultConnectionHandler) {
 func (this *GameServer) registerServerPacket(handler *DefaultConnectionHandler) {
 	network.RegisterPacketHandler(handler, new(pb.KickPlayerReq), this.onKickPlayer)
 	network.RegisterPacketHandler(handler, new(pb.RoutePlayerMessage), this.onRoutePlayerMessage)
+	network.RegisterPacketHandler(handler, new(pb.DrainServerReq), this.onDrainServer)
+}
+
+// 发给所有连接到本服的网关
+func (this *GameServer) BroadcastToGates(packet Packet) {
+	if this.gateListener != nil {
+		this.gateListener.Broadcast(packet)
+	}
 }
 
 // 添加一个在线玩家
@@ -320,6 +374,18 @@ func (this *GameServer) RemovePlayer(player IPlayer) {
 	cache.RemoveOnlinePlayer(player.GetId(), this.GetId())
 }
 
+// 统计在线玩家数和玩家协程消息队列里待处理的消息总数
+func (this *GameServer) updateServerLoad(serverInfo *pb.ServerInfo) {
+	var onlineCount, queueDepth int32
+	this.playerMap.Range(func(key, value any) bool {
+		onlineCount++
+		queueDepth += value.(*game.Player).GetPendingMessageCount()
+		return true
+	})
+	serverInfo.OnlineCount = onlineCount
+	serverInfo.QueueDepth = queueDepth
+}
+
 // 获取一个在线玩家
 func (this *GameServer) GetPlayer(playerId int64) IPlayer {
 	if v, ok := this.playerMap.Load(playerId); ok {
@@ -15,11 +15,26 @@ type ClientListerHandler struct {
 func (this *ClientListerHandler) OnConnectionConnected(listener Listener, acceptedConnection Connection) {
 }
 
+// 登录排队中的客户端
+type loginQueueClient struct {
+	loginServerId int32
+}
+
 // 客户端断开连接
 func (this *ClientListerHandler) OnConnectionDisconnect(listener Listener, connection Connection) {
 	if connection.GetTag() == nil {
 		return
 	}
+	if queueClient, ok := connection.GetTag().(*loginQueueClient); ok {
+		connection.SetTag(nil)
+		// 通知LoginServer,排队中的客户端掉线了
+		_gateServer.GetServerList().SendPacket(queueClient.loginServerId, network.NewGatePacket(
+			int64(connection.GetConnectionId()), 0, &pb.ClientDisconnect{
+				ClientConnId: connection.GetConnectionId(),
+			}))
+		slog.Debug("LoginQueueClientDisconnect", "connId", connection.GetConnectionId(), "loginServerId", queueClient.loginServerId)
+		return
+	}
 	if clientData, ok := connection.GetTag().(*network.ClientData); ok {
 		connection.SetTag(nil)
 		playerId := clientData.GetPlayerId()
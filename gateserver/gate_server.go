@@ -104,8 +104,12 @@ func (s *GateServer) registerClientPacket(clientHandler *DefaultConnectionHandle
 	// 手动注册消息回调
 	network.RegisterPacketHandler(clientHandler, new(pb.AccountReg), s.routeToLoginServer)
 	network.RegisterPacketHandler(clientHandler, new(pb.LoginReq), s.routeToLoginServer)
+	network.RegisterPacketHandler(clientHandler, new(pb.AccountBindReq), s.routeToLoginServer)
 	network.RegisterPacketHandler(clientHandler, new(pb.PlayerEntryGameReq), s.routeToGameServerWithConnId)
 	network.RegisterPacketHandler(clientHandler, new(pb.CreatePlayerReq), s.routeToGameServerWithConnId)
+	network.RegisterPacketHandler(clientHandler, new(pb.CharacterListReq), s.routeToGameServerWithConnId)
+	network.RegisterPacketHandler(clientHandler, new(pb.DeletePlayerReq), s.routeToGameServerWithConnId)
+	network.RegisterPacketHandler(clientHandler, new(pb.RecoverPlayerReq), s.routeToGameServerWithConnId)
 	// 重连请求:客户端是新连接没有GameServerId,需通过Redis查找玩家所在游戏服来路由
 	network.RegisterPacketHandler(clientHandler, new(pb.PlayerReconnectGameReq), s.routeReconnectToGameServer)
 
@@ -227,6 +231,7 @@ func (s *GateServer) routeToGameServerWithConnId(connection Connection, packet P
 // 路由策略:
 //   - 玩家在线(Redis有记录):校验req.GameServerId与在线游戏服一致,不一致则通知重新登录
 //   - 玩家不在线(超过保留期/从未在线):直接转发到req.GameServerId,由游戏服从数据库加载
+//   - 玩家不在线且req.GameServerId正在排空:通知重新登录,由登录服分配其他游戏服
 //
 // client -> gateserver -> gameServer
 func (s *GateServer) routeReconnectToGameServer(connection Connection, packet Packet) {
@@ -250,6 +255,15 @@ func (s *GateServer) routeReconnectToGameServer(connection Connection, packet Pa
 			pb.ErrorCode_ErrorCode_ReconnectNeedRelogin, "GameServerIdMismatch")
 		return
 	}
+	if onlineGameServerId == 0 {
+		targetServerInfo := s.GetServerList().GetServerInfo(targetGameServerId)
+		if targetServerInfo != nil && !IsServerAvailable(targetServerInfo) {
+			slog.Debug("routeReconnectToGameServer gameServerDraining", "playerId", req.GetPlayerId(), "targetGameServerId", targetGameServerId)
+			s.sendRouteErrorRes(connection, packet.Command(), packet.RpcCallId(),
+				pb.ErrorCode_ErrorCode_ReconnectNeedRelogin, "GameServerDraining")
+			return
+		}
+	}
 	// 玩家在线且GameServerId一致,或玩家不在线(转发到req.GameServerId由游戏服加载DB)
 	message := packet.Message()
 	data := packet.GetStreamData()
@@ -308,8 +322,13 @@ func (s *GateServer) routeToGameServer(connection Connection, packet Packet) {
 // 注册服务器消息回调
 func (s *GateServer) registerServerPacket(serverHandler *DefaultConnectionHandler) {
 	network.RegisterPacketHandler(serverHandler, new(pb.AccountRes), s.routeToClientWithConnId)
+	network.RegisterPacketHandler(serverHandler, new(pb.AccountBindRes), s.routeToClientWithConnId)
 	network.RegisterPacketHandler(serverHandler, new(pb.LoginRes), s.onLoginRes)
+	network.RegisterPacketHandler(serverHandler, new(pb.LoginQueueUpdate), s.routeToClientWithConnId)
 	network.RegisterPacketHandler(serverHandler, new(pb.CreatePlayerRes), s.routeToClientWithConnId)
+	network.RegisterPacketHandler(serverHandler, new(pb.CharacterListRes), s.routeToClientWithConnId)
+	network.RegisterPacketHandler(serverHandler, new(pb.DeletePlayerRes), s.routeToClientWithConnId)
+	network.RegisterPacketHandler(serverHandler, new(pb.RecoverPlayerRes), s.routeToClientWithConnId)
 	network.RegisterPacketHandler(serverHandler, new(pb.PlayerEntryGameRes), s.onPlayerEntryGameRes)
 	// 重连响应:需要为新的客户端连接建立ClientData绑定
 	network.RegisterPacketHandler(serverHandler, new(pb.PlayerReconnectGameRes), s.onPlayerReconnectGameRes)
@@ -317,6 +336,8 @@ func (s *GateServer) registerServerPacket(serverHandler *DefaultConnectionHandle
 	// 必须注册专门 handler:否则该消息会走默认 routeToClient 被原样转发给客户端,
 	// 客户端收到的命令号是 GateRouteClientPacketError 的消息号而非原请求号,无法识别是哪个请求失败
 	network.RegisterPacketHandler(serverHandler, new(pb.GateRouteClientPacketError), s.onGateRouteClientPacketError)
+	// 世界聊天:game服发给所有网关,网关再广播给自己的所有客户端
+	network.RegisterPacketHandler(serverHandler, new(pb.WorldChatBroadcast), s.onWorldChatBroadcast)
 
 	serverHandler.SetUnRegisterHandler(s.routeToClient)
 }
@@ -357,6 +378,11 @@ func (s *GateServer) onLoginRes(connection Connection, packet Packet) {
 		clientData.SetGameServerId(res.GetGameServer().GetServerId())
 		clientData.SetConnection(clientConn)
 		clientConn.SetTag(clientData)
+	} else if packet.ErrorCode() == uint32(pb.ErrorCode_ErrorCode_InLoginQueue) {
+		// 排队中,记录所在的登录服,客户端断开连接时通知登录服退出排队
+		if loginServerId, ok := connection.GetTag().(int32); ok {
+			clientConn.SetTag(&loginQueueClient{loginServerId: loginServerId})
+		}
 	}
 	// 透传 rpcCallId 给客户端
 	clientPacket := NewProtoPacket(packet.Command(), packet.Message()).SetErrorCode(packet.ErrorCode()).WithRpc(packet.RpcCallId())
@@ -551,3 +577,31 @@ func (s *GateServer) onGateRouteClientPacketError(connection Connection, packet
 	s.sendRouteErrorRes(clientConn, PacketCommand(errorMsg.GetCommand()), packet.RpcCallId(),
 		pb.ErrorCode_ErrorCode_RouteClientPacketError, errorMsg.GetResultStr())
 }
+
+// 世界聊天广播给本网关的所有客户端
+func (s *GateServer) onWorldChatBroadcast(connection Connection, packet Packet) {
+	worldChat := packet.Message().(*pb.WorldChatBroadcast)
+	if worldChat.GetMessage() == nil {
+		return
+	}
+	// 只序列化1次,所有客户端共用
+	bytes, err := proto.Marshal(worldChat.GetMessage())
+	if err != nil {
+		slog.Error("onWorldChatBroadcast marshal error", "err", err)
+		return
+	}
+	cmd := PacketCommand(network.GetCommandByProto(worldChat.GetMessage()))
+	// 持锁期间只拷贝连接,释放锁后再发送,避免慢客户端阻塞写锁
+	s.clientsMutex.RLock()
+	clientConns := make([]Connection, 0, len(s.clients))
+	for _, clientData := range s.clients {
+		if clientConn := clientData.GetConnection(); clientConn != nil {
+			clientConns = append(clientConns, clientConn)
+		}
+	}
+	s.clientsMutex.RUnlock()
+	for _, clientConn := range clientConns {
+		clientConn.SendPacket(NewProtoPacketWithData(cmd, bytes))
+	}
+	slog.Debug("onWorldChatBroadcast", "fromPlayerId", worldChat.GetMessage().GetFromPlayerId(), "clientCount", len(clientConns))
+}
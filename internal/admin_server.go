@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 管理接口的路径前缀
+const AdminPathPrefix = "/admin/"
+
+// 管理接口请求体的最大长度
+const AdminMaxBodySize = 1 << 20
+
+var ErrAdminRequest = errors.New("admin request error")
+
+// 管理后台http接口配置
+type AdminConfig struct {
+	Addr  string `yaml:"Addr"`  // 监听地址,空表示不开启
+	Token string `yaml:"Token"` // 请求头 Authorization: Bearer Token
+}
+
+// 管理接口,返回值序列化成json
+//
+//	在http协程中调用,需要注意线程安全
+type AdminHandler func(r *http.Request) (any, error)
+
+// 管理接口的返回格式
+type AdminResponse struct {
+	Error string `json:"Error,omitempty"`
+	Data  any    `json:"Data,omitempty"`
+}
+
+// 管理后台http服务
+//
+//	用于GM工具和运维脚本,不对客户端开放,正式环境需要配合防火墙只对内网开放
+type AdminServer struct {
+	config   AdminConfig
+	handlers map[string]AdminHandler
+	server   *http.Server
+}
+
+func NewAdminServer(config AdminConfig) *AdminServer {
+	return &AdminServer{
+		config:   config,
+		handlers: make(map[string]AdminHandler),
+	}
+}
+
+// 注册管理接口,name不带前缀,如"status"对应/admin/status
+func (this *AdminServer) Register(name string, handler AdminHandler) {
+	this.handlers[name] = handler
+}
+
+// 开始监听,需要在注册完管理接口之后调用
+func (this *AdminServer) Start() error {
+	listener, err := net.Listen("tcp", this.config.Addr)
+	if err != nil {
+		return err
+	}
+	this.server = &http.Server{
+		Handler:           this,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := this.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("AdminServer.Serve error", "addr", this.config.Addr, "error", err)
+		}
+	}()
+	slog.Info("AdminServer.Start", "addr", this.config.Addr)
+	return nil
+}
+
+func (this *AdminServer) Stop() {
+	if this.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	this.server.Shutdown(ctx)
+	slog.Info("AdminServer.Stop")
+}
+
+func (this *AdminServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !this.checkToken(r) {
+		this.writeResponse(w, http.StatusUnauthorized, &AdminResponse{Error: "Unauthorized"})
+		slog.Warn("AdminServer unauthorized", "path", r.URL.Path, "remoteAddr", r.RemoteAddr)
+		return
+	}
+	handler, ok := this.handlers[strings.TrimPrefix(r.URL.Path, AdminPathPrefix)]
+	if !ok || !strings.HasPrefix(r.URL.Path, AdminPathPrefix) {
+		this.writeResponse(w, http.StatusNotFound, &AdminResponse{Error: "NotFound"})
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, AdminMaxBodySize)
+	data, err := this.callHandler(handler, r)
+	// 管理操作都记录日志,用于审计
+	slog.Info("AdminServer", "path", r.URL.Path, "remoteAddr", r.RemoteAddr, "error", err)
+	if err != nil {
+		this.writeResponse(w, http.StatusOK, &AdminResponse{Error: err.Error()})
+		return
+	}
+	this.writeResponse(w, http.StatusOK, &AdminResponse{Data: data})
+}
+
+func (this *AdminServer) callHandler(handler AdminHandler, r *http.Request) (data any, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			slog.Error("AdminHandler panic", "path", r.URL.Path, "error", e)
+			SendAlert(e)
+			err = errors.New("panic")
+		}
+	}()
+	return handler(r)
+}
+
+func (this *AdminServer) checkToken(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(this.config.Token)) == 1
+}
+
+func (this *AdminServer) writeResponse(w http.ResponseWriter, statusCode int, res *AdminResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		slog.Error("AdminServer writeResponse error", "error", err)
+	}
+}
+
+// 解析管理接口的json请求体
+func ParseAdminRequest(r *http.Request, req any) error {
+	if r.Method != http.MethodPost {
+		return ErrAdminRequest
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, req); err != nil {
+		return ErrAdminRequest
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminServer(t *testing.T) {
+	adminServer := NewAdminServer(AdminConfig{Token: "test-token"})
+	adminServer.Register("echo", func(r *http.Request) (any, error) {
+		req := make(map[string]string)
+		if err := ParseAdminRequest(r, &req); err != nil {
+			return nil, err
+		}
+		return req, nil
+	})
+	doRequest := func(path, token, body string) (int, *AdminResponse) {
+		r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		adminServer.ServeHTTP(w, r)
+		res := new(AdminResponse)
+		if err := json.Unmarshal(w.Body.Bytes(), res); err != nil {
+			t.Fatalf("path:%v body:%v err:%v", path, w.Body.String(), err)
+		}
+		return w.Code, res
+	}
+	if code, _ := doRequest("/admin/echo", "", `{}`); code != http.StatusUnauthorized {
+		t.Errorf("no token code:%v", code)
+	}
+	if code, _ := doRequest("/admin/echo", "wrong-token", `{}`); code != http.StatusUnauthorized {
+		t.Errorf("wrong token code:%v", code)
+	}
+	if code, _ := doRequest("/admin/notexist", "test-token", `{}`); code != http.StatusNotFound {
+		t.Errorf("not found code:%v", code)
+	}
+	code, res := doRequest("/admin/echo", "test-token", `{"Name":"abc"}`)
+	if code != http.StatusOK || res.Error != "" || res.Data.(map[string]any)["Name"] != "abc" {
+		t.Errorf("echo code:%v res:%v", code, res)
+	}
+	if _, res = doRequest("/admin/echo", "test-token", `not json`); res.Error == "" {
+		t.Errorf("bad json res:%v", res)
+	}
+}
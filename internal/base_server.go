@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -40,6 +41,70 @@ type RedisConfig struct {
 	DB       int      `yaml:"DB"`
 }
 
+// 登录服分配游戏服的配置
+type GameServerSelectConfig struct {
+	Strategy string `yaml:"Strategy"` // LeastLoad(默认) P2C Random
+	Sticky   bool   `yaml:"Sticky"`   // 是否优先分配到账号上次的游戏服
+}
+
+// 角色配置(仅GameServer使用)
+type CharacterConfig struct {
+	MaxPerRegion int32 `yaml:"MaxPerRegion"` // 每个账号在每个区服最多可以创建的角色数,默认1
+	RecoverDays  int32 `yaml:"RecoverDays"`  // 删除的角色在多少天内可以恢复,默认7
+}
+
+// 每个区服最多可以创建的角色数
+func (this *CharacterConfig) GetMaxPerRegion() int32 {
+	if this.MaxPerRegion <= 0 {
+		return 1
+	}
+	return this.MaxPerRegion
+}
+
+// 删除的角色可以恢复的时长
+func (this *CharacterConfig) GetRecoverDuration() time.Duration {
+	if this.RecoverDays <= 0 {
+		return time.Hour * 24 * 7
+	}
+	return time.Hour * 24 * time.Duration(this.RecoverDays)
+}
+
+// 公会配置(仅GameServer使用)
+type GuildConfig struct {
+	JoinRequestMaxCount int32 `yaml:"JoinRequestMaxCount"` // 每个公会最多保留的入会申请数量,默认50,负数表示不限制
+	JoinRequestTTL      int32 `yaml:"JoinRequestTTL"`      // 入会申请的有效期(秒),默认3天,负数表示不过期
+}
+
+// 每个公会最多保留的入会申请数量,0表示不限制
+func (this *GuildConfig) GetJoinRequestMaxCount() int {
+	if this.JoinRequestMaxCount < 0 {
+		return 0
+	}
+	if this.JoinRequestMaxCount == 0 {
+		return 50
+	}
+	return int(this.JoinRequestMaxCount)
+}
+
+// 入会申请的有效期,0表示不过期
+func (this *GuildConfig) GetJoinRequestTTL() time.Duration {
+	if this.JoinRequestTTL < 0 {
+		return 0
+	}
+	if this.JoinRequestTTL == 0 {
+		return time.Hour * 24 * 3
+	}
+	return time.Second * time.Duration(this.JoinRequestTTL)
+}
+
+// 登录方式配置(仅LoginServer使用)
+type LoginProviderConfig struct {
+	Type        string `yaml:"Type"`        // guest HmacToken
+	Name        string `yaml:"Name"`        // 对应LoginReq.Provider,默认和Type一样
+	Secret      string `yaml:"Secret"`      // HmacToken的签名密钥
+	TokenMaxAge int32  `yaml:"TokenMaxAge"` // HmacToken的最长有效期(秒),0表示只检查token里的过期时间
+}
+
 type BaseServerConfig struct {
 	// 服务器id
 	ServerId int32 `yaml:"ServerId"`
@@ -53,15 +118,34 @@ type BaseServerConfig struct {
 	Mongo        MongoConfig  `yaml:"Mongo"`
 	Redis        RedisConfig  `yaml:"Redis"`
 	AlertWebhook string       `yaml:"AlertWebhook"` // 接收告警信息的webhook地址
+	// 路由权重(仅GameServer使用,公会按权重分配到游戏服务器,默认1)
+	RouteWeight int32 `yaml:"RouteWeight"`
+	// 最大在线人数(仅GameServer使用,所有游戏服都满员时,登录服让账号排队,0表示不限制)
+	MaxOnlineCount int32 `yaml:"MaxOnlineCount"`
+	// 角色(仅GameServer使用)
+	Character CharacterConfig `yaml:"Character"`
+	// 公会(仅GameServer使用)
+	Guild GuildConfig `yaml:"Guild"`
+	// 服务注册和发现
+	Discovery DiscoveryConfig `yaml:"Discovery"`
+	// 分配游戏服(仅LoginServer使用)
+	GameServerSelect GameServerSelectConfig `yaml:"GameServerSelect"`
+	// 账号密码之外的登录方式(仅LoginServer使用)
+	LoginProviders []LoginProviderConfig `yaml:"LoginProviders"`
+	// 管理后台http接口,不配置时不开启
+	Admin AdminConfig `yaml:"Admin"`
+	// 检查配置数据(md5.json)是否有更新的间隔秒数,有更新时自动重新加载,0表示不检查(仅GameServer使用)
+	CfgWatchInterval int32 `yaml:"CfgWatchInterval"`
 }
 
 // 服务器运行状态
 type ServerStatus int32
 
 const (
-	ServerStatus_Init    ServerStatus = 0 // 初始化中
-	ServerStatus_Running ServerStatus = 1 // 运行中
-	ServerStatus_Exit    ServerStatus = 2 // 正在退出
+	ServerStatus_Init     ServerStatus = 0 // 初始化中
+	ServerStatus_Running  ServerStatus = 1 // 运行中
+	ServerStatus_Draining ServerStatus = 2 // 排空中:不再接收新玩家,在线玩家和公会逐步迁移到其他服务器
+	ServerStatus_Exit     ServerStatus = 3 // 正在退出
 )
 
 // 服务器基础流程
@@ -74,6 +158,8 @@ type BaseServer struct {
 	cfgDir string
 	// 自己的服务器信息
 	serverInfo *pb.ServerInfo
+	// serverInfo的只读快照,每次更新serverInfo后发布,供其他协程(如管理后台)读取
+	serverInfoSnapshot atomic.Pointer[pb.ServerInfo]
 	// 服务器列表
 	serverList *ServerList
 	// 定时更新间隔
@@ -88,6 +174,14 @@ type BaseServer struct {
 	ctxCancel  context.CancelFunc
 	wg         sync.WaitGroup
 	serverHooks []gentity.ApplicationHook
+	// 定时上传服务器信息前的回调,用于填充负载等信息
+	serverInfoUpdateHooks []func(serverInfo *pb.ServerInfo)
+	// cpu使用率采样
+	cpuLoadSampler gserverutil.CpuLoadSampler
+	// 管理后台http服务,没开启时为nil
+	adminServer *AdminServer
+	// 服务器主动请求关闭(如排空完成)的通知
+	exitNotify chan struct{}
 }
 
 func NewBaseServer(ctx context.Context, serverType string, configFile string, cfgDir string) *BaseServer {
@@ -103,6 +197,7 @@ func NewBaseServer(ctx context.Context, serverType string, configFile string, cf
 		serverInfo: &pb.ServerInfo{
 			ServerType: serverType,
 		},
+		exitNotify: make(chan struct{}, 1),
 	}
 	// 创建可取消的 context,确保 Exit() 能主动触发 updateLoop 退出
 	s.ctx, s.ctxCancel = context.WithCancel(ctx)
@@ -138,6 +233,8 @@ func (this *BaseServer) ReadConfig() {
 	this.serverInfo.ClientListenAddr = this.config.Client.Addr
 	this.serverInfo.GateListenAddr = this.config.Gate.Addr
 	this.serverInfo.ServerListenAddr = this.config.Server.Addr
+	this.serverInfo.RouteWeight = this.config.RouteWeight
+	this.serverInfo.MaxOnlineCount = this.config.MaxOnlineCount
 	if this.config.WsClient.Url != "" {
 		this.serverInfo.WsClientListenAddr = this.config.WsClient.Url
 	} else if this.config.WsClient.Addr != "" {
@@ -145,9 +242,15 @@ func (this *BaseServer) ReadConfig() {
 	} else {
 		this.serverInfo.WsClientListenAddr = ""
 	}
+	this.publishServerInfo()
 	this.SetAlertWebhook(this.config.AlertWebhook)
 }
 
+// 发布serverInfo的快照,serverInfo只在主协程里修改,其他协程只能读快照
+func (this *BaseServer) publishServerInfo() {
+	this.serverInfoSnapshot.Store(proto.Clone(this.serverInfo).(*pb.ServerInfo))
+}
+
 func (this *BaseServer) GetId() int32 {
 	return this.serverInfo.GetServerId()
 }
@@ -180,11 +283,64 @@ func (this *BaseServer) GetServerHooks() []gentity.ApplicationHook {
 	return this.serverHooks
 }
 
+// 添加定时上传服务器信息前的回调,在定时更新协程中调用
+func (this *BaseServer) AddServerInfoUpdateHook(hooks ...func(serverInfo *pb.ServerInfo)) {
+	this.serverInfoUpdateHooks = append(this.serverInfoUpdateHooks, hooks...)
+}
+
+// 注册管理后台接口,没开启管理后台时忽略
+func (this *BaseServer) RegisterAdminHandler(name string, handler AdminHandler) {
+	if this.adminServer != nil {
+		this.adminServer.Register(name, handler)
+	}
+}
+
+// 服务器状态
+func (this *BaseServer) onAdminStatus(r *http.Request) (any, error) {
+	return map[string]any{
+		"ServerInfo": this.serverInfoSnapshot.Load(),
+		"Status":     this.GetStatus(),
+		"BuildTime":  BuildTime,
+		"BuildType":  BuildType,
+		"GitVersion": GitVersion,
+	}, nil
+}
+
 // 服务器是否处于运行状态
 func (this *BaseServer) IsRunning() bool {
 	return ServerStatus(this.status.Load()) == ServerStatus_Running
 }
 
+// 服务器是否处于排空状态
+func (this *BaseServer) IsDraining() bool {
+	return ServerStatus(this.status.Load()) == ServerStatus_Draining
+}
+
+// 进入排空状态,只有运行中的服务器才能进入排空状态
+func (this *BaseServer) StartDraining() bool {
+	if !this.status.CompareAndSwap(int32(ServerStatus_Running), int32(ServerStatus_Draining)) {
+		return false
+	}
+	slog.Info("BaseServer.StartDraining")
+	return true
+}
+
+// 请求关闭服务器,main收到通知后走和kill信号一样的退出流程
+//
+//	不能在服务器管理的协程里直接调用Exit,Exit会等待这些协程结束
+func (this *BaseServer) RequestExit() {
+	select {
+	case this.exitNotify <- struct{}{}:
+	default:
+	}
+	slog.Info("BaseServer.RequestExit")
+}
+
+// 服务器主动请求关闭的通知
+func (this *BaseServer) ExitNotify() <-chan struct{} {
+	return this.exitNotify
+}
+
 // 获取服务器当前状态
 func (this *BaseServer) GetStatus() ServerStatus {
 	return ServerStatus(this.status.Load())
@@ -201,7 +357,29 @@ func (this *BaseServer) Init(ctx context.Context, configFile string) bool {
 	util.InitIdGenerator(uint16(this.serverInfo.ServerId))
 	network.InitCommandMappingFromFile(this.GetCfgDir() + "message_command_mapping.json")
 	this.serverList = NewServerList(this.serverInfo)
+	switch this.config.Discovery.Type {
+	case "", DiscoveryType_Redis:
+		// 在设置缓存接口时创建
+	case DiscoveryType_Static:
+		discovery, err := NewStaticDiscovery(this.config.Discovery.File)
+		if err != nil {
+			slog.Error("NewStaticDiscoveryErr", "file", this.config.Discovery.File, "err", err)
+			return false
+		}
+		this.serverList.SetDiscovery(discovery)
+	default:
+		slog.Error("unknown discovery type", "type", this.config.Discovery.Type)
+		return false
+	}
 	this.updateInterval = time.Second
+	if this.config.Admin.Addr != "" {
+		if this.config.Admin.Token == "" {
+			slog.Error("Admin need Token", "addr", this.config.Admin.Addr)
+			return false
+		}
+		this.adminServer = NewAdminServer(this.config.Admin)
+		this.adminServer.Register("status", this.onAdminStatus)
+	}
 	// 初始化告警模块
 	if this.alertWebhook != "" {
 		workDir, _ := os.Getwd()
@@ -222,22 +400,46 @@ func (this *BaseServer) Run(ctx context.Context) {
 		// 不能用外部传入的 ctx(父 context),否则 Exit 单独调用时无法取消 updateLoop
 		this.updateLoop(this.ctx)
 	}()
+	this.wg.Add(1)
+	go func() {
+		defer this.wg.Done()
+		// 服务器列表由ServiceDiscovery推送
+		this.GetServerList().WatchServers(this.ctx)
+	}()
+	if this.adminServer != nil {
+		if err := this.adminServer.Start(); err != nil {
+			slog.Error("AdminServer.Start error", "addr", this.config.Admin.Addr, "error", err)
+		}
+	}
 }
 
 func (this *BaseServer) OnUpdate(ctx context.Context, updateCount int64) {
 	// 定时上传本地服务器的信息
 	this.serverInfo.LastActiveTime = util.GetCurrentMS()
+	// 其他服务器根据状态判断是否可以分配新玩家和公会
+	this.serverInfo.Status = this.status.Load()
+	this.serverInfo.CpuLoad = this.cpuLoadSampler.Sample()
+	for _, hook := range this.serverInfoUpdateHooks {
+		hook(this.serverInfo)
+	}
+	this.publishServerInfo()
 	this.GetServerList().RegisterLocalServerInfo()
-	this.GetServerList().FindAndConnectServers(ctx)
+	// 断开的服务器需要定时重连
+	this.GetServerList().ConnectServers(ctx)
 }
 
 func (this *BaseServer) Exit() {
 	this.status.Store(int32(ServerStatus_Exit))
 	slog.Info("BaseServer.Exit")
+	// 先注销,让其他服务器尽快感知
+	this.GetServerList().DeregisterLocalServerInfo()
 	// 取消 context,确保 updateLoop 协程退出,不再依赖外部调用方取消 context
 	if this.ctxCancel != nil {
 		this.ctxCancel()
 	}
+	if this.adminServer != nil {
+		this.adminServer.Stop()
+	}
 	for _, hook := range this.serverHooks {
 		hook.OnApplicationExit()
 	}
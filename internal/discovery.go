@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gentity/util"
+	"github.com/fish-tennis/gserver/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DiscoveryType_Redis  = "redis"  // 默认
+	DiscoveryType_Static = "static" // 静态配置文件
+)
+
+// 服务注册和发现的配置
+type DiscoveryConfig struct {
+	// 类型:redis(默认) static
+	Type string `yaml:"Type"`
+	// 静态服务器列表文件(Type=static时使用)
+	File string `yaml:"File"`
+}
+
+// 服务注册和发现
+//
+//	Watch是阻塞接口,直到ctx结束才返回,服务器列表有变化时通过onUpdate推送全量的服务器列表
+//	onUpdate只会在调用Watch的协程中调用
+type ServiceDiscovery interface {
+	// 注册(或更新)服务器信息,由服务器定时调用,也起到心跳的作用
+	Register(ctx context.Context, info *pb.ServerInfo) error
+	// 注销服务器信息,服务器退出时调用
+	Deregister(ctx context.Context, info *pb.ServerInfo) error
+	// 监听某些类型的服务器列表
+	Watch(ctx context.Context, serverTypes []string, onUpdate func(serverInfos []*pb.ServerInfo)) error
+}
+
+// 基于redis的服务注册和发现
+//
+//	每个服务器定时上传自己的信息到redis hash servers:<type>,其他服务器定时从redis获取整个服务器集群的信息
+//	pb.ServerInfo.LastActiveTime记录服务器最后上传信息的时间,达到类似"心跳检测"的效果
+//	redis不支持推送,所以Watch内部是轮询
+type RedisDiscovery struct {
+	cache gentity.KvCache
+	// 服务器多少毫秒没上传自己的信息,就判断为不活跃了
+	activeTimeout int32
+	// 轮询间隔
+	pollInterval time.Duration
+}
+
+func NewRedisDiscovery(cache gentity.KvCache, activeTimeout int32) *RedisDiscovery {
+	return &RedisDiscovery{
+		cache:         cache,
+		activeTimeout: activeTimeout,
+		pollInterval:  time.Second,
+	}
+}
+
+func redisServersKey(serverType string) string {
+	return fmt.Sprintf("servers:%v", serverType)
+}
+
+func (this *RedisDiscovery) Register(ctx context.Context, info *pb.ServerInfo) error {
+	bytes, err := proto.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return this.cache.HSet(redisServersKey(info.GetServerType()), util.Itoa(info.GetServerId()), bytes)
+}
+
+func (this *RedisDiscovery) Deregister(ctx context.Context, info *pb.ServerInfo) error {
+	_, err := this.cache.HDel(redisServersKey(info.GetServerType()), util.Itoa(info.GetServerId()))
+	if gentity.IsRedisError(err) {
+		return err
+	}
+	return nil
+}
+
+func (this *RedisDiscovery) Watch(ctx context.Context, serverTypes []string, onUpdate func(serverInfos []*pb.ServerInfo)) error {
+	ticker := time.NewTicker(this.pollInterval)
+	defer ticker.Stop()
+	for {
+		onUpdate(this.fetch(serverTypes))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// 读取活跃的服务器列表
+func (this *RedisDiscovery) fetch(serverTypes []string) []*pb.ServerInfo {
+	var infos []*pb.ServerInfo
+	for _, serverType := range serverTypes {
+		serverInfos := make(map[int32]*pb.ServerInfo)
+		err := this.cache.GetMap(redisServersKey(serverType), serverInfos)
+		if gentity.IsRedisError(err) {
+			slog.Error("get server info error", "serverType", serverType, "error", err)
+			continue
+		}
+		for _, serverInfo := range serverInfos {
+			// 目标服务器已经处于"不活跃"状态了
+			if util.GetCurrentMS()-serverInfo.GetLastActiveTime() > int64(this.activeTimeout) {
+				continue
+			}
+			infos = append(infos, serverInfo)
+		}
+	}
+	return infos
+}
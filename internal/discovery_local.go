@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/fish-tennis/gserver/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// 进程内的服务注册和发现,用于测试
+//
+//	注册和注销会立即推送给所有的Watch,类似zookeeper的临时节点
+type LocalDiscovery struct {
+	mutex       sync.Mutex
+	serverInfos map[int32]*pb.ServerInfo
+	watchers    map[chan struct{}]struct{}
+}
+
+func NewLocalDiscovery() *LocalDiscovery {
+	return &LocalDiscovery{
+		serverInfos: make(map[int32]*pb.ServerInfo),
+		watchers:    make(map[chan struct{}]struct{}),
+	}
+}
+
+func (this *LocalDiscovery) Register(ctx context.Context, info *pb.ServerInfo) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.serverInfos[info.GetServerId()] = proto.Clone(info).(*pb.ServerInfo)
+	// 重复注册时服务器信息(如状态)可能变化了,每次都推送
+	this.notify()
+	return nil
+}
+
+func (this *LocalDiscovery) Deregister(ctx context.Context, info *pb.ServerInfo) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if _, ok := this.serverInfos[info.GetServerId()]; ok {
+		delete(this.serverInfos, info.GetServerId())
+		this.notify()
+	}
+	return nil
+}
+
+func (this *LocalDiscovery) Watch(ctx context.Context, serverTypes []string, onUpdate func(serverInfos []*pb.ServerInfo)) error {
+	// 缓冲为1,多次通知合并成一次推送
+	notifyChan := make(chan struct{}, 1)
+	this.mutex.Lock()
+	this.watchers[notifyChan] = struct{}{}
+	this.mutex.Unlock()
+	defer func() {
+		this.mutex.Lock()
+		delete(this.watchers, notifyChan)
+		this.mutex.Unlock()
+	}()
+	for {
+		onUpdate(this.getServerInfos(serverTypes))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-notifyChan:
+		}
+	}
+}
+
+func (this *LocalDiscovery) getServerInfos(serverTypes []string) []*pb.ServerInfo {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	var infos []*pb.ServerInfo
+	for _, info := range this.serverInfos {
+		if slices.Contains(serverTypes, info.GetServerType()) {
+			infos = append(infos, proto.Clone(info).(*pb.ServerInfo))
+		}
+	}
+	return infos
+}
+
+// 调用者需要加锁
+func (this *LocalDiscovery) notify() {
+	for notifyChan := range this.watchers {
+		select {
+		case notifyChan <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/fish-tennis/gserver/pb"
+	"gopkg.in/yaml.v3"
+)
+
+// 静态服务器列表文件的格式
+type staticServerList struct {
+	Servers []staticServerInfo `yaml:"Servers"`
+}
+
+type staticServerInfo struct {
+	ServerId           int32  `yaml:"ServerId"`
+	ServerType         string `yaml:"ServerType"`
+	ServerListenAddr   string `yaml:"ServerListenAddr"`
+	ClientListenAddr   string `yaml:"ClientListenAddr"`
+	WsClientListenAddr string `yaml:"WsClientListenAddr"`
+	GateListenAddr     string `yaml:"GateListenAddr"`
+	RouteWeight        int32  `yaml:"RouteWeight"`
+}
+
+// 基于静态配置文件的服务发现
+//
+//	服务器列表固定,不依赖redis,适合服务器数量固定的部署环境
+//	不检测服务器是否存活,由服务器之间的连接状态来判断
+//	Register的服务器信息(如排空状态)保存在内存中,覆盖配置文件里的信息,并推送给本进程内的Watch
+//	NOTE:没有共享存储,其他进程看不到本进程注册的服务器状态
+type StaticDiscovery struct {
+	*LocalDiscovery
+	// 配置文件里的服务器信息
+	staticInfos map[int32]*pb.ServerInfo
+}
+
+func NewStaticDiscovery(file string) (*StaticDiscovery, error) {
+	fileData, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	serverList := &staticServerList{}
+	err = yaml.Unmarshal(fileData, serverList)
+	if err != nil {
+		return nil, err
+	}
+	discovery := &StaticDiscovery{
+		LocalDiscovery: NewLocalDiscovery(),
+		staticInfos:    make(map[int32]*pb.ServerInfo),
+	}
+	for _, info := range serverList.Servers {
+		if _, ok := discovery.staticInfos[info.ServerId]; ok {
+			return nil, errors.New("duplicate serverId")
+		}
+		serverInfo := &pb.ServerInfo{
+			ServerId:           info.ServerId,
+			ServerType:         info.ServerType,
+			ServerListenAddr:   info.ServerListenAddr,
+			ClientListenAddr:   info.ClientListenAddr,
+			WsClientListenAddr: info.WsClientListenAddr,
+			GateListenAddr:     info.GateListenAddr,
+			RouteWeight:        info.RouteWeight,
+		}
+		discovery.staticInfos[info.ServerId] = serverInfo
+		discovery.serverInfos[info.ServerId] = serverInfo
+	}
+	return discovery, nil
+}
+
+// 注销时恢复成配置文件里的服务器信息,配置文件里没有的服务器直接删除
+func (this *StaticDiscovery) Deregister(ctx context.Context, info *pb.ServerInfo) error {
+	if staticInfo, ok := this.staticInfos[info.GetServerId()]; ok {
+		return this.LocalDiscovery.Register(ctx, staticInfo)
+	}
+	return this.LocalDiscovery.Deregister(ctx, info)
+}
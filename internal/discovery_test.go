@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fish-tennis/gserver/pb"
+)
+
+func TestLocalDiscovery(t *testing.T) {
+	discovery := NewLocalDiscovery()
+	serverList := NewServerList(&pb.ServerInfo{
+		ServerId:   1,
+		ServerType: ServerType_Gate,
+	})
+	serverList.SetDiscovery(discovery)
+	serverList.SetFetchServerTypes(ServerType_Game)
+	updated := make(chan []*pb.ServerInfo, 10)
+	serverList.AddListUpdateHook(func(serverList map[string][]*pb.ServerInfo, oldServerList map[string][]*pb.ServerInfo) {
+		updated <- serverList[ServerType_Game]
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go serverList.WatchServers(ctx)
+
+	waitUpdate := func(serverCount int) {
+		select {
+		case servers := <-updated:
+			if len(servers) != serverCount {
+				t.Fatalf("server count %v, want %v", len(servers), serverCount)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("wait update timeout")
+		}
+	}
+	game101 := &pb.ServerInfo{ServerId: 101, ServerType: ServerType_Game}
+	game102 := &pb.ServerInfo{ServerId: 102, ServerType: ServerType_Game}
+	discovery.Register(ctx, game101)
+	waitUpdate(1)
+	discovery.Register(ctx, game102)
+	waitUpdate(2)
+	// 非监听类型的服务器不会推送
+	discovery.Register(ctx, &pb.ServerInfo{ServerId: 11, ServerType: ServerType_Login})
+	// 重复注册但服务器列表没变化,不会触发更新
+	discovery.Register(ctx, game101)
+	discovery.Deregister(ctx, game101)
+	waitUpdate(1)
+	if serverList.GetServerInfo(101) != nil || serverList.GetServerInfo(102) == nil {
+		t.Fatalf("server list error")
+	}
+}
+
+func TestStaticDiscovery(t *testing.T) {
+	discovery, err := NewStaticDiscovery("./../config/servers.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var serverInfos []*pb.ServerInfo
+	discovery.Watch(ctx, []string{ServerType_Game}, func(infos []*pb.ServerInfo) {
+		serverInfos = infos
+	})
+	if len(serverInfos) != 2 {
+		t.Fatalf("server count %v", len(serverInfos))
+	}
+	for _, info := range serverInfos {
+		if info.GetServerListenAddr() == "" {
+			t.Fatalf("server %v ServerListenAddr empty", info.GetServerId())
+		}
+	}
+}
+
+// 重复注册时服务器状态变化了,需要推送给Watch
+func TestDiscoveryReRegisterStatus(t *testing.T) {
+	staticDiscovery, err := NewStaticDiscovery("./../config/servers.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, discovery := range map[string]ServiceDiscovery{
+		"local":  NewLocalDiscovery(),
+		"static": staticDiscovery,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			game := &pb.ServerInfo{ServerId: 101, ServerType: ServerType_Game, Status: int32(ServerStatus_Running)}
+			discovery.Register(ctx, game)
+			updated := make(chan *pb.ServerInfo, 10)
+			go discovery.Watch(ctx, []string{ServerType_Game}, func(serverInfos []*pb.ServerInfo) {
+				for _, info := range serverInfos {
+					if info.GetServerId() == game.GetServerId() {
+						updated <- info
+					}
+				}
+			})
+			waitStatus := func(status ServerStatus) {
+				for {
+					select {
+					case info := <-updated:
+						if info.GetStatus() == int32(status) {
+							return
+						}
+					case <-time.After(time.Second):
+						t.Fatalf("wait status %v timeout", status)
+					}
+				}
+			}
+			waitStatus(ServerStatus_Running)
+			game.Status = int32(ServerStatus_Draining)
+			discovery.Register(ctx, game)
+			waitStatus(ServerStatus_Draining)
+		})
+	}
+}
@@ -12,6 +12,12 @@ type EventPlayerEntryGame struct {
 type EventPlayerExit struct {
 }
 
+// 配置数据重新加载完成
+type EventCfgReloaded struct {
+	OldVersion int64 // 之前使用的配置数据版本
+	Version    int64 // 新的配置数据版本
+}
+
 // 日期更新
 type EventDateChange struct {
 	OldDate time.Time
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"sync/atomic"
+
+	"github.com/fish-tennis/gserver/pb"
+)
+
+// 每个权重单位对应的虚拟节点数
+var HashRingVirtualNodes = 160
+
+// 一致性哈希环
+//
+//	每个服务器按权重映射成多个虚拟节点,key顺时针找到的第一个虚拟节点即为所属服务器
+//	增加或删除一个服务器时,只有约1/N的key会改变所属服务器,减少分布式实体的迁移
+type HashRing struct {
+	nodes       []hashRingNode // 按hash升序
+	fingerprint uint64         // 服务器列表的指纹,用于判断服务器列表是否变化
+}
+
+type hashRingNode struct {
+	hash     uint64
+	serverId int32
+}
+
+// 根据服务器列表创建一致性哈希环
+//
+//	virtualNodes是每个权重单位对应的虚拟节点数
+func NewHashRing(servers []*pb.ServerInfo, virtualNodes int) *HashRing {
+	if virtualNodes <= 0 {
+		virtualNodes = 1
+	}
+	ring := &HashRing{
+		fingerprint: hashRingFingerprint(servers),
+	}
+	buf := make([]byte, 8)
+	for _, server := range servers {
+		count := virtualNodes * int(GetServerRouteWeight(server))
+		for i := 0; i < count; i++ {
+			binary.LittleEndian.PutUint32(buf[0:4], uint32(server.GetServerId()))
+			binary.LittleEndian.PutUint32(buf[4:8], uint32(i))
+			ring.nodes = append(ring.nodes, hashRingNode{
+				hash:     hashBytes(buf),
+				serverId: server.GetServerId(),
+			})
+		}
+	}
+	sort.Slice(ring.nodes, func(i, j int) bool {
+		if ring.nodes[i].hash == ring.nodes[j].hash {
+			// hash冲突时按服务器id排序,保证不同进程创建的哈希环一致
+			return ring.nodes[i].serverId < ring.nodes[j].serverId
+		}
+		return ring.nodes[i].hash < ring.nodes[j].hash
+	})
+	return ring
+}
+
+// 获取key所属的服务器id,哈希环为空时返回0
+func (this *HashRing) Get(key int64) int32 {
+	if len(this.nodes) == 0 {
+		return 0
+	}
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(key))
+	h := hashBytes(buf)
+	index := sort.Search(len(this.nodes), func(i int) bool {
+		return this.nodes[i].hash >= h
+	})
+	if index == len(this.nodes) {
+		index = 0
+	}
+	return this.nodes[index].serverId
+}
+
+// 服务器的路由权重,没有配置的话默认1
+func GetServerRouteWeight(server *pb.ServerInfo) int32 {
+	if server.GetRouteWeight() <= 0 {
+		return 1
+	}
+	return server.GetRouteWeight()
+}
+
+// fnv的结果再经过一次混淆,让相邻的key也能均匀分布
+func hashBytes(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+	return mix64(h.Sum64())
+}
+
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// 服务器列表的指纹,和服务器的顺序无关
+func hashRingFingerprint(servers []*pb.ServerInfo) uint64 {
+	fingerprint := uint64(len(servers))
+	for _, server := range servers {
+		fingerprint ^= mix64(uint64(uint32(server.GetServerId()))<<32 | uint64(uint32(GetServerRouteWeight(server))))
+	}
+	return fingerprint
+}
+
+// 公会路由使用的一致性哈希环,服务器列表变化时重建
+var _guildHashRing atomic.Pointer[HashRing]
+
+func getGuildHashRing(servers []*pb.ServerInfo) *HashRing {
+	ring := _guildHashRing.Load()
+	if ring != nil && ring.fingerprint == hashRingFingerprint(servers) {
+		return ring
+	}
+	ring = NewHashRing(servers, HashRingVirtualNodes)
+	_guildHashRing.Store(ring)
+	return ring
+}
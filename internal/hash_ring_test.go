@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/fish-tennis/gserver/pb"
+)
+
+const testGuildCount = 100000
+
+func testGameServers(serverIds ...int32) []*pb.ServerInfo {
+	var servers []*pb.ServerInfo
+	for _, serverId := range serverIds {
+		servers = append(servers, &pb.ServerInfo{
+			ServerId:   serverId,
+			ServerType: ServerType_Game,
+		})
+	}
+	return servers
+}
+
+// 统计服务器列表变化时,改变所属服务器的公会数量
+func countMovedGuilds(t *testing.T, oldRing, newRing *HashRing, allowedServerId int32) int {
+	moved := 0
+	for guildId := int64(1); guildId <= testGuildCount; guildId++ {
+		oldServerId := oldRing.Get(guildId)
+		newServerId := newRing.Get(guildId)
+		if oldServerId == newServerId {
+			continue
+		}
+		// 只允许迁入新增的服务器,或者从删除的服务器迁出
+		if oldServerId != allowedServerId && newServerId != allowedServerId {
+			t.Fatalf("guild %v moved %v->%v", guildId, oldServerId, newServerId)
+		}
+		moved++
+	}
+	return moved
+}
+
+func TestHashRingAddServer(t *testing.T) {
+	oldRing := NewHashRing(testGameServers(101, 102, 103, 104, 105, 106, 107, 108, 109), HashRingVirtualNodes)
+	newRing := NewHashRing(testGameServers(101, 102, 103, 104, 105, 106, 107, 108, 109, 110), HashRingVirtualNodes)
+	moved := countMovedGuilds(t, oldRing, newRing, 110)
+	// 理论上迁移1/10
+	ratio := float64(moved) / testGuildCount
+	t.Logf("add server moved:%v ratio:%.4f", moved, ratio)
+	if ratio < 0.05 || ratio > 0.15 {
+		t.Errorf("moved ratio %v", ratio)
+	}
+}
+
+func TestHashRingRemoveServer(t *testing.T) {
+	oldRing := NewHashRing(testGameServers(101, 102, 103, 104, 105, 106, 107, 108, 109, 110), HashRingVirtualNodes)
+	newRing := NewHashRing(testGameServers(101, 102, 103, 104, 105, 106, 107, 108, 109), HashRingVirtualNodes)
+	moved := countMovedGuilds(t, oldRing, newRing, 110)
+	ratio := float64(moved) / testGuildCount
+	t.Logf("remove server moved:%v ratio:%.4f", moved, ratio)
+	if ratio < 0.05 || ratio > 0.15 {
+		t.Errorf("moved ratio %v", ratio)
+	}
+}
+
+func TestHashRingWeight(t *testing.T) {
+	servers := testGameServers(101, 102, 103)
+	servers[2].RouteWeight = 2
+	ring := NewHashRing(servers, HashRingVirtualNodes)
+	counts := make(map[int32]int)
+	for guildId := int64(1); guildId <= testGuildCount; guildId++ {
+		counts[ring.Get(guildId)]++
+	}
+	// 理论上是1:1:2
+	t.Logf("counts:%v", counts)
+	for _, serverId := range []int32{101, 102} {
+		ratio := float64(counts[serverId]) / testGuildCount
+		if ratio < 0.2 || ratio > 0.3 {
+			t.Errorf("server %v ratio %v", serverId, ratio)
+		}
+	}
+	ratio := float64(counts[103]) / testGuildCount
+	if ratio < 0.42 || ratio > 0.58 {
+		t.Errorf("server 103 ratio %v", ratio)
+	}
+	// 服务器顺序不影响结果
+	reversed := NewHashRing([]*pb.ServerInfo{servers[2], servers[1], servers[0]}, HashRingVirtualNodes)
+	if reversed.fingerprint != ring.fingerprint {
+		t.Errorf("fingerprint not equal")
+	}
+	for guildId := int64(1); guildId <= 1000; guildId++ {
+		if reversed.Get(guildId) != ring.Get(guildId) {
+			t.Fatalf("guild %v route not equal", guildId)
+		}
+	}
+}
@@ -25,17 +25,19 @@ type RouteSourcePacket struct {
 }
 
 // 根据公会id查找对应的服务器
+//
+//	游戏服务器按RouteWeight分配公会,排空中的服务器不再分配公会
 func RouteGuildServerId(guildId int64) int32 {
-	servers := GetServerList().GetServersByType(ServerType_Game)
+	servers := GetServerList().GetAvailableServersByType(ServerType_Game)
 	if len(servers) == 0 {
-		return 0
+		// 所有服务器都在排空,公会只能留在原服务器
+		servers = GetServerList().GetServersByType(ServerType_Game)
 	}
-	// 这里只演示了最简单的路由方式
-	index := guildId % int64(len(servers))
-	if index < 0 {
-		index += int64(len(servers))
+	if len(servers) == 0 {
+		return 0
 	}
-	return servers[index].GetServerId()
+	// 一致性哈希,服务器列表变化时只有约1/N的公会需要迁移
+	return getGuildHashRing(servers).Get(guildId)
 }
 
 // 玩家对公会的请求消息转换成路由消息
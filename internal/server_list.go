@@ -2,7 +2,6 @@ package internal
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"slices"
 	"sort"
@@ -35,12 +34,15 @@ type ServerInfo interface {
 }
 
 // 服务器列表管理
-// 每个服务器定时上传自己的信息到redis,其他服务器定时从redis获取整个服务器集群的信息
-// 属于服务注册和发现的功能,zookeeper的临时节点更适合来实现这类需求
-// 这里用redis来实现,pb.ServerInfo.LastActiveTime记录服务器最后上传信息的时间,达到类似"心跳检测"的效果
+// 每个服务器定时注册自己的信息,并监听整个服务器集群的信息
+// 服务注册和发现的功能由ServiceDiscovery实现,默认使用redis(RedisDiscovery)
 type ServerList struct {
 	// 缓存接口
 	cache gentity.KvCache
+	// 服务注册和发现
+	discovery ServiceDiscovery
+	// 保证服务器列表的更新是串行的
+	updateMutex sync.Mutex
 	// 需要获取信息的服务器类型
 	fetchServerTypes []string
 	// 需要连接的服务器类型
@@ -89,6 +91,19 @@ func NewServerList(serverInfo *pb.ServerInfo) *ServerList {
 
 func (this *ServerList) SetCache(cache gentity.KvCache) {
 	this.cache = cache
+	// 没有设置服务注册和发现的话,默认使用redis
+	if this.discovery == nil {
+		this.discovery = NewRedisDiscovery(cache, this.activeTimeout)
+	}
+}
+
+// 设置服务注册和发现的实现
+func (this *ServerList) SetDiscovery(discovery ServiceDiscovery) {
+	this.discovery = discovery
+}
+
+func (this *ServerList) GetDiscovery() ServiceDiscovery {
+	return this.discovery
 }
 
 func (this *ServerList) initDefaultServerConnectorConfig() {
@@ -187,63 +202,74 @@ func (this *ServerList) NewAdaptPacket(message proto.Message) gnet.Packet {
 	}
 }
 
-// 服务发现: 读取服务器列表信息,并连接这些服务器
-func (this *ServerList) FindAndConnectServers(ctx context.Context) {
+// 服务发现: 监听服务器列表信息,阻塞直到ctx结束
+func (this *ServerList) WatchServers(ctx context.Context) {
+	err := this.GetDiscovery().Watch(ctx, this.fetchServerTypes, func(serverInfos []*pb.ServerInfo) {
+		this.OnServerListUpdate(ctx, serverInfos)
+	})
+	if err != nil {
+		slog.Error("WatchServersErr", "err", err)
+	}
+}
+
+// 服务器列表推送,并连接这些服务器
+func (this *ServerList) OnServerListUpdate(ctx context.Context, serverInfos []*pb.ServerInfo) {
+	this.updateMutex.Lock()
 	serverInfoMapUpdated := false
 	infoMap := make(map[int32]*pb.ServerInfo)
-	for _, serverType := range this.fetchServerTypes {
-		serverInfos := make(map[int32]*pb.ServerInfo)
-		err := this.cache.GetMap(fmt.Sprintf("servers:%v", serverType), serverInfos)
-		if gentity.IsRedisError(err) {
-			slog.Error("get server info error", "serverType", serverType, "error", err)
-			continue
-		}
-		for _, serverInfo := range serverInfos {
-			// 目标服务器已经处于"不活跃"状态了
-			if util.GetCurrentMS()-serverInfo.GetLastActiveTime() > int64(this.activeTimeout) {
-				continue
-			}
-			// 这里不用加锁,因为其他协程不会修改serverInfos
-			if _, ok := this.serverInfos[serverInfo.GetServerId()]; !ok {
-				serverInfoMapUpdated = true
-			}
-			infoMap[serverInfo.GetServerId()] = serverInfo
+	for _, serverInfo := range serverInfos {
+		// 这里不用加锁,因为updateMutex保证了其他协程不会修改serverInfos
+		if oldInfo, ok := this.serverInfos[serverInfo.GetServerId()]; !ok {
+			serverInfoMapUpdated = true
+		} else if oldInfo.GetStatus() != serverInfo.GetStatus() {
+			// 服务器状态变化(如进入排空状态),也需要触发更新,让公会等分布式实体重新分配
+			serverInfoMapUpdated = true
 		}
+		infoMap[serverInfo.GetServerId()] = serverInfo
 	}
 	if len(this.serverInfos) != len(infoMap) {
 		serverInfoMapUpdated = true
 	}
-	// 服务器列表有更新,才更新服务器列表和类型分组信息
-	if serverInfoMapUpdated {
-		this.serverInfosMutex.Lock()
-		this.serverInfos = infoMap
-		this.serverInfosMutex.Unlock()
-		serverInfoTypeMap := make(map[string][]*pb.ServerInfo)
-		for _, info := range infoMap {
-			infoSlice, ok := serverInfoTypeMap[info.GetServerType()]
-			if !ok {
-				infoSlice = make([]*pb.ServerInfo, 0)
-				serverInfoTypeMap[info.GetServerType()] = infoSlice
-			}
-			infoSlice = append(infoSlice, info)
+	// 每次都更新服务器信息(负载等信息会变化)
+	this.serverInfosMutex.Lock()
+	this.serverInfos = infoMap
+	this.serverInfosMutex.Unlock()
+	serverInfoTypeMap := make(map[string][]*pb.ServerInfo)
+	for _, info := range infoMap {
+		infoSlice, ok := serverInfoTypeMap[info.GetServerType()]
+		if !ok {
+			infoSlice = make([]*pb.ServerInfo, 0)
 			serverInfoTypeMap[info.GetServerType()] = infoSlice
 		}
-		// 预排序:避免 GetServersByType 每次调用都排序
-		for _, infoSlice := range serverInfoTypeMap {
-			sort.Slice(infoSlice, func(i, j int) bool {
-				return infoSlice[i].GetServerId() < infoSlice[j].GetServerId()
-			})
-		}
-		var oldList map[string][]*pb.ServerInfo
-		this.serverInfoTypeMapMutex.Lock()
-		oldList = this.serverInfoTypeMap
-		this.serverInfoTypeMap = serverInfoTypeMap
-		this.serverInfoTypeMapMutex.Unlock()
+		infoSlice = append(infoSlice, info)
+		serverInfoTypeMap[info.GetServerType()] = infoSlice
+	}
+	// 预排序:避免 GetServersByType 每次调用都排序
+	for _, infoSlice := range serverInfoTypeMap {
+		sort.Slice(infoSlice, func(i, j int) bool {
+			return infoSlice[i].GetServerId() < infoSlice[j].GetServerId()
+		})
+	}
+	var oldList map[string][]*pb.ServerInfo
+	this.serverInfoTypeMapMutex.Lock()
+	oldList = this.serverInfoTypeMap
+	this.serverInfoTypeMap = serverInfoTypeMap
+	this.serverInfoTypeMapMutex.Unlock()
+	// 服务器列表有更新,才触发回调
+	if serverInfoMapUpdated {
 		for _, hookFunc := range this.listUpdateHooks {
 			hookFunc(serverInfoTypeMap, oldList)
 		}
 	}
+	this.updateMutex.Unlock()
+	this.ConnectServers(ctx)
+}
 
+// 连接服务器列表中需要连接的服务器,已断开的服务器会重连
+func (this *ServerList) ConnectServers(ctx context.Context) {
+	this.serverInfosMutex.RLock()
+	infoMap := this.serverInfos
+	this.serverInfosMutex.RUnlock()
 	for _, info := range infoMap {
 		if slices.Contains(this.connectServerTypes, info.GetServerType()) {
 			//// 目标服务器已经处于"不活跃"状态了
@@ -317,9 +343,18 @@ func (this *ServerList) ConnectServer(ctx context.Context, info *pb.ServerInfo)
 func (this *ServerList) RegisterLocalServerInfo() {
 	// 从原子变量读取最新的 Ping 值,避免与心跳回调协程的数据竞争
 	this.localServerInfo.Ping = this.localServerInfoPing.Load()
-	bytes, _ := proto.Marshal(this.localServerInfo)
-	this.cache.HSet(fmt.Sprintf("servers:%v", this.localServerInfo.GetServerType()),
-		util.Itoa(this.localServerInfo.GetServerId()), bytes)
+	err := this.GetDiscovery().Register(context.Background(), this.localServerInfo)
+	if err != nil {
+		slog.Error("RegisterLocalServerInfoErr", "serverId", this.localServerInfo.GetServerId(), "err", err)
+	}
+}
+
+// 服务注销:服务器退出时,让其他服务器尽快感知
+func (this *ServerList) DeregisterLocalServerInfo() {
+	err := this.GetDiscovery().Deregister(context.Background(), this.localServerInfo)
+	if err != nil {
+		slog.Error("DeregisterLocalServerInfoErr", "serverId", this.localServerInfo.GetServerId(), "err", err)
+	}
 }
 
 // 获取某个服务器的信息
@@ -397,6 +432,24 @@ func (this *ServerList) GetServersByType(serverType string) []*pb.ServerInfo {
 	return nil
 }
 
+// 获取某类可分配新玩家和新实体的服务器信息列表(排除排空中和退出中的服务器)
+func (this *ServerList) GetAvailableServersByType(serverType string) []*pb.ServerInfo {
+	servers := this.GetServersByType(serverType)
+	availableServers := servers[:0]
+	for _, info := range servers {
+		if IsServerAvailable(info) {
+			availableServers = append(availableServers, info)
+		}
+	}
+	return availableServers
+}
+
+// 服务器是否可以分配新玩家和新实体
+func IsServerAvailable(info *pb.ServerInfo) bool {
+	status := ServerStatus(info.GetStatus())
+	return status != ServerStatus_Draining && status != ServerStatus_Exit
+}
+
 // 获取服务器的连接
 func (this *ServerList) GetServerConnection(serverId int32) gnet.Connection {
 	this.connectedServersMutex.RLock()
@@ -0,0 +1,175 @@
+package loginserver
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+// 分配游戏服的策略
+const (
+	GameServerSelect_LeastLoad = "LeastLoad" // 加权最小负载(默认)
+	GameServerSelect_P2C       = "P2C"       // 随机选2个,取负载小的
+	GameServerSelect_Random    = "Random"    // 随机
+)
+
+var (
+	// 每条积压的消息相当于多少个在线玩家的负载
+	QueueDepthLoadFactor = 0.5
+	// cpu跑满时,负载放大的倍数
+	CpuLoadFactor = 2.0
+	// 粘性分配时,上次的游戏服负载不超过最小负载*StickyMaxLoadRatio+StickyLoadTolerance,才认为是健康的
+	StickyMaxLoadRatio  = 1.5
+	StickyLoadTolerance = 100.0
+	// 粘性分配时,上次的游戏服cpu使用率(千分比)超过该值,就不再分配
+	StickyMaxCpuLoad int32 = 900
+
+	_gameServerSelector = newGameServerSelector()
+)
+
+// 游戏服分配
+//
+//	游戏服每秒上传一次负载信息,在负载信息更新前,本登录服分配的账号数也计入负载,
+//	防止开服时大量账号在负载信息更新前都分配到同一个游戏服
+type gameServerSelector struct {
+	mutex    sync.Mutex
+	assigned map[int32]*gameServerAssigned // serverId-分配信息
+}
+
+type gameServerAssigned struct {
+	lastActiveTime int64 // 游戏服信息的上传时间,游戏服信息更新后重新计数
+	count          int32 // 游戏服信息更新后,本登录服分配的账号数
+}
+
+func newGameServerSelector() *gameServerSelector {
+	return &gameServerSelector{
+		assigned: make(map[int32]*gameServerAssigned),
+	}
+}
+
+// 分配一个游戏服,所有游戏服都满员时返回nil
+//
+//	lastServerId>0时,优先分配到该游戏服(粘性分配),该游戏服不可用或负载过高时,再按strategy分配
+func (this *gameServerSelector) Select(servers []*pb.ServerInfo, strategy string, lastServerId int32) *pb.ServerInfo {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	servers = slices.DeleteFunc(slices.Clone(servers), this.isFull)
+	if len(servers) == 0 {
+		return nil
+	}
+	var selected *pb.ServerInfo
+	if lastServerId > 0 {
+		selected = this.selectSticky(servers, lastServerId)
+	}
+	if selected == nil {
+		switch strategy {
+		case GameServerSelect_Random:
+			selected = servers[rand.Intn(len(servers))]
+		case GameServerSelect_P2C:
+			selected = this.selectP2C(servers)
+		default:
+			selected = this.selectLeastLoad(servers)
+		}
+	}
+	this.getAssigned(selected).count++
+	return selected
+}
+
+// 调用者需要加锁
+func (this *gameServerSelector) getAssigned(info *pb.ServerInfo) *gameServerAssigned {
+	assigned, ok := this.assigned[info.GetServerId()]
+	if !ok {
+		assigned = &gameServerAssigned{}
+		this.assigned[info.GetServerId()] = assigned
+	}
+	if assigned.lastActiveTime != info.GetLastActiveTime() {
+		// 游戏服信息更新了,之前分配的账号已经计入在线人数
+		assigned.lastActiveTime = info.GetLastActiveTime()
+		assigned.count = 0
+	}
+	return assigned
+}
+
+// 游戏服是否满员,调用者需要加锁
+func (this *gameServerSelector) isFull(info *pb.ServerInfo) bool {
+	return info.GetMaxOnlineCount() > 0 && info.GetOnlineCount()+this.getAssigned(info).count >= info.GetMaxOnlineCount()
+}
+
+// 游戏服还能接收的玩家数,unlimited表示有不限制在线人数的游戏服
+func (this *gameServerSelector) GetFreeCapacity(servers []*pb.ServerInfo) (freeCount int32, unlimited bool) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	for _, info := range servers {
+		if info.GetMaxOnlineCount() <= 0 {
+			return 0, true
+		}
+		freeCount += max(0, info.GetMaxOnlineCount()-info.GetOnlineCount()-this.getAssigned(info).count)
+	}
+	return
+}
+
+// 游戏服的负载,越小越空闲
+//
+//	以在线人数为主,消息队列积压和cpu使用率高的游戏服额外增加负载,再除以游戏服的权重
+func (this *gameServerSelector) getLoad(info *pb.ServerInfo) float64 {
+	playerCount := float64(info.GetOnlineCount() + this.getAssigned(info).count + 1)
+	load := playerCount + float64(info.GetQueueDepth())*QueueDepthLoadFactor
+	load *= 1 + float64(info.GetCpuLoad())/1000*CpuLoadFactor
+	return load / float64(internal.GetServerRouteWeight(info))
+}
+
+func (this *gameServerSelector) selectLeastLoad(servers []*pb.ServerInfo) *pb.ServerInfo {
+	var selected *pb.ServerInfo
+	minLoad := 0.0
+	// 从随机位置开始遍历,负载相同时不总是选第一个
+	offset := rand.Intn(len(servers))
+	for i := range servers {
+		info := servers[(offset+i)%len(servers)]
+		load := this.getLoad(info)
+		if selected == nil || load < minLoad {
+			selected = info
+			minLoad = load
+		}
+	}
+	return selected
+}
+
+func (this *gameServerSelector) selectP2C(servers []*pb.ServerInfo) *pb.ServerInfo {
+	if len(servers) == 1 {
+		return servers[0]
+	}
+	i := rand.Intn(len(servers))
+	j := rand.Intn(len(servers) - 1)
+	if j >= i {
+		j++
+	}
+	if this.getLoad(servers[j]) < this.getLoad(servers[i]) {
+		return servers[j]
+	}
+	return servers[i]
+}
+
+// 上次的游戏服可用且负载不太高,就继续分配到该游戏服
+func (this *gameServerSelector) selectSticky(servers []*pb.ServerInfo, lastServerId int32) *pb.ServerInfo {
+	var lastServer *pb.ServerInfo
+	minLoad := 0.0
+	for i, info := range servers {
+		load := this.getLoad(info)
+		if i == 0 || load < minLoad {
+			minLoad = load
+		}
+		if info.GetServerId() == lastServerId {
+			lastServer = info
+		}
+	}
+	if lastServer == nil || lastServer.GetCpuLoad() > StickyMaxCpuLoad {
+		return nil
+	}
+	if this.getLoad(lastServer) > minLoad*StickyMaxLoadRatio+StickyLoadTolerance {
+		return nil
+	}
+	return lastServer
+}
@@ -0,0 +1,105 @@
+package loginserver
+
+import (
+	"testing"
+
+	"github.com/fish-tennis/gserver/pb"
+)
+
+func testGameServers() []*pb.ServerInfo {
+	return []*pb.ServerInfo{
+		{ServerId: 101, OnlineCount: 1000, LastActiveTime: 1},
+		{ServerId: 102, OnlineCount: 100, LastActiveTime: 1},
+		{ServerId: 103, OnlineCount: 100, QueueDepth: 4000, LastActiveTime: 1},
+	}
+}
+
+func TestSelectLeastLoad(t *testing.T) {
+	selector := newGameServerSelector()
+	servers := testGameServers()
+	counts := make(map[int32]int)
+	for i := 0; i < 1000; i++ {
+		counts[selector.Select(servers, GameServerSelect_LeastLoad, 0).GetServerId()]++
+	}
+	t.Logf("counts:%v", counts)
+	// 负载信息更新前,分配的账号数也计入负载,102分配900个左右后,和101的负载持平
+	if counts[102] < 850 || counts[101] > 100 || counts[103] > 0 {
+		t.Errorf("counts:%v", counts)
+	}
+	// 负载信息更新后,重新计数
+	servers[1].OnlineCount += int32(counts[102])
+	servers[1].LastActiveTime++
+	selector.Select(servers, GameServerSelect_LeastLoad, 0)
+	if selector.assigned[102].count > 1 {
+		t.Errorf("assigned count:%v", selector.assigned[102].count)
+	}
+}
+
+func TestSelectP2C(t *testing.T) {
+	selector := newGameServerSelector()
+	servers := testGameServers()
+	counts := make(map[int32]int)
+	for i := 0; i < 300; i++ {
+		counts[selector.Select(servers, GameServerSelect_P2C, 0).GetServerId()]++
+	}
+	t.Logf("counts:%v", counts)
+	// 负载最高的服务器,不会被P2C选中
+	if counts[103] > 0 || counts[102] < counts[101] {
+		t.Errorf("counts:%v", counts)
+	}
+}
+
+func TestSelectSticky(t *testing.T) {
+	selector := newGameServerSelector()
+	servers := testGameServers()
+	// 101的负载不算太高,继续分配到101
+	servers[0].OnlineCount = 200
+	if selector.Select(servers, GameServerSelect_LeastLoad, 101).GetServerId() != 101 {
+		t.Errorf("sticky server not selected")
+	}
+	// 101的负载过高
+	servers[0].OnlineCount = 1000
+	if selector.Select(servers, GameServerSelect_LeastLoad, 101).GetServerId() == 101 {
+		t.Errorf("overloaded sticky server selected")
+	}
+	// 101的cpu过高
+	servers[0].OnlineCount = 100
+	servers[0].CpuLoad = 950
+	if selector.Select(servers, GameServerSelect_LeastLoad, 101).GetServerId() == 101 {
+		t.Errorf("busy sticky server selected")
+	}
+	// 上次的游戏服不可用
+	if selector.Select(servers, GameServerSelect_LeastLoad, 104) == nil {
+		t.Errorf("no server selected")
+	}
+}
+
+func TestSelectFull(t *testing.T) {
+	selector := newGameServerSelector()
+	servers := testGameServers()
+	servers[0].MaxOnlineCount = 1000
+	servers[1].MaxOnlineCount = 110
+	servers[2].MaxOnlineCount = 100
+	// 101和103已满,只能分配到102
+	for i := 0; i < 10; i++ {
+		if selector.Select(servers, GameServerSelect_Random, 101).GetServerId() != 102 {
+			t.Fatalf("full server selected")
+		}
+	}
+	if freeCount, unlimited := selector.GetFreeCapacity(servers); freeCount != 0 || unlimited {
+		t.Errorf("freeCount:%v unlimited:%v", freeCount, unlimited)
+	}
+	if selector.Select(servers, GameServerSelect_LeastLoad, 0) != nil {
+		t.Errorf("all servers full")
+	}
+	// 游戏服信息更新后,有人下线了
+	servers[1].OnlineCount = 100
+	servers[1].LastActiveTime++
+	if freeCount, _ := selector.GetFreeCapacity(servers); freeCount != 10 {
+		t.Errorf("freeCount:%v", freeCount)
+	}
+	servers[2].MaxOnlineCount = 0
+	if _, unlimited := selector.GetFreeCapacity(servers); !unlimited {
+		t.Errorf("unlimited server not found")
+	}
+}
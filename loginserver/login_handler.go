@@ -1,8 +1,9 @@
 package loginserver
 
 import (
+	"crypto/subtle"
 	"log/slog"
-	"math/rand"
+	"time"
 
 	. "github.com/fish-tennis/gnet"
 	"github.com/fish-tennis/gserver/cache"
@@ -10,6 +11,7 @@ import (
 	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/network"
 	"github.com/fish-tennis/gserver/pb"
+	"github.com/fish-tennis/gserver/util"
 )
 
 // 客户端账号登录
@@ -17,7 +19,11 @@ import (
 func onLoginReq(connection Connection, packet Packet) {
 	req := packet.Message().(*pb.LoginReq)
 	accountName := req.GetAccountName()
-	if !internal.SubmitDbTaskByName(accountName, func() {
+	taskKey := accountName
+	if req.GetProvider() != "" {
+		taskKey = GetProviderAccountName(req.GetProvider(), req.GetCredential())
+	}
+	if !internal.SubmitDbTaskByName(taskKey, func() {
 		processLoginReq(connection, packet, req)
 	}) {
 		// 协程池队列满,返回TryLater让客户端延迟重试
@@ -45,27 +51,45 @@ func processLoginReq(connection Connection, packet Packet, req *pb.LoginReq) {
 		slog.Debug("processLoginReq connection closed", "accountName", req.GetAccountName())
 		return
 	}
-	err := _loginServer.getAccountData(req.GetAccountName(), account)
-	if err != nil {
-		errorCode = pb.ErrorCode_ErrorCode_DbErr
-		return
+	if req.GetProvider() != "" {
+		// 其他登录方式
+		errorCode = getProviderAccount(req.GetProvider(), req.GetCredential(), account, true)
+		if errorCode != 0 {
+			return
+		}
 	} else {
+		err := _loginServer.getAccountData(req.GetAccountName(), account)
+		if err != nil {
+			errorCode = pb.ErrorCode_ErrorCode_DbErr
+			return
+		}
 		if account.XId == 0 {
 			errorCode = pb.ErrorCode_ErrorCode_NotReg
 			return
-			// 密码安全说明:客户端在传输前已完成加密(如RSA+AES混合加密),传到服务器的并非明文
-			// 服务器存储和比较的都是加密后的值,因此这里用 != 直接比较是安全的
-		} else if req.GetPassword() != account.GetPassword() {
-			errorCode = pb.ErrorCode_ErrorCode_PasswordError
+		}
+		errorCode = verifyLogin(req, account)
+		if errorCode != 0 {
 			return
 		}
 	}
-	loginRes.AccountId = account.XId
-	loginRes.LoginSession = cache.NewLoginSession(account)
-	if loginRes.LoginSession == "" {
+	// 封号检查,只封了某个角色的在进游戏时检查
+	now := time.Now().Unix()
+	sanctions, err := db.FindActiveSanctions(account.GetXId(), now)
+	if err != nil {
 		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		slog.Error("FindActiveSanctions error", "accountId", account.GetXId(), "error", err)
+		return
+	}
+	if ban := db.MatchSanction(sanctions, pb.SanctionType_SanctionType_Ban, 0, now); ban != nil {
+		loginRes.BanEndTimestamp = ban.EndTimestamp
+		loginRes.BanReason = ban.Reason
+		errorCode = pb.ErrorCode_ErrorCode_AccountBanned
 		return
 	}
+	loginRes.AccountName = account.GetName()
+	// 每次登录都更换RefreshToken
+	loginRes.RefreshToken = cache.NewRefreshToken(account.GetXId())
+	loginRes.AccountId = account.XId
 	onlinePlayerId, gameServerId := cache.GetOnlineAccount(account.GetXId())
 	if onlinePlayerId > 0 {
 		// 如果该账号还在游戏中,则需要先将其清理下线
@@ -89,28 +113,97 @@ func processLoginReq(connection Connection, packet Packet, req *pb.LoginReq) {
 			}
 		}
 	}
-	// 没有在线记录或目标服不可达,随机分配一个游戏服
-	gameServerInfo := selectGameServer(account)
+	// 没有在线记录或目标服不可达,按负载分配一个游戏服(排空中的游戏服不分配)
+	// 已经有账号在排队时,不能插队
+	var gameServerInfo *pb.ServerInfo
+	if !_loginQueue.HasWaiting() {
+		gameServerInfo = selectGameServer(account)
+	}
 	if gameServerInfo == nil {
+		if len(_loginServer.GetServerList().GetAvailableServersByType(internal.ServerType_Game)) > 0 &&
+			_loginQueue.Join(connection, packet, account) {
+			// 所有游戏服都满员,排到后再推送LoginRes
+			errorCode = pb.ErrorCode_ErrorCode_InLoginQueue
+			return
+		}
 		errorCode = pb.ErrorCode_ErrorCode_TryLater
 		return
 	}
+	loginRes.LoginSession = cache.NewLoginSession(account)
+	if loginRes.LoginSession == "" {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		return
+	}
 	loginRes.GameServer = &pb.GameServerInfo{
 		ServerId:         gameServerInfo.GetServerId(),
 		ClientListenAddr: gameServerInfo.GetClientListenAddr(),
 	}
 }
 
+// 网关通知:排队中的客户端断开连接了
+func onClientDisconnect(connection Connection, packet Packet) {
+	req := packet.Message().(*pb.ClientDisconnect)
+	_loginQueue.RemoveByConnection(connection, req.GetClientConnId())
+}
+
+// 验证密码或RefreshToken
+//
+//	密码:客户端在传输前已完成加密(如RSA+AES混合加密),传到服务器的并非明文,
+//	服务器再加盐hash后存储,旧账号存的是客户端发来的原始值,验证成功后自动更新成hash
+func verifyLogin(req *pb.LoginReq, account *pb.Account) pb.ErrorCode {
+	if req.GetPassword() == "" && req.GetRefreshToken() != "" {
+		if !cache.UseRefreshToken(account.GetXId(), req.GetRefreshToken()) {
+			return pb.ErrorCode_ErrorCode_RefreshTokenError
+		}
+		return 0
+	}
+	if account.GetProvider() != "" {
+		// 其他登录方式创建的账号没有密码
+		return pb.ErrorCode_ErrorCode_PasswordError
+	}
+	if lockTime := cache.GetLoginLockTime(account.GetName()); lockTime > 0 {
+		slog.Debug("AccountLocked", "accountName", account.GetName(), "lockTime", lockTime)
+		return pb.ErrorCode_ErrorCode_AccountLocked
+	}
+	var ok, needRehash bool
+	if util.IsPasswordHashed(account.GetPassword()) {
+		var err error
+		ok, needRehash, err = util.VerifyPassword(req.GetPassword(), account.GetPassword())
+		if err != nil {
+			slog.Error("VerifyPassword error", "accountId", account.GetXId(), "error", err)
+		}
+	} else {
+		ok = subtle.ConstantTimeCompare([]byte(req.GetPassword()), []byte(account.GetPassword())) == 1
+		needRehash = ok
+	}
+	if !ok {
+		if cache.IncrLoginFail(account.GetName()) >= cache.LoginFailMaxCount {
+			return pb.ErrorCode_ErrorCode_AccountLocked
+		}
+		return pb.ErrorCode_ErrorCode_PasswordError
+	}
+	cache.ClearLoginFail(account.GetName())
+	if needRehash {
+		// 更新失败不影响本次登录,下次登录再试
+		_loginServer.updateAccountPassword(account.GetXId(), req.GetPassword())
+	}
+	return 0
+}
+
 // 选择一个游戏服给登录成功的客户端
-// NOTE:可以在这里做游戏服的负载均衡
 func selectGameServer(account *pb.Account) *pb.ServerInfo {
-	gameServerInfos := _loginServer.GetServerList().GetServersByType(internal.ServerType_Game)
-	if len(gameServerInfos) > 0 {
-		// 作为演示,这里随机一个
-		selectGameServerInfo := gameServerInfos[rand.Intn(len(gameServerInfos))]
-		return selectGameServerInfo
+	selectConfig := _loginServer.GetConfig().GameServerSelect
+	gameServerInfos := _loginServer.GetServerList().GetAvailableServersByType(internal.ServerType_Game)
+	lastServerId := int32(0)
+	if selectConfig.Sticky {
+		lastServerId = cache.GetAccountLastServer(account.GetXId())
+	}
+	gameServerInfo := _gameServerSelector.Select(gameServerInfos, selectConfig.Strategy, lastServerId)
+	if gameServerInfo != nil && selectConfig.Sticky {
+		// 每次都记录,刷新过期时间
+		cache.SetAccountLastServer(account.GetXId(), gameServerInfo.GetServerId())
 	}
-	return nil
+	return gameServerInfo
 }
 
 // 注册账号
@@ -144,13 +237,36 @@ func processAccountReg(connection Connection, packet Packet, req *pb.AccountReg)
 		slog.Debug("processAccountReg connection closed", "accountName", req.GetAccountName())
 		return
 	}
-	result := ""
-	newAccountIdValue, err := db.GetKvDb().Inc(db.AccountIdKeyName, int64(1), true)
+	errorCode = checkAccountName(req.GetAccountName())
+	if errorCode != 0 {
+		return
+	}
+	// 客户端加密后的值,再加盐hash后存储
+	passwordHash, err := util.HashPassword(req.GetPassword())
 	if err != nil {
 		errorCode = pb.ErrorCode_ErrorCode_DbErr
-		slog.Error("onAccountReg error", "error", err)
+		slog.Error("onAccountReg HashPassword error", "error", err)
 		return
 	}
+	account := &pb.Account{
+		Name:     req.GetAccountName(),
+		Password: passwordHash,
+	}
+	errorCode = createAccount(account)
+	if errorCode != 0 {
+		return
+	}
+	res.AccountId = account.XId
+}
+
+// 创建账号,分配账号id
+func createAccount(account *pb.Account) pb.ErrorCode {
+	result := ""
+	newAccountIdValue, err := db.GetKvDb().Inc(db.AccountIdKeyName, int64(1), true)
+	if err != nil {
+		slog.Error("createAccount error", "error", err)
+		return pb.ErrorCode_ErrorCode_DbErr
+	}
 	var newAccountId int64
 	switch idVal := newAccountIdValue.(type) {
 	case int64:
@@ -160,33 +276,121 @@ func processAccountReg(connection Connection, packet Packet, req *pb.AccountReg)
 	case float64:
 		newAccountId = int64(idVal)
 	default:
-		errorCode = pb.ErrorCode_ErrorCode_DbErr
-		slog.Error("onAccountReg invalid accountId type", "type", newAccountIdValue, "val", newAccountIdValue)
-		return
-	}
-	account := &pb.Account{
-		XId:  newAccountId,
-		Name: req.GetAccountName(),
-		// 存储的是客户端加密后的值,非明文密码(加密由客户端负责)
-		Password: req.GetPassword(),
+		slog.Error("createAccount invalid accountId type", "type", newAccountIdValue, "val", newAccountIdValue)
+		return pb.ErrorCode_ErrorCode_DbErr
 	}
+	account.XId = newAccountId
 	accountMapData := map[string]any{
-		db.UniqueIdName: account.XId, // mongodb _id特殊处理
-		"Name":          account.Name,
-		"Password":      account.Password, // 客户端加密后的值,非明文
+		db.UniqueIdName:    account.XId, // mongodb _id特殊处理
+		db.AccountName:     account.Name,
+		db.AccountPassword: account.Password, // 加盐hash后的值
+	}
+	if account.Provider != "" {
+		accountMapData[db.AccountProvider] = account.Provider
 	}
 	err, isDuplicateKey := _loginServer.GetAccountDb().InsertEntity(account.XId, accountMapData)
 	if err != nil {
 		account.XId = 0
+		errorCode := pb.ErrorCode_ErrorCode_DbErr
+		result = "DbError"
 		if isDuplicateKey {
 			errorCode = pb.ErrorCode_ErrorCode_NameDuplicate
 			result = "AccountNameDuplicate"
-		} else {
-			result = "DbError"
-			errorCode = pb.ErrorCode_ErrorCode_DbErr
 		}
-		slog.Error("onAccountReg error", "account", account.Name, "result", result, "error", err.Error())
+		slog.Error("createAccount error", "account", account.Name, "result", result, "error", err.Error())
+		return errorCode
+	}
+	return 0
+}
+
+// 获取其他登录方式对应的账号,autoCreate表示外部账号首次登录时自动创建账号
+func getProviderAccount(providerName, credential string, account *pb.Account, autoCreate bool) pb.ErrorCode {
+	provider := GetLoginProvider(providerName)
+	if provider == nil {
+		return pb.ErrorCode_ErrorCode_LoginProviderError
+	}
+	externalId, err := provider.Verify(credential)
+	if err != nil {
+		slog.Debug("LoginProvider Verify error", "provider", providerName, "error", err)
+		return pb.ErrorCode_ErrorCode_LoginProviderError
+	}
+	accountName := GetProviderAccountName(providerName, externalId)
+	err = _loginServer.getAccountData(accountName, account)
+	if err != nil {
+		return pb.ErrorCode_ErrorCode_DbErr
+	}
+	if account.XId > 0 {
+		return 0
+	}
+	if !autoCreate {
+		return pb.ErrorCode_ErrorCode_NotReg
+	}
+	account.Name = accountName
+	account.Provider = providerName
+	errorCode := createAccount(account)
+	if errorCode == pb.ErrorCode_ErrorCode_NameDuplicate {
+		// 同一个外部账号同时登录,已经被创建了
+		if _loginServer.getAccountData(accountName, account) != nil || account.XId == 0 {
+			return pb.ErrorCode_ErrorCode_DbErr
+		}
+		return 0
+	}
+	if errorCode == 0 {
+		slog.Info("createProviderAccount", "accountId", account.XId, "accountName", accountName)
+	}
+	return errorCode
+}
+
+// 游客账号绑定账号名和密码
+func onAccountBindReq(connection Connection, packet Packet) {
+	req := packet.Message().(*pb.AccountBindReq)
+	if !internal.SubmitDbTaskByName(req.GetAccountName(), func() {
+		processAccountBindReq(connection, packet, req)
+	}) {
+		network.SendPacketAdaptWithError(connection, packet, &pb.AccountBindRes{
+			AccountName: req.GetAccountName(),
+		}, int32(pb.ErrorCode_ErrorCode_TryLater))
+		slog.Warn("DbWorkerPool full for onAccountBindReq", "accountName", req.GetAccountName())
+	}
+}
+
+// processAccountBindReq 绑定账号的实际处理逻辑,在DB协程池中执行
+func processAccountBindReq(connection Connection, packet Packet, req *pb.AccountBindReq) {
+	slog.Debug("onAccountBindReq", "provider", req.GetProvider(), "accountName", req.GetAccountName())
+	var errorCode pb.ErrorCode
+	res := &pb.AccountBindRes{
+		AccountName: req.GetAccountName(),
+	}
+	defer func() {
+		network.SendPacketAdaptWithError(connection, packet, res, int32(errorCode))
+	}()
+	if !connection.IsConnected() {
 		return
 	}
-	res.AccountId = account.XId
+	// 目前只有游客账号可以绑定
+	if _, ok := GetLoginProvider(req.GetProvider()).(*GuestLoginProvider); !ok {
+		errorCode = pb.ErrorCode_ErrorCode_LoginProviderError
+		return
+	}
+	errorCode = checkAccountName(req.GetAccountName())
+	if errorCode != 0 {
+		return
+	}
+	account := &pb.Account{}
+	errorCode = getProviderAccount(req.GetProvider(), req.GetCredential(), account, false)
+	if errorCode != 0 {
+		return
+	}
+	passwordHash, err := util.HashPassword(req.GetPassword())
+	if err != nil {
+		errorCode = pb.ErrorCode_ErrorCode_DbErr
+		slog.Error("onAccountBindReq HashPassword error", "error", err)
+		return
+	}
+	errorCode = _loginServer.bindAccount(account.GetXId(), req.GetAccountName(), passwordHash)
+	if errorCode != 0 {
+		return
+	}
+	res.AccountId = account.GetXId()
+	slog.Info("AccountBind", "accountId", account.GetXId(), "from", account.GetName(), "to", req.GetAccountName())
 }
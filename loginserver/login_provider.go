@@ -0,0 +1,183 @@
+package loginserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+// 登录方式
+const (
+	LoginProvider_Guest     = "guest"     // 游客,凭证是设备id
+	LoginProvider_HmacToken = "HmacToken" // 渠道服务器签发的hmac签名token
+)
+
+// 外部账号id的最大长度
+const MaxExternalIdLength = 128
+
+var (
+	ErrInvalidCredential = errors.New("invalid credential")
+	ErrTokenExpired      = errors.New("token expired")
+
+	// 已注册的登录方式
+	_loginProviders = make(map[string]LoginProvider)
+)
+
+// 账号密码之外的登录方式
+//
+//	把外部身份(设备id,渠道账号等)映射到账号,账号名是"登录方式:外部账号id",
+//	外部账号首次登录时自动创建账号
+type LoginProvider interface {
+	// 登录方式的名字,对应LoginReq.Provider
+	GetName() string
+	// 验证客户端发来的凭证,返回外部账号id
+	Verify(credential string) (externalId string, err error)
+}
+
+// 注册登录方式
+func RegisterLoginProvider(provider LoginProvider) {
+	_loginProviders[provider.GetName()] = provider
+	slog.Info("RegisterLoginProvider", "name", provider.GetName())
+}
+
+func GetLoginProvider(name string) LoginProvider {
+	return _loginProviders[name]
+}
+
+// 根据配置注册登录方式
+func registerLoginProviders(configs []internal.LoginProviderConfig) {
+	for _, config := range configs {
+		name := config.Name
+		if name == "" {
+			name = config.Type
+		}
+		switch config.Type {
+		case LoginProvider_Guest:
+			RegisterLoginProvider(&GuestLoginProvider{name: name})
+		case LoginProvider_HmacToken:
+			if config.Secret == "" {
+				panic("LoginProvider HmacToken need Secret:" + name)
+			}
+			RegisterLoginProvider(NewHmacTokenLoginProvider(name, []byte(config.Secret), time.Duration(config.TokenMaxAge)*time.Second))
+		default:
+			panic("unknown LoginProvider type:" + config.Type)
+		}
+	}
+}
+
+// 外部账号对应的账号名
+func GetProviderAccountName(providerName, externalId string) string {
+	return providerName + ":" + externalId
+}
+
+// 检查外部账号id,只允许可见的ascii字符
+func checkExternalId(externalId string) error {
+	if externalId == "" || len(externalId) > MaxExternalIdLength {
+		return ErrInvalidCredential
+	}
+	for i := 0; i < len(externalId); i++ {
+		if externalId[i] <= ' ' || externalId[i] > '~' {
+			return ErrInvalidCredential
+		}
+	}
+	return nil
+}
+
+// 游客登录,凭证就是设备id
+type GuestLoginProvider struct {
+	name string
+}
+
+func (this *GuestLoginProvider) GetName() string {
+	return this.name
+}
+
+func (this *GuestLoginProvider) Verify(credential string) (string, error) {
+	if err := checkExternalId(credential); err != nil {
+		return "", err
+	}
+	return credential, nil
+}
+
+// 渠道服务器签发的token
+//
+//	token格式: 外部账号id.过期时间戳(秒).签名,签名=hex(hmac-sha256(secret, "外部账号id.过期时间戳"))
+//	接入渠道sdk时,渠道服务器验证完渠道账号后签发token,测试环境可以用IssueHmacToken模拟
+type HmacTokenLoginProvider struct {
+	name   string
+	secret []byte
+	maxAge time.Duration
+}
+
+func NewHmacTokenLoginProvider(name string, secret []byte, maxAge time.Duration) *HmacTokenLoginProvider {
+	return &HmacTokenLoginProvider{
+		name:   name,
+		secret: secret,
+		maxAge: maxAge,
+	}
+}
+
+func (this *HmacTokenLoginProvider) GetName() string {
+	return this.name
+}
+
+func (this *HmacTokenLoginProvider) Verify(credential string) (string, error) {
+	index := strings.LastIndexByte(credential, '.')
+	if index < 0 {
+		return "", ErrInvalidCredential
+	}
+	payload, signature := credential[:index], credential[index+1:]
+	expectedSignature := hmacSign(this.secret, payload)
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return "", ErrInvalidCredential
+	}
+	index = strings.LastIndexByte(payload, '.')
+	if index < 0 {
+		return "", ErrInvalidCredential
+	}
+	externalId := payload[:index]
+	expireTime, err := strconv.ParseInt(payload[index+1:], 10, 64)
+	if err != nil {
+		return "", ErrInvalidCredential
+	}
+	now := time.Now()
+	if now.Unix() > expireTime {
+		return "", ErrTokenExpired
+	}
+	// 防止签发了有效期过长的token
+	if this.maxAge > 0 && time.Unix(expireTime, 0).Sub(now) > this.maxAge {
+		return "", ErrInvalidCredential
+	}
+	if err = checkExternalId(externalId); err != nil {
+		return "", err
+	}
+	return externalId, nil
+}
+
+// 签发token,用于测试或者渠道服务器
+func IssueHmacToken(secret []byte, externalId string, expireTime time.Time) string {
+	payload := externalId + "." + strconv.FormatInt(expireTime.Unix(), 10)
+	return payload + "." + hmacSign(secret, payload)
+}
+
+func hmacSign(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// 检查注册或绑定的账号名,不能和外部账号的账号名冲突
+func checkAccountName(accountName string) pb.ErrorCode {
+	if accountName == "" || strings.Contains(accountName, ":") {
+		return pb.ErrorCode_ErrorCode_AccountNameInvalid
+	}
+	return 0
+}
@@ -0,0 +1,45 @@
+package loginserver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHmacTokenLoginProvider(t *testing.T) {
+	secret := []byte("test-secret")
+	provider := NewHmacTokenLoginProvider("test", secret, time.Hour)
+	// 本地测试签发者
+	token := IssueHmacToken(secret, "user.123", time.Now().Add(time.Minute))
+	externalId, err := provider.Verify(token)
+	if err != nil || externalId != "user.123" {
+		t.Fatalf("externalId:%v err:%v", externalId, err)
+	}
+	// 密钥不对
+	if _, err = provider.Verify(IssueHmacToken([]byte("other"), "user.123", time.Now().Add(time.Minute))); err != ErrInvalidCredential {
+		t.Errorf("wrong secret err:%v", err)
+	}
+	// 篡改外部账号id
+	if _, err = provider.Verify("user.124" + token[len("user.123"):]); err != ErrInvalidCredential {
+		t.Errorf("tampered token err:%v", err)
+	}
+	// 过期
+	if _, err = provider.Verify(IssueHmacToken(secret, "user.123", time.Now().Add(-time.Second))); err != ErrTokenExpired {
+		t.Errorf("expired token err:%v", err)
+	}
+	// 有效期过长
+	if _, err = provider.Verify(IssueHmacToken(secret, "user.123", time.Now().Add(time.Hour*2))); err != ErrInvalidCredential {
+		t.Errorf("long token err:%v", err)
+	}
+}
+
+func TestGuestLoginProvider(t *testing.T) {
+	provider := &GuestLoginProvider{name: LoginProvider_Guest}
+	if externalId, err := provider.Verify("device-001"); err != nil || externalId != "device-001" {
+		t.Errorf("externalId:%v err:%v", externalId, err)
+	}
+	for _, credential := range []string{"", "device 001", string(make([]byte, MaxExternalIdLength+1))} {
+		if _, err := provider.Verify(credential); err == nil {
+			t.Errorf("invalid credential verified:%q", credential)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package loginserver
+
+import (
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	. "github.com/fish-tennis/gnet"
+	"github.com/fish-tennis/gserver/cache"
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/network"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+var (
+	// 排队检查的间隔
+	LoginQueueTickInterval = time.Second
+	// 给排队的客户端推送排队进度的间隔
+	LoginQueueUpdateInterval = 5 * time.Second
+	// 估算排队速度的平滑系数
+	LoginQueueRateSmoothing = 0.2
+
+	_loginQueue = newLoginQueue()
+)
+
+// 登录排队
+//
+//	所有游戏服都满员时,账号进入redis中的排队队列(多个登录服共用,先进先出),
+//	每个登录服只负责放行自己的排队账号,排到的账号直接推送LoginRes,客户端不需要重新登录
+type loginQueue struct {
+	mutex   sync.Mutex
+	entries map[string]*loginQueueEntry // member-排队信息
+	// 上次检查时,redis中累计出队的人数
+	lastAdmitted int64
+	// 平均每秒出队的人数
+	admitRate float64
+	// 上次推送排队进度的时间
+	lastUpdateTime time.Time
+}
+
+// 本登录服的一个排队账号
+type loginQueueEntry struct {
+	member     string
+	account    *pb.Account
+	connection Connection // 客户端直连时是客户端连接,网关模式时是网关连接
+	packet     Packet     // 登录请求包,用于区分客户端直连和网关模式
+}
+
+func newLoginQueue() *loginQueue {
+	return &loginQueue{
+		entries:      make(map[string]*loginQueueEntry),
+		lastAdmitted: -1,
+	}
+}
+
+// 是否有账号在排队
+func (this *loginQueue) HasWaiting() bool {
+	count, _ := cache.GetLoginQueueLength()
+	return count > 0
+}
+
+// 加入排队,已经在排队的账号,保持原来的位置
+func (this *loginQueue) Join(connection Connection, packet Packet, account *pb.Account) bool {
+	member := cache.LoginQueueMember(_loginServer.GetId(), account.GetXId())
+	entry := &loginQueueEntry{
+		member:     member,
+		account:    account,
+		connection: connection,
+		packet:     packet,
+	}
+	// 先加入本地记录,再加入redis队列
+	// 否则定时放行时可能看到redis里的账号却找不到本地记录,当成本登录服重启前的排队账号删除掉
+	this.mutex.Lock()
+	oldEntry := this.entries[member]
+	this.entries[member] = entry
+	this.mutex.Unlock()
+	if !cache.JoinLoginQueue(member) {
+		this.mutex.Lock()
+		if this.entries[member] == entry {
+			if oldEntry != nil {
+				this.entries[member] = oldEntry
+			} else {
+				delete(this.entries, member)
+			}
+		}
+		this.mutex.Unlock()
+		return false
+	}
+	slog.Info("JoinLoginQueue", "accountId", account.GetXId(), "accountName", account.GetName())
+	return true
+}
+
+// 客户端断开连接,退出排队
+func (this *loginQueue) RemoveByConnection(connection Connection, clientConnId uint32) {
+	var removed []string
+	this.mutex.Lock()
+	for member, entry := range this.entries {
+		if entry.connection != connection {
+			continue
+		}
+		if gatePacket, ok := entry.packet.(*network.GatePacket); ok && uint32(gatePacket.PlayerId()) != clientConnId {
+			continue
+		}
+		delete(this.entries, member)
+		removed = append(removed, member)
+	}
+	this.mutex.Unlock()
+	if len(removed) > 0 {
+		cache.RemoveLoginQueue(removed...)
+		slog.Info("LeaveLoginQueue", "members", removed)
+	}
+}
+
+func (this *loginQueue) getEntry(member string) *loginQueueEntry {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.entries[member]
+}
+
+func (this *loginQueue) removeEntry(member string) {
+	this.mutex.Lock()
+	delete(this.entries, member)
+	this.mutex.Unlock()
+}
+
+// 排队检查协程
+func (this *loginQueue) run(server *LoginServer) {
+	defer server.GetWaitGroup().Done()
+	ticker := time.NewTicker(LoginQueueTickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-server.GetContext().Done():
+			return
+		case <-ticker.C:
+			if server.IsRunning() {
+				this.onTick(server)
+			}
+		}
+	}
+}
+
+func (this *loginQueue) onTick(server *LoginServer) {
+	this.removeDisconnected()
+	this.updateAdmitRate()
+	queueLength, err := cache.GetLoginQueueLength()
+	if err != nil || queueLength == 0 {
+		return
+	}
+	this.admit(server, queueLength)
+	if time.Since(this.lastUpdateTime) >= LoginQueueUpdateInterval {
+		this.lastUpdateTime = time.Now()
+		this.pushQueueUpdate()
+	}
+}
+
+// 移除已经断开连接的排队账号
+func (this *loginQueue) removeDisconnected() {
+	var removed []string
+	this.mutex.Lock()
+	for member, entry := range this.entries {
+		if !entry.connection.IsConnected() {
+			delete(this.entries, member)
+			removed = append(removed, member)
+		}
+	}
+	this.mutex.Unlock()
+	cache.RemoveLoginQueue(removed...)
+}
+
+// 根据累计出队人数的变化,估算每秒的出队人数
+func (this *loginQueue) updateAdmitRate() {
+	admitted := cache.GetLoginQueueAdmitted()
+	if this.lastAdmitted >= 0 && admitted >= this.lastAdmitted {
+		rate := float64(admitted-this.lastAdmitted) / LoginQueueTickInterval.Seconds()
+		this.admitRate = this.admitRate*(1-LoginQueueRateSmoothing) + rate*LoginQueueRateSmoothing
+	}
+	this.lastAdmitted = admitted
+}
+
+// 游戏服有空位时,放行排在前面的账号
+//
+//	每个登录服都按相同的规则计算可以放行的人数,只放行其中属于自己的账号
+func (this *loginQueue) admit(server *LoginServer, queueLength int64) {
+	gameServerInfos := server.GetServerList().GetAvailableServersByType(internal.ServerType_Game)
+	if len(gameServerInfos) == 0 {
+		return
+	}
+	freeCount, unlimited := _gameServerSelector.GetFreeCapacity(gameServerInfos)
+	admitCount := int64(freeCount)
+	if unlimited {
+		admitCount = queueLength
+	}
+	if admitCount <= 0 {
+		return
+	}
+	members, err := cache.GetLoginQueueHead(admitCount)
+	if err != nil {
+		return
+	}
+	// 服务器列表中有自己,说明已经获取到登录服列表了,才能判断其他登录服是否还在
+	serverList := server.GetServerList()
+	loginServerListReady := serverList.GetServerInfo(server.GetId()) != nil
+	var removed []string
+	admitted := int64(0)
+	for _, member := range members {
+		loginServerId, _ := cache.ParseLoginQueueMember(member)
+		if loginServerId != server.GetId() {
+			if loginServerListReady && serverList.GetServerInfo(loginServerId) == nil {
+				// 登录服已经关闭,它的排队账号无法放行了
+				removed = append(removed, member)
+			}
+			continue
+		}
+		entry := this.getEntry(member)
+		if entry == nil {
+			// 本登录服重启前的排队账号
+			removed = append(removed, member)
+			continue
+		}
+		gameServerInfo := selectGameServer(entry.account)
+		if gameServerInfo == nil {
+			break
+		}
+		loginRes := &pb.LoginRes{
+			AccountName: entry.account.GetName(),
+			AccountId:   entry.account.GetXId(),
+			GameServer: &pb.GameServerInfo{
+				ServerId:         gameServerInfo.GetServerId(),
+				ClientListenAddr: gameServerInfo.GetClientListenAddr(),
+			},
+		}
+		loginRes.LoginSession = cache.NewLoginSession(entry.account)
+		if loginRes.LoginSession == "" {
+			// redis异常,下次再试
+			break
+		}
+		this.removeEntry(member)
+		removed = append(removed, member)
+		admitted++
+		network.PushPacketAdapt(entry.connection, entry.packet, loginRes, 0)
+		slog.Info("AdmitLoginQueue", "accountId", entry.account.GetXId(), "gameServer", gameServerInfo.GetServerId())
+	}
+	cache.RemoveLoginQueue(removed...)
+	if admitted > 0 {
+		cache.IncrLoginQueueAdmitted(admitted)
+	}
+}
+
+// 给本登录服的排队账号推送排队进度
+func (this *loginQueue) pushQueueUpdate() {
+	this.mutex.Lock()
+	entries := make([]*loginQueueEntry, 0, len(this.entries))
+	members := make([]string, 0, len(this.entries))
+	for member, entry := range this.entries {
+		entries = append(entries, entry)
+		members = append(members, member)
+	}
+	this.mutex.Unlock()
+	if len(members) == 0 {
+		return
+	}
+	positions, err := cache.GetLoginQueuePositions(members)
+	if err != nil {
+		return
+	}
+	queueLength, _ := cache.GetLoginQueueLength()
+	for i, entry := range entries {
+		if positions[i] == 0 {
+			continue
+		}
+		network.PushPacketAdapt(entry.connection, entry.packet, &pb.LoginQueueUpdate{
+			AccountId:   entry.account.GetXId(),
+			Position:    int32(positions[i]),
+			QueueLength: int32(queueLength),
+			EtaSeconds:  this.getEtaSeconds(positions[i]),
+		}, 0)
+	}
+}
+
+// 预计的排队时间,还无法估算时返回0
+func (this *loginQueue) getEtaSeconds(position int64) int32 {
+	if this.admitRate < 0.01 {
+		return 0
+	}
+	return int32(math.Ceil(float64(position) / this.admitRate))
+}
@@ -13,6 +13,7 @@ import (
 	. "github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/network"
 	"github.com/fish-tennis/gserver/pb"
+	"github.com/fish-tennis/gserver/util"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
@@ -53,6 +54,7 @@ func (this *LoginServer) Init(ctx context.Context, configFile string) bool {
 	}
 	this.initDb()
 	this.initCache()
+	registerLoginProviders(this.GetConfig().LoginProviders)
 	this.initNetwork()
 	// 初始化DB操作协程池,将登录/注册等含DB查询的请求从收包goroutine卸载
 	InitDbWorkerPool()
@@ -63,6 +65,9 @@ func (this *LoginServer) Init(ctx context.Context, configFile string) bool {
 // 运行
 func (this *LoginServer) Run(ctx context.Context) {
 	this.BaseServer.Run(ctx)
+	// 登录排队
+	this.GetWaitGroup().Add(1)
+	go _loginQueue.run(this)
 	slog.Info("LoginServer.Run")
 }
 
@@ -85,6 +90,8 @@ func (this *LoginServer) initDb() {
 	mongoDb := gentity.NewMongoDb(this.GetConfig().Mongo.Uri, this.GetConfig().Mongo.Db)
 	// 账号数据库
 	this.accountDb = mongoDb.RegisterEntityDb(db.AccountDbName, true, db.UniqueIdName)
+	// 处罚记录
+	mongoDb.RegisterEntityDb(db.SanctionDbName, true, db.UniqueIdName)
 	// kv数据库
 	mongoDb.RegisterKvDb(db.GlobalDbName, true, db.GlobalDbKeyName, db.GlobalDbValueName)
 	if !mongoDb.Connect() {
@@ -93,6 +100,7 @@ func (this *LoginServer) initDb() {
 	// 账号名建立唯一索引
 	this.accountDb.(*gentity.MongoCollection).CreateIndex(db.AccountName, true)
 	db.SetDbMgr(mongoDb)
+	db.CreateSanctionIndex()
 }
 
 // 初始化redis缓存
@@ -114,7 +122,8 @@ func (this *LoginServer) initNetwork() {
 		panic("listen gateserver failed")
 	}
 	this.GetServerList().SetCache(cache.Get())
-	this.BaseServer.GetServerList().SetFetchServerTypes(ServerType_Game)
+	// 登录服列表用于清理已关闭的登录服的排队账号
+	this.BaseServer.GetServerList().SetFetchServerTypes(ServerType_Game, ServerType_Login)
 }
 
 func (this *LoginServer) getAccountData(accountName string, accountData *pb.Account) error {
@@ -147,6 +156,42 @@ func (this *LoginServer) getAccountData(accountName string, accountData *pb.Acco
 	return nil
 }
 
+// 更新账号的密码hash
+func (this *LoginServer) updateAccountPassword(accountId int64, password string) bool {
+	passwordHash, err := util.HashPassword(password)
+	if err != nil {
+		slog.Error("updateAccountPassword HashPassword error", "accountId", accountId, "error", err)
+		return false
+	}
+	col := this.GetAccountDb().(*gentity.MongoCollection).GetCollection()
+	_, err = col.UpdateOne(context.Background(), bson.D{{db.UniqueIdName, accountId}},
+		bson.D{{"$set", bson.D{{db.AccountPassword, passwordHash}}}})
+	if err != nil {
+		slog.Error("updateAccountPassword error", "accountId", accountId, "error", err)
+		return false
+	}
+	slog.Info("updateAccountPassword", "accountId", accountId)
+	return true
+}
+
+// 游客账号绑定账号名和密码,绑定后只能用账号名登录
+func (this *LoginServer) bindAccount(accountId int64, accountName, passwordHash string) pb.ErrorCode {
+	col := this.GetAccountDb().(*gentity.MongoCollection).GetCollection()
+	_, err := col.UpdateOne(context.Background(), bson.D{{db.UniqueIdName, accountId}},
+		bson.D{
+			{"$set", bson.D{{db.AccountName, accountName}, {db.AccountPassword, passwordHash}}},
+			{"$unset", bson.D{{db.AccountProvider, ""}}},
+		})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return pb.ErrorCode_ErrorCode_NameDuplicate
+		}
+		slog.Error("bindAccount error", "accountId", accountId, "error", err)
+		return pb.ErrorCode_ErrorCode_DbErr
+	}
+	return 0
+}
+
 // 注册客户端消息回调
 func (this *LoginServer) registerClientPacket(clientHandler *DefaultConnectionHandler) {
 	// 状态检查包装器:非Running状态时拒绝客户端请求
@@ -172,9 +217,12 @@ func (this *LoginServer) registerClientPacket(clientHandler *DefaultConnectionHa
 	}
 	network.RegisterPacketHandler(clientHandler, new(pb.LoginReq), checkRunning(onLoginReq))
 	network.RegisterPacketHandler(clientHandler, new(pb.AccountReg), checkRunning(onAccountReg))
+	network.RegisterPacketHandler(clientHandler, new(pb.AccountBindReq), checkRunning(onAccountBindReq))
 }
 
 // 注册服务器消息回调
 func (this *LoginServer) registerServerPacket(serverHandler *DefaultConnectionHandler) {
 	this.registerClientPacket(serverHandler)
+	// 排队中的客户端断开连接
+	network.RegisterPacketHandler(serverHandler, new(pb.ClientDisconnect), onClientDisconnect)
 }
@@ -87,6 +87,13 @@ func main() {
 				lineBytes, _, _ := consoleReader.ReadLine()
 				line := strings.ToLower(string(lineBytes))
 				slog.Info("line", "line", line)
+				if line == "drain" {
+					// 排空游戏服,排空完成后再关闭
+					if drainServer, ok := server.(interface{ StartDraining() bool }); ok {
+						slog.Info("drain by console input", "result", drainServer.StartDraining())
+					}
+					continue
+				}
 				if line == "close" || line == "exit" {
 					slog.Info("kill by console input")
 					// 模拟一个kill信号,以方便测试服务器退出流程
@@ -96,15 +103,21 @@ func main() {
 			}
 		}()
 	}
+	// 服务器主动请求关闭(如游戏服排空完成)
+	var exitNotify <-chan struct{}
+	if exitServer, ok := server.(interface{ ExitNotify() <-chan struct{} }); ok {
+		exitNotify = exitServer.ExitNotify()
+	}
 	// 阻塞等待系统关闭信号
 	slog.Info("wait for kill signal")
 	select {
 	case <-signalKillNotify:
 		slog.Info("signalKillNotify, cancel ctx")
-		// 通知所有协程关闭,所有监听<-ctx.Done()的地方会收到通知
-		cancel()
-		break
+	case <-exitNotify:
+		slog.Info("exitNotify, cancel ctx")
 	}
+	// 通知所有协程关闭,所有监听<-ctx.Done()的地方会收到通知
+	cancel()
 	// 清理
 	server.Exit()
 }
@@ -138,3 +138,12 @@ func SendPacketAdaptWithError(connection Connection, reqPacket Packet, sendMessa
 		return connection.SendPacket(packet)
 	}
 }
+
+// 根据请求消息的类型,自动适配不同的发消息接口,用于请求之后的主动推送,不带请求的rpcCallId
+func PushPacketAdapt(connection Connection, reqPacket Packet, sendMessage proto.Message, errorCode int32) bool {
+	cmd := GetCommandByProto(sendMessage)
+	if gatePacket, ok := reqPacket.(*GatePacket); ok {
+		return connection.SendPacket(NewGatePacket(gatePacket.PlayerId(), PacketCommand(cmd), sendMessage).SetErrorCode(uint32(errorCode)))
+	}
+	return connection.SendPacket(NewProtoPacket(PacketCommand(cmd), sendMessage).SetErrorCode(uint32(errorCode)))
+}
@@ -21,12 +21,66 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 处罚类型
+type SanctionType int32
+
+const (
+	SanctionType_SanctionType_None        SanctionType = 0
+	SanctionType_SanctionType_Ban         SanctionType = 1 // 封号,禁止登录
+	SanctionType_SanctionType_Mute        SanctionType = 2 // 禁言
+	SanctionType_SanctionType_FreezeTrade SanctionType = 3 // 冻结交易
+)
+
+// Enum value maps for SanctionType.
+var (
+	SanctionType_name = map[int32]string{
+		0: "SanctionType_None",
+		1: "SanctionType_Ban",
+		2: "SanctionType_Mute",
+		3: "SanctionType_FreezeTrade",
+	}
+	SanctionType_value = map[string]int32{
+		"SanctionType_None":        0,
+		"SanctionType_Ban":         1,
+		"SanctionType_Mute":        2,
+		"SanctionType_FreezeTrade": 3,
+	}
+)
+
+func (x SanctionType) Enum() *SanctionType {
+	p := new(SanctionType)
+	*p = x
+	return p
+}
+
+func (x SanctionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SanctionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_account_proto_enumTypes[0].Descriptor()
+}
+
+func (SanctionType) Type() protoreflect.EnumType {
+	return &file_account_proto_enumTypes[0]
+}
+
+func (x SanctionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SanctionType.Descriptor instead.
+func (SanctionType) EnumDescriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{0}
+}
+
 // 账号
 type Account struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	XId           int64                  `protobuf:"varint,1,opt,name=_id,json=Id,proto3" json:"_id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
 	Password      string                 `protobuf:"bytes,3,opt,name=Password,proto3" json:"Password,omitempty"`
+	Provider      string                 `protobuf:"bytes,4,opt,name=Provider,proto3" json:"Provider,omitempty"` // 登录方式,空表示账号密码,其他登录方式的账号名是"登录方式:外部账号id"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,15 +136,157 @@ func (x *Account) GetPassword() string {
 	return ""
 }
 
+func (x *Account) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+// 处罚记录,每次处罚一条记录,用于审计
+type Sanction struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`             // 账号id
+	PlayerId        int64                  `protobuf:"varint,2,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`               // 角色id,0表示账号下的所有角色
+	Type            int32                  `protobuf:"varint,3,opt,name=Type,proto3" json:"Type,omitempty"`                       // 处罚类型 SanctionType
+	EndTimestamp    int64                  `protobuf:"varint,4,opt,name=EndTimestamp,proto3" json:"EndTimestamp,omitempty"`       // 截止时间(秒),0表示永久
+	Reason          string                 `protobuf:"bytes,5,opt,name=Reason,proto3" json:"Reason,omitempty"`                    // 处罚原因
+	Operator        string                 `protobuf:"bytes,6,opt,name=Operator,proto3" json:"Operator,omitempty"`                // 操作人
+	Timestamp       int64                  `protobuf:"varint,7,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`             // 处罚时间
+	RevokeTimestamp int64                  `protobuf:"varint,8,opt,name=RevokeTimestamp,proto3" json:"RevokeTimestamp,omitempty"` // 撤销时间,0表示未撤销
+	RevokeOperator  string                 `protobuf:"bytes,9,opt,name=RevokeOperator,proto3" json:"RevokeOperator,omitempty"`    // 撤销的操作人
+	RevokeReason    string                 `protobuf:"bytes,10,opt,name=RevokeReason,proto3" json:"RevokeReason,omitempty"`       // 撤销原因
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Sanction) Reset() {
+	*x = Sanction{}
+	mi := &file_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sanction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sanction) ProtoMessage() {}
+
+func (x *Sanction) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sanction.ProtoReflect.Descriptor instead.
+func (*Sanction) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Sanction) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *Sanction) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *Sanction) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Sanction) GetEndTimestamp() int64 {
+	if x != nil {
+		return x.EndTimestamp
+	}
+	return 0
+}
+
+func (x *Sanction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Sanction) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *Sanction) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Sanction) GetRevokeTimestamp() int64 {
+	if x != nil {
+		return x.RevokeTimestamp
+	}
+	return 0
+}
+
+func (x *Sanction) GetRevokeOperator() string {
+	if x != nil {
+		return x.RevokeOperator
+	}
+	return ""
+}
+
+func (x *Sanction) GetRevokeReason() string {
+	if x != nil {
+		return x.RevokeReason
+	}
+	return ""
+}
+
 var File_account_proto protoreflect.FileDescriptor
 
 const file_account_proto_rawDesc = "" +
 	"\n" +
-	"\raccount.proto\x12\agserver\"J\n" +
+	"\raccount.proto\x12\agserver\"f\n" +
 	"\aAccount\x12\x0f\n" +
 	"\x03_id\x18\x01 \x01(\x03R\x02Id\x12\x12\n" +
 	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x1a\n" +
-	"\bPassword\x18\x03 \x01(\tR\bPasswordB\x06Z\x04./pbb\x06proto3"
+	"\bPassword\x18\x03 \x01(\tR\bPassword\x12\x1a\n" +
+	"\bProvider\x18\x04 \x01(\tR\bProvider\"\xc4\x02\n" +
+	"\bSanction\x12\x1c\n" +
+	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\x1a\n" +
+	"\bPlayerId\x18\x02 \x01(\x03R\bPlayerId\x12\x12\n" +
+	"\x04Type\x18\x03 \x01(\x05R\x04Type\x12\"\n" +
+	"\fEndTimestamp\x18\x04 \x01(\x03R\fEndTimestamp\x12\x16\n" +
+	"\x06Reason\x18\x05 \x01(\tR\x06Reason\x12\x1a\n" +
+	"\bOperator\x18\x06 \x01(\tR\bOperator\x12\x1c\n" +
+	"\tTimestamp\x18\a \x01(\x03R\tTimestamp\x12(\n" +
+	"\x0fRevokeTimestamp\x18\b \x01(\x03R\x0fRevokeTimestamp\x12&\n" +
+	"\x0eRevokeOperator\x18\t \x01(\tR\x0eRevokeOperator\x12\"\n" +
+	"\fRevokeReason\x18\n" +
+	" \x01(\tR\fRevokeReason*p\n" +
+	"\fSanctionType\x12\x15\n" +
+	"\x11SanctionType_None\x10\x00\x12\x14\n" +
+	"\x10SanctionType_Ban\x10\x01\x12\x15\n" +
+	"\x11SanctionType_Mute\x10\x02\x12\x1c\n" +
+	"\x18SanctionType_FreezeTrade\x10\x03B\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_account_proto_rawDescOnce sync.Once
@@ -104,9 +300,12 @@ func file_account_proto_rawDescGZIP() []byte {
 	return file_account_proto_rawDescData
 }
 
-var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_account_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_account_proto_goTypes = []any{
-	(*Account)(nil), // 0: gserver.Account
+	(SanctionType)(0), // 0: gserver.SanctionType
+	(*Account)(nil),   // 1: gserver.Account
+	(*Sanction)(nil),  // 2: gserver.Sanction
 }
 var file_account_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -126,13 +325,14 @@ func file_account_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_account_proto_rawDesc), len(file_account_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_account_proto_goTypes,
 		DependencyIndexes: file_account_proto_depIdxs,
+		EnumInfos:         file_account_proto_enumTypes,
 		MessageInfos:      file_account_proto_msgTypes,
 	}.Build()
 	File_account_proto = out.File
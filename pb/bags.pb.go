@@ -394,6 +394,67 @@ func (x *BagsSync) GetEquip() map[int64]*Equip {
 	return nil
 }
 
+// GM发放物品,路由给在线玩家处理
+type GrantItems struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*AddElemArg          `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`     // 发放原因
+	Operator      string                 `protobuf:"bytes,3,opt,name=Operator,proto3" json:"Operator,omitempty"` // 操作人
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantItems) Reset() {
+	*x = GrantItems{}
+	mi := &file_bags_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantItems) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantItems) ProtoMessage() {}
+
+func (x *GrantItems) ProtoReflect() protoreflect.Message {
+	mi := &file_bags_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantItems.ProtoReflect.Descriptor instead.
+func (*GrantItems) Descriptor() ([]byte, []int) {
+	return file_bags_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GrantItems) GetItems() []*AddElemArg {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GrantItems) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GrantItems) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
 var File_bags_proto protoreflect.FileDescriptor
 
 const file_bags_proto_rawDesc = "" +
@@ -427,7 +488,12 @@ const file_bags_proto_rawDesc = "" +
 	"\n" +
 	"EquipEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\x03R\x03key\x12$\n" +
-	"\x05value\x18\x02 \x01(\v2\x0e.gserver.EquipR\x05value:\x028\x01*{\n" +
+	"\x05value\x18\x02 \x01(\v2\x0e.gserver.EquipR\x05value:\x028\x01\"k\n" +
+	"\n" +
+	"GrantItems\x12)\n" +
+	"\x05Items\x18\x01 \x03(\v2\x13.gserver.AddElemArgR\x05Items\x12\x16\n" +
+	"\x06Reason\x18\x02 \x01(\tR\x06Reason\x12\x1a\n" +
+	"\bOperator\x18\x03 \x01(\tR\bOperator*{\n" +
 	"\rContainerType\x12\x16\n" +
 	"\x12ContainerType_None\x10\x00\x12\x1b\n" +
 	"\x17ContainerType_CountItem\x10\x01\x12\x1c\n" +
@@ -453,7 +519,7 @@ func file_bags_proto_rawDescGZIP() []byte {
 }
 
 var file_bags_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_bags_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_bags_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_bags_proto_goTypes = []any{
 	(ContainerType)(0),          // 0: gserver.ContainerType
 	(ElemOpType)(0),             // 1: gserver.ElemOpType
@@ -462,28 +528,31 @@ var file_bags_proto_goTypes = []any{
 	(*UniqueId)(nil),            // 4: gserver.UniqueId
 	(*ElemNum)(nil),             // 5: gserver.ElemNum
 	(*BagsSync)(nil),            // 6: gserver.BagsSync
-	nil,                         // 7: gserver.BagsSync.CountItemEntry
-	nil,                         // 8: gserver.BagsSync.UniqueItemEntry
-	nil,                         // 9: gserver.BagsSync.EquipEntry
-	(*anypb.Any)(nil),           // 10: google.protobuf.Any
-	(*UniqueCountItem)(nil),     // 11: gserver.UniqueCountItem
-	(*Equip)(nil),               // 12: gserver.Equip
+	(*GrantItems)(nil),          // 7: gserver.GrantItems
+	nil,                         // 8: gserver.BagsSync.CountItemEntry
+	nil,                         // 9: gserver.BagsSync.UniqueItemEntry
+	nil,                         // 10: gserver.BagsSync.EquipEntry
+	(*anypb.Any)(nil),           // 11: google.protobuf.Any
+	(*AddElemArg)(nil),          // 12: gserver.AddElemArg
+	(*UniqueCountItem)(nil),     // 13: gserver.UniqueCountItem
+	(*Equip)(nil),               // 14: gserver.Equip
 }
 var file_bags_proto_depIdxs = []int32{
 	3,  // 0: gserver.ElemContainerUpdate.ElemOps:type_name -> gserver.ElemOp
 	0,  // 1: gserver.ElemOp.ContainerType:type_name -> gserver.ContainerType
 	1,  // 2: gserver.ElemOp.OpType:type_name -> gserver.ElemOpType
-	10, // 3: gserver.ElemOp.ElemData:type_name -> google.protobuf.Any
-	7,  // 4: gserver.BagsSync.CountItem:type_name -> gserver.BagsSync.CountItemEntry
-	8,  // 5: gserver.BagsSync.UniqueItem:type_name -> gserver.BagsSync.UniqueItemEntry
-	9,  // 6: gserver.BagsSync.Equip:type_name -> gserver.BagsSync.EquipEntry
-	11, // 7: gserver.BagsSync.UniqueItemEntry.value:type_name -> gserver.UniqueCountItem
-	12, // 8: gserver.BagsSync.EquipEntry.value:type_name -> gserver.Equip
-	9,  // [9:9] is the sub-list for method output_type
-	9,  // [9:9] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	11, // 3: gserver.ElemOp.ElemData:type_name -> google.protobuf.Any
+	8,  // 4: gserver.BagsSync.CountItem:type_name -> gserver.BagsSync.CountItemEntry
+	9,  // 5: gserver.BagsSync.UniqueItem:type_name -> gserver.BagsSync.UniqueItemEntry
+	10, // 6: gserver.BagsSync.Equip:type_name -> gserver.BagsSync.EquipEntry
+	12, // 7: gserver.GrantItems.Items:type_name -> gserver.AddElemArg
+	13, // 8: gserver.BagsSync.UniqueItemEntry.value:type_name -> gserver.UniqueCountItem
+	14, // 9: gserver.BagsSync.EquipEntry.value:type_name -> gserver.Equip
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_bags_proto_init() }
@@ -499,7 +568,7 @@ func file_bags_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bags_proto_rawDesc), len(file_bags_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   8,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
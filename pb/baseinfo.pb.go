@@ -110,6 +110,95 @@ func (x *BaseInfoSync) GetData() *BaseInfo {
 	return nil
 }
 
+// 改名请求,需要消耗物品
+type PlayerRenameReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"` // 新名字
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerRenameReq) Reset() {
+	*x = PlayerRenameReq{}
+	mi := &file_baseinfo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerRenameReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerRenameReq) ProtoMessage() {}
+
+func (x *PlayerRenameReq) ProtoReflect() protoreflect.Message {
+	mi := &file_baseinfo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerRenameReq.ProtoReflect.Descriptor instead.
+func (*PlayerRenameReq) Descriptor() ([]byte, []int) {
+	return file_baseinfo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlayerRenameReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PlayerRenameRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"` // 新名字
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerRenameRes) Reset() {
+	*x = PlayerRenameRes{}
+	mi := &file_baseinfo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerRenameRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerRenameRes) ProtoMessage() {}
+
+func (x *PlayerRenameRes) ProtoReflect() protoreflect.Message {
+	mi := &file_baseinfo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerRenameRes.ProtoReflect.Descriptor instead.
+func (*PlayerRenameRes) Descriptor() ([]byte, []int) {
+	return file_baseinfo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlayerRenameRes) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 var File_baseinfo_proto protoreflect.FileDescriptor
 
 const file_baseinfo_proto_rawDesc = "" +
@@ -118,7 +207,11 @@ const file_baseinfo_proto_rawDesc = "" +
 	"\x11PlayerEntryGameOk\x12 \n" +
 	"\vIsReconnect\x18\x01 \x01(\bR\vIsReconnect\"5\n" +
 	"\fBaseInfoSync\x12%\n" +
-	"\x04Data\x18\x01 \x01(\v2\x11.gserver.BaseInfoR\x04DataB\x06Z\x04./pbb\x06proto3"
+	"\x04Data\x18\x01 \x01(\v2\x11.gserver.BaseInfoR\x04Data\"%\n" +
+	"\x0fPlayerRenameReq\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\"%\n" +
+	"\x0fPlayerRenameRes\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04NameB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_baseinfo_proto_rawDescOnce sync.Once
@@ -132,14 +225,16 @@ func file_baseinfo_proto_rawDescGZIP() []byte {
 	return file_baseinfo_proto_rawDescData
 }
 
-var file_baseinfo_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_baseinfo_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_baseinfo_proto_goTypes = []any{
 	(*PlayerEntryGameOk)(nil), // 0: gserver.PlayerEntryGameOk
 	(*BaseInfoSync)(nil),      // 1: gserver.BaseInfoSync
-	(*BaseInfo)(nil),          // 2: gserver.BaseInfo
+	(*PlayerRenameReq)(nil),   // 2: gserver.PlayerRenameReq
+	(*PlayerRenameRes)(nil),   // 3: gserver.PlayerRenameRes
+	(*BaseInfo)(nil),          // 4: gserver.BaseInfo
 }
 var file_baseinfo_proto_depIdxs = []int32{
-	2, // 0: gserver.BaseInfoSync.Data:type_name -> gserver.BaseInfo
+	4, // 0: gserver.BaseInfoSync.Data:type_name -> gserver.BaseInfo
 	1, // [1:1] is the sub-list for method output_type
 	1, // [1:1] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
@@ -159,7 +254,7 @@ func file_baseinfo_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_baseinfo_proto_rawDesc), len(file_baseinfo_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -512,8 +512,9 @@ func (QuestCategory) EnumDescriptor() ([]byte, []int) {
 type ExchangeCategory int32
 
 const (
-	ExchangeCategory_ExchangeCategory_None ExchangeCategory = 0
-	ExchangeCategory_ExchangeCategory_Shop ExchangeCategory = 1 // 商店
+	ExchangeCategory_ExchangeCategory_None      ExchangeCategory = 0
+	ExchangeCategory_ExchangeCategory_Shop      ExchangeCategory = 1 // 商店
+	ExchangeCategory_ExchangeCategory_GuildShop ExchangeCategory = 2 // 公会商店,只能通过GuildShopBuyReq购买
 )
 
 // Enum value maps for ExchangeCategory.
@@ -521,10 +522,12 @@ var (
 	ExchangeCategory_name = map[int32]string{
 		0: "ExchangeCategory_None",
 		1: "ExchangeCategory_Shop",
+		2: "ExchangeCategory_GuildShop",
 	}
 	ExchangeCategory_value = map[string]int32{
-		"ExchangeCategory_None": 0,
-		"ExchangeCategory_Shop": 1,
+		"ExchangeCategory_None":      0,
+		"ExchangeCategory_Shop":      1,
+		"ExchangeCategory_GuildShop": 2,
 	}
 )
 
@@ -555,6 +558,53 @@ func (ExchangeCategory) EnumDescriptor() ([]byte, []int) {
 	return file_cfg_proto_rawDescGZIP(), []int{10}
 }
 
+// 排行榜分数的更新方式
+type RankScoreType int32
+
+const (
+	RankScoreType_RankScoreType_Inc RankScoreType = 0 // 累加进度值,如战斗胜利次数
+	RankScoreType_RankScoreType_Set RankScoreType = 1 // 以进度值覆盖分数,如等级
+)
+
+// Enum value maps for RankScoreType.
+var (
+	RankScoreType_name = map[int32]string{
+		0: "RankScoreType_Inc",
+		1: "RankScoreType_Set",
+	}
+	RankScoreType_value = map[string]int32{
+		"RankScoreType_Inc": 0,
+		"RankScoreType_Set": 1,
+	}
+)
+
+func (x RankScoreType) Enum() *RankScoreType {
+	p := new(RankScoreType)
+	*p = x
+	return p
+}
+
+func (x RankScoreType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RankScoreType) Descriptor() protoreflect.EnumDescriptor {
+	return file_cfg_proto_enumTypes[11].Descriptor()
+}
+
+func (RankScoreType) Type() protoreflect.EnumType {
+	return &file_cfg_proto_enumTypes[11]
+}
+
+func (x RankScoreType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RankScoreType.Descriptor instead.
+func (RankScoreType) EnumDescriptor() ([]byte, []int) {
+	return file_cfg_proto_rawDescGZIP(), []int{11}
+}
+
 // 物品数量
 type ItemNum struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1787,6 +1837,7 @@ type ExchangeCfg struct {
 	Properties         map[string]string      `protobuf:"bytes,8,rep,name=Properties,proto3" json:"Properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 扩展属性
 	Detail             string                 `protobuf:"bytes,9,opt,name=Detail,proto3" json:"Detail,omitempty"`
 	Icon               string                 `protobuf:"bytes,10,opt,name=Icon,proto3" json:"Icon,omitempty"`                             // 图标(客户端使用)
+	GuildConditions    []*ConditionCfg        `protobuf:"bytes,11,rep,name=GuildConditions,proto3" json:"GuildConditions,omitempty"`       // 公会条件(ConditionType_GuildPropertyCompare),由公会所在服务器检查
 	ConditionTemplates []*CfgArgOptions       `protobuf:"bytes,21,rep,name=ConditionTemplates,proto3" json:"ConditionTemplates,omitempty"` // 关联的配置模板id和参数,简化配置表用,业务代码不要调用
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
@@ -1892,6 +1943,13 @@ func (x *ExchangeCfg) GetIcon() string {
 	return ""
 }
 
+func (x *ExchangeCfg) GetGuildConditions() []*ConditionCfg {
+	if x != nil {
+		return x.GuildConditions
+	}
+	return nil
+}
+
 func (x *ExchangeCfg) GetConditionTemplates() []*CfgArgOptions {
 	if x != nil {
 		return x.ConditionTemplates
@@ -2186,6 +2244,261 @@ func (x *ShopCfg) GetProperties() map[string]string {
 	return nil
 }
 
+// 排行榜配置
+type RankCfg struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CfgId            int32                  `protobuf:"varint,1,opt,name=CfgId,proto3" json:"CfgId,omitempty"`                                                                                    // 配置id
+	Name             string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`                                                                                       // 排行榜名,也是redis key的一部分,不能重复
+	ScoreType        int32                  `protobuf:"varint,3,opt,name=ScoreType,proto3" json:"ScoreType,omitempty"`                                                                            // 分数更新方式(enum RankScoreType)
+	MaxCount         int32                  `protobuf:"varint,4,opt,name=MaxCount,proto3" json:"MaxCount,omitempty"`                                                                              // 排行榜保留的最大人数(0表示不限制)
+	Progress         *ProgressCfg           `protobuf:"bytes,5,opt,name=Progress,proto3" json:"Progress,omitempty"`                                                                               // 分数更新的事件匹配规则,和任务进度的规则一致
+	Properties       map[string]string      `protobuf:"bytes,6,rep,name=Properties,proto3" json:"Properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 扩展属性
+	Detail           string                 `protobuf:"bytes,7,opt,name=Detail,proto3" json:"Detail,omitempty"`
+	ProgressTemplate *CfgArg                `protobuf:"bytes,22,opt,name=ProgressTemplate,proto3" json:"ProgressTemplate,omitempty"` // 关联的配置模板id和参数,简化配置表用,业务代码不要调用
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RankCfg) Reset() {
+	*x = RankCfg{}
+	mi := &file_cfg_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankCfg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankCfg) ProtoMessage() {}
+
+func (x *RankCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_cfg_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankCfg.ProtoReflect.Descriptor instead.
+func (*RankCfg) Descriptor() ([]byte, []int) {
+	return file_cfg_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RankCfg) GetCfgId() int32 {
+	if x != nil {
+		return x.CfgId
+	}
+	return 0
+}
+
+func (x *RankCfg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RankCfg) GetScoreType() int32 {
+	if x != nil {
+		return x.ScoreType
+	}
+	return 0
+}
+
+func (x *RankCfg) GetMaxCount() int32 {
+	if x != nil {
+		return x.MaxCount
+	}
+	return 0
+}
+
+func (x *RankCfg) GetProgress() *ProgressCfg {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *RankCfg) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *RankCfg) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *RankCfg) GetProgressTemplate() *CfgArg {
+	if x != nil {
+		return x.ProgressTemplate
+	}
+	return nil
+}
+
+// 排行榜赛季奖励配置
+type RankRewardCfg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CfgId         int32                  `protobuf:"varint,1,opt,name=CfgId,proto3" json:"CfgId,omitempty"`         // 配置id
+	RankCfgId     int32                  `protobuf:"varint,2,opt,name=RankCfgId,proto3" json:"RankCfgId,omitempty"` // 排行榜配置id
+	MinRank       int32                  `protobuf:"varint,3,opt,name=MinRank,proto3" json:"MinRank,omitempty"`     // 名次区间的最小值(名次从1开始)
+	MaxRank       int32                  `protobuf:"varint,4,opt,name=MaxRank,proto3" json:"MaxRank,omitempty"`     // 名次区间的最大值
+	Rewards       []*AddElemArg          `protobuf:"bytes,5,rep,name=Rewards,proto3" json:"Rewards,omitempty"`      // 奖励,通过邮件发放
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankRewardCfg) Reset() {
+	*x = RankRewardCfg{}
+	mi := &file_cfg_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankRewardCfg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankRewardCfg) ProtoMessage() {}
+
+func (x *RankRewardCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_cfg_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankRewardCfg.ProtoReflect.Descriptor instead.
+func (*RankRewardCfg) Descriptor() ([]byte, []int) {
+	return file_cfg_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RankRewardCfg) GetCfgId() int32 {
+	if x != nil {
+		return x.CfgId
+	}
+	return 0
+}
+
+func (x *RankRewardCfg) GetRankCfgId() int32 {
+	if x != nil {
+		return x.RankCfgId
+	}
+	return 0
+}
+
+func (x *RankRewardCfg) GetMinRank() int32 {
+	if x != nil {
+		return x.MinRank
+	}
+	return 0
+}
+
+func (x *RankRewardCfg) GetMaxRank() int32 {
+	if x != nil {
+		return x.MaxRank
+	}
+	return 0
+}
+
+func (x *RankRewardCfg) GetRewards() []*AddElemArg {
+	if x != nil {
+		return x.Rewards
+	}
+	return nil
+}
+
+// 公会捐献配置
+type GuildDonateCfg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CfgId         int32                  `protobuf:"varint,1,opt,name=CfgId,proto3" json:"CfgId,omitempty"`
+	Consumes      []*ItemNum             `protobuf:"bytes,2,rep,name=Consumes,proto3" json:"Consumes,omitempty"`          // 捐献消耗的物品
+	Exp           int32                  `protobuf:"varint,3,opt,name=Exp,proto3" json:"Exp,omitempty"`                   // 增加的公会经验
+	Contribution  int32                  `protobuf:"varint,4,opt,name=Contribution,proto3" json:"Contribution,omitempty"` // 增加的成员贡献
+	Detail        string                 `protobuf:"bytes,5,opt,name=Detail,proto3" json:"Detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildDonateCfg) Reset() {
+	*x = GuildDonateCfg{}
+	mi := &file_cfg_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildDonateCfg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildDonateCfg) ProtoMessage() {}
+
+func (x *GuildDonateCfg) ProtoReflect() protoreflect.Message {
+	mi := &file_cfg_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildDonateCfg.ProtoReflect.Descriptor instead.
+func (*GuildDonateCfg) Descriptor() ([]byte, []int) {
+	return file_cfg_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GuildDonateCfg) GetCfgId() int32 {
+	if x != nil {
+		return x.CfgId
+	}
+	return 0
+}
+
+func (x *GuildDonateCfg) GetConsumes() []*ItemNum {
+	if x != nil {
+		return x.Consumes
+	}
+	return nil
+}
+
+func (x *GuildDonateCfg) GetExp() int32 {
+	if x != nil {
+		return x.Exp
+	}
+	return 0
+}
+
+func (x *GuildDonateCfg) GetContribution() int32 {
+	if x != nil {
+		return x.Contribution
+	}
+	return 0
+}
+
+func (x *GuildDonateCfg) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 var File_cfg_proto protoreflect.FileDescriptor
 
 const file_cfg_proto_rawDesc = "" +
@@ -2348,7 +2661,7 @@ const file_cfg_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
 	"\x0fPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8e\x04\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcf\x04\n" +
 	"\vExchangeCfg\x12\x14\n" +
 	"\x05CfgId\x18\x01 \x01(\x05R\x05CfgId\x125\n" +
 	"\n" +
@@ -2366,7 +2679,8 @@ const file_cfg_proto_rawDesc = "" +
 	"Properties\x12\x16\n" +
 	"\x06Detail\x18\t \x01(\tR\x06Detail\x12\x12\n" +
 	"\x04Icon\x18\n" +
-	" \x01(\tR\x04Icon\x12F\n" +
+	" \x01(\tR\x04Icon\x12?\n" +
+	"\x0fGuildConditions\x18\v \x03(\v2\x15.gserver.ConditionCfgR\x0fGuildConditions\x12F\n" +
 	"\x12ConditionTemplates\x18\x15 \x03(\v2\x16.gserver.CfgArgOptionsR\x12ConditionTemplates\x1a=\n" +
 	"\x0fPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
@@ -2406,7 +2720,33 @@ const file_cfg_proto_rawDesc = "" +
 	"Properties\x1a=\n" +
 	"\x0fPropertiesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*i\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf5\x02\n" +
+	"\aRankCfg\x12\x14\n" +
+	"\x05CfgId\x18\x01 \x01(\x05R\x05CfgId\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x1c\n" +
+	"\tScoreType\x18\x03 \x01(\x05R\tScoreType\x12\x1a\n" +
+	"\bMaxCount\x18\x04 \x01(\x05R\bMaxCount\x120\n" +
+	"\bProgress\x18\x05 \x01(\v2\x14.gserver.ProgressCfgR\bProgress\x12@\n" +
+	"\n" +
+	"Properties\x18\x06 \x03(\v2 .gserver.RankCfg.PropertiesEntryR\n" +
+	"Properties\x12\x16\n" +
+	"\x06Detail\x18\a \x01(\tR\x06Detail\x12;\n" +
+	"\x10ProgressTemplate\x18\x16 \x01(\v2\x0f.gserver.CfgArgR\x10ProgressTemplate\x1a=\n" +
+	"\x0fPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa6\x01\n" +
+	"\rRankRewardCfg\x12\x14\n" +
+	"\x05CfgId\x18\x01 \x01(\x05R\x05CfgId\x12\x1c\n" +
+	"\tRankCfgId\x18\x02 \x01(\x05R\tRankCfgId\x12\x18\n" +
+	"\aMinRank\x18\x03 \x01(\x05R\aMinRank\x12\x18\n" +
+	"\aMaxRank\x18\x04 \x01(\x05R\aMaxRank\x12-\n" +
+	"\aRewards\x18\x05 \x03(\v2\x13.gserver.AddElemArgR\aRewards\"\xa2\x01\n" +
+	"\x0eGuildDonateCfg\x12\x14\n" +
+	"\x05CfgId\x18\x01 \x01(\x05R\x05CfgId\x12,\n" +
+	"\bConsumes\x18\x02 \x03(\v2\x10.gserver.ItemNumR\bConsumes\x12\x10\n" +
+	"\x03Exp\x18\x03 \x01(\x05R\x03Exp\x12\"\n" +
+	"\fContribution\x18\x04 \x01(\x05R\fContribution\x12\x16\n" +
+	"\x06Detail\x18\x05 \x01(\tR\x06Detail*i\n" +
 	"\x05Color\x12\x0e\n" +
 	"\n" +
 	"Color_None\x10\x00\x12\r\n" +
@@ -2445,10 +2785,14 @@ const file_cfg_proto_rawDesc = "" +
 	"\x12QuestType_SubQuest\x10\x01\x12\x19\n" +
 	"\x15QuestType_Achievement\x10\x02*'\n" +
 	"\rQuestCategory\x12\x16\n" +
-	"\x12QuestCategory_None\x10\x00*H\n" +
+	"\x12QuestCategory_None\x10\x00*h\n" +
 	"\x10ExchangeCategory\x12\x19\n" +
 	"\x15ExchangeCategory_None\x10\x00\x12\x19\n" +
-	"\x15ExchangeCategory_Shop\x10\x01B\x06Z\x04./pbb\x06proto3"
+	"\x15ExchangeCategory_Shop\x10\x01\x12\x1e\n" +
+	"\x1aExchangeCategory_GuildShop\x10\x02*=\n" +
+	"\rRankScoreType\x12\x15\n" +
+	"\x11RankScoreType_Inc\x10\x00\x12\x15\n" +
+	"\x11RankScoreType_Set\x10\x01B\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_cfg_proto_rawDescOnce sync.Once
@@ -2462,8 +2806,8 @@ func file_cfg_proto_rawDescGZIP() []byte {
 	return file_cfg_proto_rawDescData
 }
 
-var file_cfg_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
-var file_cfg_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_cfg_proto_enumTypes = make([]protoimpl.EnumInfo, 12)
+var file_cfg_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_cfg_proto_goTypes = []any{
 	(Color)(0),                   // 0: gserver.Color
 	(RefreshType)(0),             // 1: gserver.RefreshType
@@ -2476,74 +2820,85 @@ var file_cfg_proto_goTypes = []any{
 	(QuestType)(0),               // 8: gserver.QuestType
 	(QuestCategory)(0),           // 9: gserver.QuestCategory
 	(ExchangeCategory)(0),        // 10: gserver.ExchangeCategory
-	(*ItemNum)(nil),              // 11: gserver.ItemNum
-	(*IdCount)(nil),              // 12: gserver.IdCount
-	(*ItemCfg)(nil),              // 13: gserver.ItemCfg
-	(*AddElemArg)(nil),           // 14: gserver.AddElemArg
-	(*DelElemArg)(nil),           // 15: gserver.DelElemArg
-	(*CfgArg)(nil),               // 16: gserver.CfgArg
-	(*CfgArgs)(nil),              // 17: gserver.CfgArgs
-	(*CfgArgOptions)(nil),        // 18: gserver.CfgArgOptions
-	(*TypeValue)(nil),            // 19: gserver.TypeValue
-	(*QuestCfg)(nil),             // 20: gserver.QuestCfg
-	(*ValueCompareCfg)(nil),      // 21: gserver.ValueCompareCfg
-	(*ConditionCfg)(nil),         // 22: gserver.ConditionCfg
-	(*ConditionTemplateCfg)(nil), // 23: gserver.ConditionTemplateCfg
-	(*ProgressCfg)(nil),          // 24: gserver.ProgressCfg
-	(*ProgressTemplateCfg)(nil),  // 25: gserver.ProgressTemplateCfg
-	(*ExchangeCfg)(nil),          // 26: gserver.ExchangeCfg
-	(*ActivityCfg)(nil),          // 27: gserver.ActivityCfg
-	(*LevelExp)(nil),             // 28: gserver.LevelExp
-	(*ShopCfg)(nil),              // 29: gserver.ShopCfg
-	nil,                          // 30: gserver.ItemCfg.PropertiesEntry
-	nil,                          // 31: gserver.AddElemArg.PropertiesEntry
-	nil,                          // 32: gserver.DelElemArg.PropertiesEntry
-	nil,                          // 33: gserver.QuestCfg.PropertiesEntry
-	nil,                          // 34: gserver.ConditionCfg.PropertiesEntry
-	nil,                          // 35: gserver.ConditionTemplateCfg.PropertiesEntry
-	nil,                          // 36: gserver.ProgressCfg.IntEventFieldsEntry
-	nil,                          // 37: gserver.ProgressCfg.StringEventFieldsEntry
-	nil,                          // 38: gserver.ProgressCfg.PropertiesEntry
-	nil,                          // 39: gserver.ProgressTemplateCfg.IntEventFieldsEntry
-	nil,                          // 40: gserver.ProgressTemplateCfg.StringEventFieldsEntry
-	nil,                          // 41: gserver.ProgressTemplateCfg.PropertiesEntry
-	nil,                          // 42: gserver.ExchangeCfg.PropertiesEntry
-	nil,                          // 43: gserver.ActivityCfg.PropertiesEntry
-	nil,                          // 44: gserver.ShopCfg.PropertiesEntry
+	(RankScoreType)(0),           // 11: gserver.RankScoreType
+	(*ItemNum)(nil),              // 12: gserver.ItemNum
+	(*IdCount)(nil),              // 13: gserver.IdCount
+	(*ItemCfg)(nil),              // 14: gserver.ItemCfg
+	(*AddElemArg)(nil),           // 15: gserver.AddElemArg
+	(*DelElemArg)(nil),           // 16: gserver.DelElemArg
+	(*CfgArg)(nil),               // 17: gserver.CfgArg
+	(*CfgArgs)(nil),              // 18: gserver.CfgArgs
+	(*CfgArgOptions)(nil),        // 19: gserver.CfgArgOptions
+	(*TypeValue)(nil),            // 20: gserver.TypeValue
+	(*QuestCfg)(nil),             // 21: gserver.QuestCfg
+	(*ValueCompareCfg)(nil),      // 22: gserver.ValueCompareCfg
+	(*ConditionCfg)(nil),         // 23: gserver.ConditionCfg
+	(*ConditionTemplateCfg)(nil), // 24: gserver.ConditionTemplateCfg
+	(*ProgressCfg)(nil),          // 25: gserver.ProgressCfg
+	(*ProgressTemplateCfg)(nil),  // 26: gserver.ProgressTemplateCfg
+	(*ExchangeCfg)(nil),          // 27: gserver.ExchangeCfg
+	(*ActivityCfg)(nil),          // 28: gserver.ActivityCfg
+	(*LevelExp)(nil),             // 29: gserver.LevelExp
+	(*ShopCfg)(nil),              // 30: gserver.ShopCfg
+	(*RankCfg)(nil),              // 31: gserver.RankCfg
+	(*RankRewardCfg)(nil),        // 32: gserver.RankRewardCfg
+	(*GuildDonateCfg)(nil),       // 33: gserver.GuildDonateCfg
+	nil,                          // 34: gserver.ItemCfg.PropertiesEntry
+	nil,                          // 35: gserver.AddElemArg.PropertiesEntry
+	nil,                          // 36: gserver.DelElemArg.PropertiesEntry
+	nil,                          // 37: gserver.QuestCfg.PropertiesEntry
+	nil,                          // 38: gserver.ConditionCfg.PropertiesEntry
+	nil,                          // 39: gserver.ConditionTemplateCfg.PropertiesEntry
+	nil,                          // 40: gserver.ProgressCfg.IntEventFieldsEntry
+	nil,                          // 41: gserver.ProgressCfg.StringEventFieldsEntry
+	nil,                          // 42: gserver.ProgressCfg.PropertiesEntry
+	nil,                          // 43: gserver.ProgressTemplateCfg.IntEventFieldsEntry
+	nil,                          // 44: gserver.ProgressTemplateCfg.StringEventFieldsEntry
+	nil,                          // 45: gserver.ProgressTemplateCfg.PropertiesEntry
+	nil,                          // 46: gserver.ExchangeCfg.PropertiesEntry
+	nil,                          // 47: gserver.ActivityCfg.PropertiesEntry
+	nil,                          // 48: gserver.ShopCfg.PropertiesEntry
+	nil,                          // 49: gserver.RankCfg.PropertiesEntry
 }
 var file_cfg_proto_depIdxs = []int32{
-	30, // 0: gserver.ItemCfg.Properties:type_name -> gserver.ItemCfg.PropertiesEntry
-	31, // 1: gserver.AddElemArg.Properties:type_name -> gserver.AddElemArg.PropertiesEntry
-	32, // 2: gserver.DelElemArg.Properties:type_name -> gserver.DelElemArg.PropertiesEntry
-	14, // 3: gserver.QuestCfg.Rewards:type_name -> gserver.AddElemArg
-	22, // 4: gserver.QuestCfg.Conditions:type_name -> gserver.ConditionCfg
-	24, // 5: gserver.QuestCfg.Progress:type_name -> gserver.ProgressCfg
-	33, // 6: gserver.QuestCfg.Properties:type_name -> gserver.QuestCfg.PropertiesEntry
-	11, // 7: gserver.QuestCfg.Collects:type_name -> gserver.ItemNum
-	18, // 8: gserver.QuestCfg.ConditionTemplates:type_name -> gserver.CfgArgOptions
-	16, // 9: gserver.QuestCfg.ProgressTemplate:type_name -> gserver.CfgArg
-	34, // 10: gserver.ConditionCfg.Properties:type_name -> gserver.ConditionCfg.PropertiesEntry
-	35, // 11: gserver.ConditionTemplateCfg.Properties:type_name -> gserver.ConditionTemplateCfg.PropertiesEntry
-	36, // 12: gserver.ProgressCfg.IntEventFields:type_name -> gserver.ProgressCfg.IntEventFieldsEntry
-	37, // 13: gserver.ProgressCfg.StringEventFields:type_name -> gserver.ProgressCfg.StringEventFieldsEntry
-	38, // 14: gserver.ProgressCfg.Properties:type_name -> gserver.ProgressCfg.PropertiesEntry
-	39, // 15: gserver.ProgressTemplateCfg.IntEventFields:type_name -> gserver.ProgressTemplateCfg.IntEventFieldsEntry
-	40, // 16: gserver.ProgressTemplateCfg.StringEventFields:type_name -> gserver.ProgressTemplateCfg.StringEventFieldsEntry
-	41, // 17: gserver.ProgressTemplateCfg.Properties:type_name -> gserver.ProgressTemplateCfg.PropertiesEntry
-	22, // 18: gserver.ExchangeCfg.Conditions:type_name -> gserver.ConditionCfg
-	11, // 19: gserver.ExchangeCfg.Consumes:type_name -> gserver.ItemNum
-	14, // 20: gserver.ExchangeCfg.Rewards:type_name -> gserver.AddElemArg
-	42, // 21: gserver.ExchangeCfg.Properties:type_name -> gserver.ExchangeCfg.PropertiesEntry
-	18, // 22: gserver.ExchangeCfg.ConditionTemplates:type_name -> gserver.CfgArgOptions
-	43, // 23: gserver.ActivityCfg.Properties:type_name -> gserver.ActivityCfg.PropertiesEntry
-	44, // 24: gserver.ShopCfg.Properties:type_name -> gserver.ShopCfg.PropertiesEntry
-	21, // 25: gserver.ProgressCfg.IntEventFieldsEntry.value:type_name -> gserver.ValueCompareCfg
-	21, // 26: gserver.ProgressTemplateCfg.IntEventFieldsEntry.value:type_name -> gserver.ValueCompareCfg
-	27, // [27:27] is the sub-list for method output_type
-	27, // [27:27] is the sub-list for method input_type
-	27, // [27:27] is the sub-list for extension type_name
-	27, // [27:27] is the sub-list for extension extendee
-	0,  // [0:27] is the sub-list for field type_name
+	34, // 0: gserver.ItemCfg.Properties:type_name -> gserver.ItemCfg.PropertiesEntry
+	35, // 1: gserver.AddElemArg.Properties:type_name -> gserver.AddElemArg.PropertiesEntry
+	36, // 2: gserver.DelElemArg.Properties:type_name -> gserver.DelElemArg.PropertiesEntry
+	15, // 3: gserver.QuestCfg.Rewards:type_name -> gserver.AddElemArg
+	23, // 4: gserver.QuestCfg.Conditions:type_name -> gserver.ConditionCfg
+	25, // 5: gserver.QuestCfg.Progress:type_name -> gserver.ProgressCfg
+	37, // 6: gserver.QuestCfg.Properties:type_name -> gserver.QuestCfg.PropertiesEntry
+	12, // 7: gserver.QuestCfg.Collects:type_name -> gserver.ItemNum
+	19, // 8: gserver.QuestCfg.ConditionTemplates:type_name -> gserver.CfgArgOptions
+	17, // 9: gserver.QuestCfg.ProgressTemplate:type_name -> gserver.CfgArg
+	38, // 10: gserver.ConditionCfg.Properties:type_name -> gserver.ConditionCfg.PropertiesEntry
+	39, // 11: gserver.ConditionTemplateCfg.Properties:type_name -> gserver.ConditionTemplateCfg.PropertiesEntry
+	40, // 12: gserver.ProgressCfg.IntEventFields:type_name -> gserver.ProgressCfg.IntEventFieldsEntry
+	41, // 13: gserver.ProgressCfg.StringEventFields:type_name -> gserver.ProgressCfg.StringEventFieldsEntry
+	42, // 14: gserver.ProgressCfg.Properties:type_name -> gserver.ProgressCfg.PropertiesEntry
+	43, // 15: gserver.ProgressTemplateCfg.IntEventFields:type_name -> gserver.ProgressTemplateCfg.IntEventFieldsEntry
+	44, // 16: gserver.ProgressTemplateCfg.StringEventFields:type_name -> gserver.ProgressTemplateCfg.StringEventFieldsEntry
+	45, // 17: gserver.ProgressTemplateCfg.Properties:type_name -> gserver.ProgressTemplateCfg.PropertiesEntry
+	23, // 18: gserver.ExchangeCfg.Conditions:type_name -> gserver.ConditionCfg
+	12, // 19: gserver.ExchangeCfg.Consumes:type_name -> gserver.ItemNum
+	15, // 20: gserver.ExchangeCfg.Rewards:type_name -> gserver.AddElemArg
+	46, // 21: gserver.ExchangeCfg.Properties:type_name -> gserver.ExchangeCfg.PropertiesEntry
+	23, // 22: gserver.ExchangeCfg.GuildConditions:type_name -> gserver.ConditionCfg
+	19, // 23: gserver.ExchangeCfg.ConditionTemplates:type_name -> gserver.CfgArgOptions
+	47, // 24: gserver.ActivityCfg.Properties:type_name -> gserver.ActivityCfg.PropertiesEntry
+	48, // 25: gserver.ShopCfg.Properties:type_name -> gserver.ShopCfg.PropertiesEntry
+	25, // 26: gserver.RankCfg.Progress:type_name -> gserver.ProgressCfg
+	49, // 27: gserver.RankCfg.Properties:type_name -> gserver.RankCfg.PropertiesEntry
+	17, // 28: gserver.RankCfg.ProgressTemplate:type_name -> gserver.CfgArg
+	15, // 29: gserver.RankRewardCfg.Rewards:type_name -> gserver.AddElemArg
+	12, // 30: gserver.GuildDonateCfg.Consumes:type_name -> gserver.ItemNum
+	22, // 31: gserver.ProgressCfg.IntEventFieldsEntry.value:type_name -> gserver.ValueCompareCfg
+	22, // 32: gserver.ProgressTemplateCfg.IntEventFieldsEntry.value:type_name -> gserver.ValueCompareCfg
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_cfg_proto_init() }
@@ -2556,8 +2911,8 @@ func file_cfg_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cfg_proto_rawDesc), len(file_cfg_proto_rawDesc)),
-			NumEnums:      11,
-			NumMessages:   34,
+			NumEnums:      12,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
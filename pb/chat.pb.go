@@ -0,0 +1,488 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v4.25.9
+// source: chat.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 聊天频道
+type ChatChannel int32
+
+const (
+	ChatChannel_ChatChannel_None    ChatChannel = 0
+	ChatChannel_ChatChannel_Private ChatChannel = 1 // 私聊
+	ChatChannel_ChatChannel_Guild   ChatChannel = 2 // 公会
+	ChatChannel_ChatChannel_World   ChatChannel = 3 // 世界
+)
+
+// Enum value maps for ChatChannel.
+var (
+	ChatChannel_name = map[int32]string{
+		0: "ChatChannel_None",
+		1: "ChatChannel_Private",
+		2: "ChatChannel_Guild",
+		3: "ChatChannel_World",
+	}
+	ChatChannel_value = map[string]int32{
+		"ChatChannel_None":    0,
+		"ChatChannel_Private": 1,
+		"ChatChannel_Guild":   2,
+		"ChatChannel_World":   3,
+	}
+)
+
+func (x ChatChannel) Enum() *ChatChannel {
+	p := new(ChatChannel)
+	*p = x
+	return p
+}
+
+func (x ChatChannel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatChannel) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_proto_enumTypes[0].Descriptor()
+}
+
+func (ChatChannel) Type() protoreflect.EnumType {
+	return &file_chat_proto_enumTypes[0]
+}
+
+func (x ChatChannel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatChannel.Descriptor instead.
+func (ChatChannel) EnumDescriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{0}
+}
+
+// 聊天消息
+type ChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       ChatChannel            `protobuf:"varint,1,opt,name=Channel,proto3,enum=gserver.ChatChannel" json:"Channel,omitempty"` // 频道
+	FromPlayerId  int64                  `protobuf:"varint,2,opt,name=FromPlayerId,proto3" json:"FromPlayerId,omitempty"`                // 发送者id
+	FromName      string                 `protobuf:"bytes,3,opt,name=FromName,proto3" json:"FromName,omitempty"`                         // 发送者名字
+	ToPlayerId    int64                  `protobuf:"varint,4,opt,name=ToPlayerId,proto3" json:"ToPlayerId,omitempty"`                    // 私聊的接收者id
+	Content       string                 `protobuf:"bytes,5,opt,name=Content,proto3" json:"Content,omitempty"`                           // 内容
+	Timestamp     int32                  `protobuf:"varint,6,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`                      // 发送时间戳(秒)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_chat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ChatMessage) GetChannel() ChatChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ChatChannel_ChatChannel_None
+}
+
+func (x *ChatMessage) GetFromPlayerId() int64 {
+	if x != nil {
+		return x.FromPlayerId
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetFromName() string {
+	if x != nil {
+		return x.FromName
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetToPlayerId() int64 {
+	if x != nil {
+		return x.ToPlayerId
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetTimestamp() int32 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// 发送聊天req
+type ChatReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       ChatChannel            `protobuf:"varint,1,opt,name=Channel,proto3,enum=gserver.ChatChannel" json:"Channel,omitempty"` // 频道
+	ToPlayerId    int64                  `protobuf:"varint,2,opt,name=ToPlayerId,proto3" json:"ToPlayerId,omitempty"`                    // 私聊的接收者id
+	Content       string                 `protobuf:"bytes,3,opt,name=Content,proto3" json:"Content,omitempty"`                           // 内容
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatReq) Reset() {
+	*x = ChatReq{}
+	mi := &file_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatReq) ProtoMessage() {}
+
+func (x *ChatReq) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatReq.ProtoReflect.Descriptor instead.
+func (*ChatReq) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChatReq) GetChannel() ChatChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ChatChannel_ChatChannel_None
+}
+
+func (x *ChatReq) GetToPlayerId() int64 {
+	if x != nil {
+		return x.ToPlayerId
+	}
+	return 0
+}
+
+func (x *ChatReq) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// 发送聊天res
+type ChatRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *ChatMessage           `protobuf:"bytes,1,opt,name=Message,proto3" json:"Message,omitempty"` // 实际发出的消息(经过敏感词过滤)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatRes) Reset() {
+	*x = ChatRes{}
+	mi := &file_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRes) ProtoMessage() {}
+
+func (x *ChatRes) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRes.ProtoReflect.Descriptor instead.
+func (*ChatRes) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChatRes) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// 公会聊天,由玩家所在服务器路由到公会所在服务器
+type GuildChatReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *ChatMessage           `protobuf:"bytes,1,opt,name=Message,proto3" json:"Message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildChatReq) Reset() {
+	*x = GuildChatReq{}
+	mi := &file_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildChatReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildChatReq) ProtoMessage() {}
+
+func (x *GuildChatReq) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildChatReq.ProtoReflect.Descriptor instead.
+func (*GuildChatReq) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GuildChatReq) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// 公会聊天res
+type GuildChatRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildChatRes) Reset() {
+	*x = GuildChatRes{}
+	mi := &file_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildChatRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildChatRes) ProtoMessage() {}
+
+func (x *GuildChatRes) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildChatRes.ProtoReflect.Descriptor instead.
+func (*GuildChatRes) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{4}
+}
+
+// 世界聊天广播,由game服发给所有连接的网关,网关再广播给自己的所有客户端
+type WorldChatBroadcast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *ChatMessage           `protobuf:"bytes,1,opt,name=Message,proto3" json:"Message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorldChatBroadcast) Reset() {
+	*x = WorldChatBroadcast{}
+	mi := &file_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorldChatBroadcast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorldChatBroadcast) ProtoMessage() {}
+
+func (x *WorldChatBroadcast) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorldChatBroadcast.ProtoReflect.Descriptor instead.
+func (*WorldChatBroadcast) Descriptor() ([]byte, []int) {
+	return file_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WorldChatBroadcast) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+var File_chat_proto protoreflect.FileDescriptor
+
+const file_chat_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"chat.proto\x12\agserver\"\xd5\x01\n" +
+	"\vChatMessage\x12.\n" +
+	"\aChannel\x18\x01 \x01(\x0e2\x14.gserver.ChatChannelR\aChannel\x12\"\n" +
+	"\fFromPlayerId\x18\x02 \x01(\x03R\fFromPlayerId\x12\x1a\n" +
+	"\bFromName\x18\x03 \x01(\tR\bFromName\x12\x1e\n" +
+	"\n" +
+	"ToPlayerId\x18\x04 \x01(\x03R\n" +
+	"ToPlayerId\x12\x18\n" +
+	"\aContent\x18\x05 \x01(\tR\aContent\x12\x1c\n" +
+	"\tTimestamp\x18\x06 \x01(\x05R\tTimestamp\"s\n" +
+	"\aChatReq\x12.\n" +
+	"\aChannel\x18\x01 \x01(\x0e2\x14.gserver.ChatChannelR\aChannel\x12\x1e\n" +
+	"\n" +
+	"ToPlayerId\x18\x02 \x01(\x03R\n" +
+	"ToPlayerId\x12\x18\n" +
+	"\aContent\x18\x03 \x01(\tR\aContent\"9\n" +
+	"\aChatRes\x12.\n" +
+	"\aMessage\x18\x01 \x01(\v2\x14.gserver.ChatMessageR\aMessage\">\n" +
+	"\fGuildChatReq\x12.\n" +
+	"\aMessage\x18\x01 \x01(\v2\x14.gserver.ChatMessageR\aMessage\"\x0e\n" +
+	"\fGuildChatRes\"D\n" +
+	"\x12WorldChatBroadcast\x12.\n" +
+	"\aMessage\x18\x01 \x01(\v2\x14.gserver.ChatMessageR\aMessage*j\n" +
+	"\vChatChannel\x12\x14\n" +
+	"\x10ChatChannel_None\x10\x00\x12\x17\n" +
+	"\x13ChatChannel_Private\x10\x01\x12\x15\n" +
+	"\x11ChatChannel_Guild\x10\x02\x12\x15\n" +
+	"\x11ChatChannel_World\x10\x03B\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_chat_proto_rawDescOnce sync.Once
+	file_chat_proto_rawDescData []byte
+)
+
+func file_chat_proto_rawDescGZIP() []byte {
+	file_chat_proto_rawDescOnce.Do(func() {
+		file_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chat_proto_rawDesc), len(file_chat_proto_rawDesc)))
+	})
+	return file_chat_proto_rawDescData
+}
+
+var file_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_chat_proto_goTypes = []any{
+	(ChatChannel)(0),           // 0: gserver.ChatChannel
+	(*ChatMessage)(nil),        // 1: gserver.ChatMessage
+	(*ChatReq)(nil),            // 2: gserver.ChatReq
+	(*ChatRes)(nil),            // 3: gserver.ChatRes
+	(*GuildChatReq)(nil),       // 4: gserver.GuildChatReq
+	(*GuildChatRes)(nil),       // 5: gserver.GuildChatRes
+	(*WorldChatBroadcast)(nil), // 6: gserver.WorldChatBroadcast
+}
+var file_chat_proto_depIdxs = []int32{
+	0, // 0: gserver.ChatMessage.Channel:type_name -> gserver.ChatChannel
+	0, // 1: gserver.ChatReq.Channel:type_name -> gserver.ChatChannel
+	1, // 2: gserver.ChatRes.Message:type_name -> gserver.ChatMessage
+	1, // 3: gserver.GuildChatReq.Message:type_name -> gserver.ChatMessage
+	1, // 4: gserver.WorldChatBroadcast.Message:type_name -> gserver.ChatMessage
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_chat_proto_init() }
+func file_chat_proto_init() {
+	if File_chat_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chat_proto_rawDesc), len(file_chat_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_chat_proto_goTypes,
+		DependencyIndexes: file_chat_proto_depIdxs,
+		EnumInfos:         file_chat_proto_enumTypes,
+		MessageInfos:      file_chat_proto_msgTypes,
+	}.Build()
+	File_chat_proto = out.File
+	file_chat_proto_goTypes = nil
+	file_chat_proto_depIdxs = nil
+}
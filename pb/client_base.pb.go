@@ -249,6 +249,51 @@ func (x *GateRouteClientPacketError) GetResultStr() string {
 	return ""
 }
 
+// 游戏服正在排空,客户端需要重新登录(登录服会分配其他游戏服)
+type ServerDrainingNotify struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      int32                  `protobuf:"varint,1,opt,name=ServerId,proto3" json:"ServerId,omitempty"` // 排空中的游戏服id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerDrainingNotify) Reset() {
+	*x = ServerDrainingNotify{}
+	mi := &file_client_base_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerDrainingNotify) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerDrainingNotify) ProtoMessage() {}
+
+func (x *ServerDrainingNotify) ProtoReflect() protoreflect.Message {
+	mi := &file_client_base_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerDrainingNotify.ProtoReflect.Descriptor instead.
+func (*ServerDrainingNotify) Descriptor() ([]byte, []int) {
+	return file_client_base_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ServerDrainingNotify) GetServerId() int32 {
+	if x != nil {
+		return x.ServerId
+	}
+	return 0
+}
+
 var File_client_base_proto protoreflect.FileDescriptor
 
 const file_client_base_proto_rawDesc = "" +
@@ -267,7 +312,9 @@ const file_client_base_proto_rawDesc = "" +
 	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x18\n" +
 	"\aCommand\x18\x02 \x01(\x05R\aCommand\x12\x1a\n" +
 	"\bResultId\x18\x03 \x01(\x05R\bResultId\x12\x1c\n" +
-	"\tResultStr\x18\x04 \x01(\tR\tResultStrB\x06Z\x04./pbb\x06proto3"
+	"\tResultStr\x18\x04 \x01(\tR\tResultStr\"2\n" +
+	"\x14ServerDrainingNotify\x12\x1a\n" +
+	"\bServerId\x18\x01 \x01(\x05R\bServerIdB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_client_base_proto_rawDescOnce sync.Once
@@ -281,12 +328,13 @@ func file_client_base_proto_rawDescGZIP() []byte {
 	return file_client_base_proto_rawDescData
 }
 
-var file_client_base_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_client_base_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_client_base_proto_goTypes = []any{
 	(*HeartBeatReq)(nil),               // 0: gserver.HeartBeatReq
 	(*HeartBeatRes)(nil),               // 1: gserver.HeartBeatRes
 	(*ErrorRes)(nil),                   // 2: gserver.ErrorRes
 	(*GateRouteClientPacketError)(nil), // 3: gserver.GateRouteClientPacketError
+	(*ServerDrainingNotify)(nil),       // 4: gserver.ServerDrainingNotify
 }
 var file_client_base_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -307,7 +355,7 @@ func file_client_base_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_client_base_proto_rawDesc), len(file_client_base_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
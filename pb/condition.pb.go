@@ -28,6 +28,7 @@ const (
 	ConditionType_ConditionType_None                    ConditionType = 0 // 解决"The first enum value must be zero in proto3."的报错
 	ConditionType_ConditionType_PlayerPropertyCompare   ConditionType = 1 // 玩家属性值比较
 	ConditionType_ConditionType_ActivityPropertyCompare ConditionType = 2 // 活动属性值比较
+	ConditionType_ConditionType_GuildPropertyCompare    ConditionType = 3 // 公会属性值比较(公会等级,成员贡献等),由公会所在服务器检查
 )
 
 // Enum value maps for ConditionType.
@@ -36,11 +37,13 @@ var (
 		0: "ConditionType_None",
 		1: "ConditionType_PlayerPropertyCompare",
 		2: "ConditionType_ActivityPropertyCompare",
+		3: "ConditionType_GuildPropertyCompare",
 	}
 	ConditionType_value = map[string]int32{
 		"ConditionType_None":                    0,
 		"ConditionType_PlayerPropertyCompare":   1,
 		"ConditionType_ActivityPropertyCompare": 2,
+		"ConditionType_GuildPropertyCompare":    3,
 	}
 )
 
@@ -75,11 +78,12 @@ var File_condition_proto protoreflect.FileDescriptor
 
 const file_condition_proto_rawDesc = "" +
 	"\n" +
-	"\x0fcondition.proto\x12\agserver*{\n" +
+	"\x0fcondition.proto\x12\agserver*\xa3\x01\n" +
 	"\rConditionType\x12\x16\n" +
 	"\x12ConditionType_None\x10\x00\x12'\n" +
 	"#ConditionType_PlayerPropertyCompare\x10\x01\x12)\n" +
-	"%ConditionType_ActivityPropertyCompare\x10\x02B\x06Z\x04./pbb\x06proto3"
+	"%ConditionType_ActivityPropertyCompare\x10\x02\x12&\n" +
+	"\"ConditionType_GuildPropertyCompare\x10\x03B\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_condition_proto_rawDescOnce sync.Once
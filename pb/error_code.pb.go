@@ -40,6 +40,17 @@ const (
 	ErrorCode_ErrorCode_RouteClientPacketError ErrorCode = 23 // 网关转发消息失败(客户端需要退回登录界面)
 	ErrorCode_ErrorCode_RouteClientPacketLoss  ErrorCode = 24 // 网关转发消息丢失,可能目标服务器暂时繁忙
 	ErrorCode_ErrorCode_PushClientPacketLoss   ErrorCode = 25 // 游戏服收到客户端消息丢失,玩家chan已满
+	ErrorCode_ErrorCode_ServerDraining         ErrorCode = 26 // 游戏服正在排空,不再接收新玩家(客户端需要重新登录)
+	ErrorCode_ErrorCode_InLoginQueue           ErrorCode = 27 // 游戏服已满,正在排队(客户端等待LoginQueueUpdate和排到后的LoginRes)
+	ErrorCode_ErrorCode_AccountLocked          ErrorCode = 28 // 密码错误次数过多,账号暂时锁定
+	ErrorCode_ErrorCode_RefreshTokenError      ErrorCode = 29 // RefreshToken无效或已过期(客户端需要输入密码登录)
+	ErrorCode_ErrorCode_LoginProviderError     ErrorCode = 30 // 不支持的登录方式或者登录凭证无效
+	ErrorCode_ErrorCode_AccountNameInvalid     ErrorCode = 31 // 账号名不合法
+	ErrorCode_ErrorCode_PlayerCountLimit       ErrorCode = 32 // 该区服的角色数已达上限
+	ErrorCode_ErrorCode_PlayerDeleted          ErrorCode = 33 // 角色已删除(恢复期内可以恢复)
+	ErrorCode_ErrorCode_PlayerOnline           ErrorCode = 34 // 角色在线,不能删除
+	ErrorCode_ErrorCode_PlayerNameInvalid      ErrorCode = 35 // 角色名不合法
+	ErrorCode_ErrorCode_AccountBanned          ErrorCode = 36 // 账号或角色被封禁
 )
 
 // Enum value maps for ErrorCode.
@@ -61,6 +72,17 @@ var (
 		23: "ErrorCode_RouteClientPacketError",
 		24: "ErrorCode_RouteClientPacketLoss",
 		25: "ErrorCode_PushClientPacketLoss",
+		26: "ErrorCode_ServerDraining",
+		27: "ErrorCode_InLoginQueue",
+		28: "ErrorCode_AccountLocked",
+		29: "ErrorCode_RefreshTokenError",
+		30: "ErrorCode_LoginProviderError",
+		31: "ErrorCode_AccountNameInvalid",
+		32: "ErrorCode_PlayerCountLimit",
+		33: "ErrorCode_PlayerDeleted",
+		34: "ErrorCode_PlayerOnline",
+		35: "ErrorCode_PlayerNameInvalid",
+		36: "ErrorCode_AccountBanned",
 	}
 	ErrorCode_value = map[string]int32{
 		"ErrorCode_OK":                     0,
@@ -79,6 +101,17 @@ var (
 		"ErrorCode_RouteClientPacketError": 23,
 		"ErrorCode_RouteClientPacketLoss":  24,
 		"ErrorCode_PushClientPacketLoss":   25,
+		"ErrorCode_ServerDraining":         26,
+		"ErrorCode_InLoginQueue":           27,
+		"ErrorCode_AccountLocked":          28,
+		"ErrorCode_RefreshTokenError":      29,
+		"ErrorCode_LoginProviderError":     30,
+		"ErrorCode_AccountNameInvalid":     31,
+		"ErrorCode_PlayerCountLimit":       32,
+		"ErrorCode_PlayerDeleted":          33,
+		"ErrorCode_PlayerOnline":           34,
+		"ErrorCode_PlayerNameInvalid":      35,
+		"ErrorCode_AccountBanned":          36,
 	}
 )
 
@@ -113,7 +146,7 @@ var File_error_code_proto protoreflect.FileDescriptor
 
 const file_error_code_proto_rawDesc = "" +
 	"\n" +
-	"\x10error_code.proto\x12\agserver*\xd8\x03\n" +
+	"\x10error_code.proto\x12\agserver*\xab\x06\n" +
 	"\tErrorCode\x12\x10\n" +
 	"\fErrorCode_OK\x10\x00\x12\x14\n" +
 	"\x10ErrorCode_NotReg\x10\v\x12\x1b\n" +
@@ -130,7 +163,18 @@ const file_error_code_proto_rawDesc = "" +
 	"\x1eErrorCode_ReconnectNeedRelogin\x10\x16\x12$\n" +
 	" ErrorCode_RouteClientPacketError\x10\x17\x12#\n" +
 	"\x1fErrorCode_RouteClientPacketLoss\x10\x18\x12\"\n" +
-	"\x1eErrorCode_PushClientPacketLoss\x10\x19B\x06Z\x04./pbb\x06proto3"
+	"\x1eErrorCode_PushClientPacketLoss\x10\x19\x12\x1c\n" +
+	"\x18ErrorCode_ServerDraining\x10\x1a\x12\x1a\n" +
+	"\x16ErrorCode_InLoginQueue\x10\x1b\x12\x1b\n" +
+	"\x17ErrorCode_AccountLocked\x10\x1c\x12\x1f\n" +
+	"\x1bErrorCode_RefreshTokenError\x10\x1d\x12 \n" +
+	"\x1cErrorCode_LoginProviderError\x10\x1e\x12 \n" +
+	"\x1cErrorCode_AccountNameInvalid\x10\x1f\x12\x1e\n" +
+	"\x1aErrorCode_PlayerCountLimit\x10 \x12\x1b\n" +
+	"\x17ErrorCode_PlayerDeleted\x10!\x12\x1a\n" +
+	"\x16ErrorCode_PlayerOnline\x10\"\x12\x1f\n" +
+	"\x1bErrorCode_PlayerNameInvalid\x10#\x12\x1b\n" +
+	"\x17ErrorCode_AccountBanned\x10$B\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_error_code_proto_rawDescOnce sync.Once
@@ -0,0 +1,909 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v4.25.9
+// source: friend.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 好友模块数据
+type FriendsSaveData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Friends       map[int64][]byte       `protobuf:"bytes,1,rep,name=Friends,proto3" json:"Friends,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`   // map<int64,*FriendData>
+	Requests      map[int64][]byte       `protobuf:"bytes,2,rep,name=Requests,proto3" json:"Requests,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // map<int64,*FriendRequest>
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendsSaveData) Reset() {
+	*x = FriendsSaveData{}
+	mi := &file_friend_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendsSaveData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendsSaveData) ProtoMessage() {}
+
+func (x *FriendsSaveData) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendsSaveData.ProtoReflect.Descriptor instead.
+func (*FriendsSaveData) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FriendsSaveData) GetFriends() map[int64][]byte {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+func (x *FriendsSaveData) GetRequests() map[int64][]byte {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// 好友数据
+type FriendData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`   // 好友的玩家id
+	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`            // 好友的玩家名
+	Timestamp     int32                  `protobuf:"varint,3,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"` // 成为好友的时间戳(秒)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendData) Reset() {
+	*x = FriendData{}
+	mi := &file_friend_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendData) ProtoMessage() {}
+
+func (x *FriendData) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendData.ProtoReflect.Descriptor instead.
+func (*FriendData) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FriendData) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *FriendData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FriendData) GetTimestamp() int32 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// 好友申请
+type FriendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`   // 申请者的玩家id
+	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`            // 申请者的玩家名
+	Timestamp     int32                  `protobuf:"varint,3,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"` // 申请时间戳(秒)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRequest) Reset() {
+	*x = FriendRequest{}
+	mi := &file_friend_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequest) ProtoMessage() {}
+
+func (x *FriendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequest.ProtoReflect.Descriptor instead.
+func (*FriendRequest) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FriendRequest) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *FriendRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FriendRequest) GetTimestamp() int32 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// 同步好友数据给客户端
+type FriendsSync struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Friends       map[int64]*FriendData    `protobuf:"bytes,1,rep,name=Friends,proto3" json:"Friends,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Requests      map[int64]*FriendRequest `protobuf:"bytes,2,rep,name=Requests,proto3" json:"Requests,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendsSync) Reset() {
+	*x = FriendsSync{}
+	mi := &file_friend_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendsSync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendsSync) ProtoMessage() {}
+
+func (x *FriendsSync) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendsSync.ProtoReflect.Descriptor instead.
+func (*FriendsSync) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FriendsSync) GetFriends() map[int64]*FriendData {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+func (x *FriendsSync) GetRequests() map[int64]*FriendRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// 申请加好友req
+type FriendAddReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"` // 目标玩家id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendAddReq) Reset() {
+	*x = FriendAddReq{}
+	mi := &file_friend_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendAddReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendAddReq) ProtoMessage() {}
+
+func (x *FriendAddReq) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendAddReq.ProtoReflect.Descriptor instead.
+func (*FriendAddReq) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FriendAddReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 申请加好友res
+type FriendAddRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendAddRes) Reset() {
+	*x = FriendAddRes{}
+	mi := &file_friend_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendAddRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendAddRes) ProtoMessage() {}
+
+func (x *FriendAddRes) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendAddRes.ProtoReflect.Descriptor instead.
+func (*FriendAddRes) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FriendAddRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 收到好友申请(服务器之间路由给目标玩家,目标玩家处理后再转发给客户端)
+type FriendRequestAdd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *FriendRequest         `protobuf:"bytes,1,opt,name=Request,proto3" json:"Request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRequestAdd) Reset() {
+	*x = FriendRequestAdd{}
+	mi := &file_friend_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRequestAdd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequestAdd) ProtoMessage() {}
+
+func (x *FriendRequestAdd) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequestAdd.ProtoReflect.Descriptor instead.
+func (*FriendRequestAdd) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FriendRequestAdd) GetRequest() *FriendRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// 处理好友申请req
+type FriendRequestOpReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"` // 申请者的玩家id
+	IsAgree       bool                   `protobuf:"varint,2,opt,name=IsAgree,proto3" json:"IsAgree,omitempty"`   // 是否同意
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRequestOpReq) Reset() {
+	*x = FriendRequestOpReq{}
+	mi := &file_friend_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRequestOpReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequestOpReq) ProtoMessage() {}
+
+func (x *FriendRequestOpReq) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequestOpReq.ProtoReflect.Descriptor instead.
+func (*FriendRequestOpReq) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FriendRequestOpReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *FriendRequestOpReq) GetIsAgree() bool {
+	if x != nil {
+		return x.IsAgree
+	}
+	return false
+}
+
+// 处理好友申请res
+type FriendRequestOpRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	IsAgree       bool                   `protobuf:"varint,2,opt,name=IsAgree,proto3" json:"IsAgree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRequestOpRes) Reset() {
+	*x = FriendRequestOpRes{}
+	mi := &file_friend_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRequestOpRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequestOpRes) ProtoMessage() {}
+
+func (x *FriendRequestOpRes) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequestOpRes.ProtoReflect.Descriptor instead.
+func (*FriendRequestOpRes) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FriendRequestOpRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *FriendRequestOpRes) GetIsAgree() bool {
+	if x != nil {
+		return x.IsAgree
+	}
+	return false
+}
+
+// 好友申请的处理结果(服务器之间路由给申请者,申请者处理后再转发给客户端)
+type FriendRequestOpResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`   // 处理申请的玩家id
+	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`            // 处理申请的玩家名
+	IsAgree       bool                   `protobuf:"varint,3,opt,name=IsAgree,proto3" json:"IsAgree,omitempty"`     // 是否同意
+	Timestamp     int32                  `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"` // 处理时间戳(秒)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRequestOpResult) Reset() {
+	*x = FriendRequestOpResult{}
+	mi := &file_friend_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRequestOpResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequestOpResult) ProtoMessage() {}
+
+func (x *FriendRequestOpResult) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequestOpResult.ProtoReflect.Descriptor instead.
+func (*FriendRequestOpResult) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FriendRequestOpResult) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *FriendRequestOpResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FriendRequestOpResult) GetIsAgree() bool {
+	if x != nil {
+		return x.IsAgree
+	}
+	return false
+}
+
+func (x *FriendRequestOpResult) GetTimestamp() int32 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// 删除好友req
+type FriendRemoveReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRemoveReq) Reset() {
+	*x = FriendRemoveReq{}
+	mi := &file_friend_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRemoveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRemoveReq) ProtoMessage() {}
+
+func (x *FriendRemoveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRemoveReq.ProtoReflect.Descriptor instead.
+func (*FriendRemoveReq) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FriendRemoveReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 删除好友res
+type FriendRemoveRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRemoveRes) Reset() {
+	*x = FriendRemoveRes{}
+	mi := &file_friend_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRemoveRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRemoveRes) ProtoMessage() {}
+
+func (x *FriendRemoveRes) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRemoveRes.ProtoReflect.Descriptor instead.
+func (*FriendRemoveRes) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FriendRemoveRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 被好友删除(服务器之间路由给被删除的玩家,处理后再转发给客户端)
+type FriendRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"` // 删除者的玩家id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRemoved) Reset() {
+	*x = FriendRemoved{}
+	mi := &file_friend_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRemoved) ProtoMessage() {}
+
+func (x *FriendRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRemoved.ProtoReflect.Descriptor instead.
+func (*FriendRemoved) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FriendRemoved) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 查询好友在线状态req
+type FriendOnlineReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendOnlineReq) Reset() {
+	*x = FriendOnlineReq{}
+	mi := &file_friend_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendOnlineReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendOnlineReq) ProtoMessage() {}
+
+func (x *FriendOnlineReq) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendOnlineReq.ProtoReflect.Descriptor instead.
+func (*FriendOnlineReq) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{13}
+}
+
+// 查询好友在线状态res
+type FriendOnlineRes struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OnlinePlayerIds []int64                `protobuf:"varint,1,rep,packed,name=OnlinePlayerIds,proto3" json:"OnlinePlayerIds,omitempty"` // 在线的好友id
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FriendOnlineRes) Reset() {
+	*x = FriendOnlineRes{}
+	mi := &file_friend_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendOnlineRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendOnlineRes) ProtoMessage() {}
+
+func (x *FriendOnlineRes) ProtoReflect() protoreflect.Message {
+	mi := &file_friend_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendOnlineRes.ProtoReflect.Descriptor instead.
+func (*FriendOnlineRes) Descriptor() ([]byte, []int) {
+	return file_friend_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FriendOnlineRes) GetOnlinePlayerIds() []int64 {
+	if x != nil {
+		return x.OnlinePlayerIds
+	}
+	return nil
+}
+
+var File_friend_proto protoreflect.FileDescriptor
+
+const file_friend_proto_rawDesc = "" +
+	"\n" +
+	"\ffriend.proto\x12\agserver\"\x8f\x02\n" +
+	"\x0fFriendsSaveData\x12?\n" +
+	"\aFriends\x18\x01 \x03(\v2%.gserver.FriendsSaveData.FriendsEntryR\aFriends\x12B\n" +
+	"\bRequests\x18\x02 \x03(\v2&.gserver.FriendsSaveData.RequestsEntryR\bRequests\x1a:\n" +
+	"\fFriendsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\x1a;\n" +
+	"\rRequestsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"Z\n" +
+	"\n" +
+	"FriendData\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x1c\n" +
+	"\tTimestamp\x18\x03 \x01(\x05R\tTimestamp\"]\n" +
+	"\rFriendRequest\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x1c\n" +
+	"\tTimestamp\x18\x03 \x01(\x05R\tTimestamp\"\xb0\x02\n" +
+	"\vFriendsSync\x12;\n" +
+	"\aFriends\x18\x01 \x03(\v2!.gserver.FriendsSync.FriendsEntryR\aFriends\x12>\n" +
+	"\bRequests\x18\x02 \x03(\v2\".gserver.FriendsSync.RequestsEntryR\bRequests\x1aO\n" +
+	"\fFriendsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.gserver.FriendDataR\x05value:\x028\x01\x1aS\n" +
+	"\rRequestsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.gserver.FriendRequestR\x05value:\x028\x01\"*\n" +
+	"\fFriendAddReq\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"*\n" +
+	"\fFriendAddRes\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"D\n" +
+	"\x10FriendRequestAdd\x120\n" +
+	"\aRequest\x18\x01 \x01(\v2\x16.gserver.FriendRequestR\aRequest\"J\n" +
+	"\x12FriendRequestOpReq\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x18\n" +
+	"\aIsAgree\x18\x02 \x01(\bR\aIsAgree\"J\n" +
+	"\x12FriendRequestOpRes\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x18\n" +
+	"\aIsAgree\x18\x02 \x01(\bR\aIsAgree\"\x7f\n" +
+	"\x15FriendRequestOpResult\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x18\n" +
+	"\aIsAgree\x18\x03 \x01(\bR\aIsAgree\x12\x1c\n" +
+	"\tTimestamp\x18\x04 \x01(\x05R\tTimestamp\"-\n" +
+	"\x0fFriendRemoveReq\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"-\n" +
+	"\x0fFriendRemoveRes\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"+\n" +
+	"\rFriendRemoved\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"\x11\n" +
+	"\x0fFriendOnlineReq\";\n" +
+	"\x0fFriendOnlineRes\x12(\n" +
+	"\x0fOnlinePlayerIds\x18\x01 \x03(\x03R\x0fOnlinePlayerIdsB\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_friend_proto_rawDescOnce sync.Once
+	file_friend_proto_rawDescData []byte
+)
+
+func file_friend_proto_rawDescGZIP() []byte {
+	file_friend_proto_rawDescOnce.Do(func() {
+		file_friend_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_friend_proto_rawDesc), len(file_friend_proto_rawDesc)))
+	})
+	return file_friend_proto_rawDescData
+}
+
+var file_friend_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_friend_proto_goTypes = []any{
+	(*FriendsSaveData)(nil),       // 0: gserver.FriendsSaveData
+	(*FriendData)(nil),            // 1: gserver.FriendData
+	(*FriendRequest)(nil),         // 2: gserver.FriendRequest
+	(*FriendsSync)(nil),           // 3: gserver.FriendsSync
+	(*FriendAddReq)(nil),          // 4: gserver.FriendAddReq
+	(*FriendAddRes)(nil),          // 5: gserver.FriendAddRes
+	(*FriendRequestAdd)(nil),      // 6: gserver.FriendRequestAdd
+	(*FriendRequestOpReq)(nil),    // 7: gserver.FriendRequestOpReq
+	(*FriendRequestOpRes)(nil),    // 8: gserver.FriendRequestOpRes
+	(*FriendRequestOpResult)(nil), // 9: gserver.FriendRequestOpResult
+	(*FriendRemoveReq)(nil),       // 10: gserver.FriendRemoveReq
+	(*FriendRemoveRes)(nil),       // 11: gserver.FriendRemoveRes
+	(*FriendRemoved)(nil),         // 12: gserver.FriendRemoved
+	(*FriendOnlineReq)(nil),       // 13: gserver.FriendOnlineReq
+	(*FriendOnlineRes)(nil),       // 14: gserver.FriendOnlineRes
+	nil,                           // 15: gserver.FriendsSaveData.FriendsEntry
+	nil,                           // 16: gserver.FriendsSaveData.RequestsEntry
+	nil,                           // 17: gserver.FriendsSync.FriendsEntry
+	nil,                           // 18: gserver.FriendsSync.RequestsEntry
+}
+var file_friend_proto_depIdxs = []int32{
+	15, // 0: gserver.FriendsSaveData.Friends:type_name -> gserver.FriendsSaveData.FriendsEntry
+	16, // 1: gserver.FriendsSaveData.Requests:type_name -> gserver.FriendsSaveData.RequestsEntry
+	17, // 2: gserver.FriendsSync.Friends:type_name -> gserver.FriendsSync.FriendsEntry
+	18, // 3: gserver.FriendsSync.Requests:type_name -> gserver.FriendsSync.RequestsEntry
+	2,  // 4: gserver.FriendRequestAdd.Request:type_name -> gserver.FriendRequest
+	1,  // 5: gserver.FriendsSync.FriendsEntry.value:type_name -> gserver.FriendData
+	2,  // 6: gserver.FriendsSync.RequestsEntry.value:type_name -> gserver.FriendRequest
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_friend_proto_init() }
+func file_friend_proto_init() {
+	if File_friend_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_friend_proto_rawDesc), len(file_friend_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_friend_proto_goTypes,
+		DependencyIndexes: file_friend_proto_depIdxs,
+		MessageInfos:      file_friend_proto_msgTypes,
+	}.Build()
+	File_friend_proto = out.File
+	file_friend_proto_goTypes = nil
+	file_friend_proto_depIdxs = nil
+}
@@ -88,6 +88,7 @@ type GlobalEntityData struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Key             string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
 	ProcessStatInfo *ProcessStatInfo       `protobuf:"bytes,2,opt,name=ProcessStatInfo,proto3" json:"ProcessStatInfo,omitempty"`
+	SystemMail      map[int64][]byte       `protobuf:"bytes,3,rep,name=SystemMail,proto3" json:"SystemMail,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // map<int64,*SystemMailData>
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -136,6 +137,13 @@ func (x *GlobalEntityData) GetProcessStatInfo() *ProcessStatInfo {
 	return nil
 }
 
+func (x *GlobalEntityData) GetSystemMail() map[int64][]byte {
+	if x != nil {
+		return x.SystemMail
+	}
+	return nil
+}
+
 type StartupReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     int64                  `protobuf:"varint,1,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
@@ -232,10 +240,16 @@ const file_global_entity_proto_rawDesc = "" +
 	"\x0fProcessStatInfo\x122\n" +
 	"\x14LastStartupTimestamp\x18\x01 \x01(\x03R\x14LastStartupTimestamp\x124\n" +
 	"\x15LastShutdownTimestamp\x18\x02 \x01(\x03R\x15LastShutdownTimestamp\x12&\n" +
-	"\x0eLastUpdateDate\x18\x03 \x01(\x05R\x0eLastUpdateDate\"h\n" +
+	"\x0eLastUpdateDate\x18\x03 \x01(\x05R\x0eLastUpdateDate\"\xf2\x01\n" +
 	"\x10GlobalEntityData\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x12B\n" +
-	"\x0fProcessStatInfo\x18\x02 \x01(\v2\x18.gserver.ProcessStatInfoR\x0fProcessStatInfo\"*\n" +
+	"\x0fProcessStatInfo\x18\x02 \x01(\v2\x18.gserver.ProcessStatInfoR\x0fProcessStatInfo\x12I\n" +
+	"\n" +
+	"SystemMail\x18\x03 \x03(\v2).gserver.GlobalEntityData.SystemMailEntryR\n" +
+	"SystemMail\x1a=\n" +
+	"\x0fSystemMailEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"*\n" +
 	"\n" +
 	"StartupReq\x12\x1c\n" +
 	"\tTimestamp\x18\x01 \x01(\x03R\tTimestamp\"+\n" +
@@ -254,20 +268,22 @@ func file_global_entity_proto_rawDescGZIP() []byte {
 	return file_global_entity_proto_rawDescData
 }
 
-var file_global_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_global_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_global_entity_proto_goTypes = []any{
 	(*ProcessStatInfo)(nil),  // 0: gserver.ProcessStatInfo
 	(*GlobalEntityData)(nil), // 1: gserver.GlobalEntityData
 	(*StartupReq)(nil),       // 2: gserver.StartupReq
 	(*ShutdownReq)(nil),      // 3: gserver.ShutdownReq
+	nil,                      // 4: gserver.GlobalEntityData.SystemMailEntry
 }
 var file_global_entity_proto_depIdxs = []int32{
 	0, // 0: gserver.GlobalEntityData.ProcessStatInfo:type_name -> gserver.ProcessStatInfo
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4, // 1: gserver.GlobalEntityData.SystemMail:type_name -> gserver.GlobalEntityData.SystemMailEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_global_entity_proto_init() }
@@ -281,7 +297,7 @@ func file_global_entity_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_global_entity_proto_rawDesc), len(file_global_entity_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
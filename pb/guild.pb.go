@@ -70,14 +70,65 @@ func (GuildPosition) EnumDescriptor() ([]byte, []int) {
 	return file_guild_proto_rawDescGZIP(), []int{0}
 }
 
+// 被移出公会的原因
+type GuildRemoveReason int32
+
+const (
+	GuildRemoveReason_GuildRemoveReason_Leave   GuildRemoveReason = 0 // 主动退出
+	GuildRemoveReason_GuildRemoveReason_Kick    GuildRemoveReason = 1 // 被踢出
+	GuildRemoveReason_GuildRemoveReason_Disband GuildRemoveReason = 2 // 公会解散
+)
+
+// Enum value maps for GuildRemoveReason.
+var (
+	GuildRemoveReason_name = map[int32]string{
+		0: "GuildRemoveReason_Leave",
+		1: "GuildRemoveReason_Kick",
+		2: "GuildRemoveReason_Disband",
+	}
+	GuildRemoveReason_value = map[string]int32{
+		"GuildRemoveReason_Leave":   0,
+		"GuildRemoveReason_Kick":    1,
+		"GuildRemoveReason_Disband": 2,
+	}
+)
+
+func (x GuildRemoveReason) Enum() *GuildRemoveReason {
+	p := new(GuildRemoveReason)
+	*p = x
+	return p
+}
+
+func (x GuildRemoveReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GuildRemoveReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_guild_proto_enumTypes[1].Descriptor()
+}
+
+func (GuildRemoveReason) Type() protoreflect.EnumType {
+	return &file_guild_proto_enumTypes[1]
+}
+
+func (x GuildRemoveReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GuildRemoveReason.Descriptor instead.
+func (GuildRemoveReason) EnumDescriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{1}
+}
+
 // 公会在mongo中的保存格式
 // 用于一次性把公会数据加载进来
 type GuildLoadData struct {
 	state         protoimpl.MessageState     `protogen:"open.v1"`
-	Id            int64                      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // 公会唯一id
-	BaseInfo      *GuildInfo                 `protobuf:"bytes,2,opt,name=baseInfo,proto3" json:"baseInfo,omitempty"`
-	Members       map[int64]*GuildMemberData `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`           // 公会成员(明文)
-	JoinRequests  map[int64][]byte           `protobuf:"bytes,4,rep,name=joinRequests,proto3" json:"joinRequests,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 申请加入公会的请求信息(proto序列化)
+	Id            int64                      `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"` // 公会唯一id
+	BaseInfo      *GuildInfo                 `protobuf:"bytes,2,opt,name=BaseInfo,proto3" json:"BaseInfo,omitempty"`
+	Members       map[int64]*GuildMemberData `protobuf:"bytes,3,rep,name=Members,proto3" json:"Members,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`           // 公会成员(明文)
+	JoinRequests  map[int64][]byte           `protobuf:"bytes,4,rep,name=JoinRequests,proto3" json:"JoinRequests,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 申请加入公会的请求信息(proto序列化)
+	Progress      *GuildProgressSaveData     `protobuf:"bytes,5,opt,name=Progress,proto3" json:"Progress,omitempty"`                                                                                    // 公会等级经验和成员贡献
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -140,13 +191,22 @@ func (x *GuildLoadData) GetJoinRequests() map[int64][]byte {
 	return nil
 }
 
+func (x *GuildLoadData) GetProgress() *GuildProgressSaveData {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
 // 公会数据
 type GuildData struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	Id            int64                       `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // 公会唯一id
-	BaseInfo      *GuildInfo                  `protobuf:"bytes,2,opt,name=baseInfo,proto3" json:"baseInfo,omitempty"`
-	Members       map[int64]*GuildMemberData  `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`           // 公会成员
-	JoinRequests  map[int64]*GuildJoinRequest `protobuf:"bytes,4,rep,name=joinRequests,proto3" json:"joinRequests,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 申请加入公会的请求信息
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Id            int64                        `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"` // 公会唯一id
+	BaseInfo      *GuildInfo                   `protobuf:"bytes,2,opt,name=BaseInfo,proto3" json:"BaseInfo,omitempty"`
+	Members       map[int64]*GuildMemberData   `protobuf:"bytes,3,rep,name=Members,proto3" json:"Members,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`             // 公会成员
+	JoinRequests  map[int64]*GuildJoinRequest  `protobuf:"bytes,4,rep,name=JoinRequests,proto3" json:"JoinRequests,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`   // 申请加入公会的请求信息
+	Progress      *GuildProgressData           `protobuf:"bytes,5,opt,name=Progress,proto3" json:"Progress,omitempty"`                                                                                      // 公会等级经验
+	Contributions map[int64]*GuildContribution `protobuf:"bytes,6,rep,name=Contributions,proto3" json:"Contributions,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 成员的贡献记录
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,12 +269,26 @@ func (x *GuildData) GetJoinRequests() map[int64]*GuildJoinRequest {
 	return nil
 }
 
+func (x *GuildData) GetProgress() *GuildProgressData {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *GuildData) GetContributions() map[int64]*GuildContribution {
+	if x != nil {
+		return x.Contributions
+	}
+	return nil
+}
+
 // 公会成员数据
 type GuildMemberData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`             // 玩家id
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`          // 玩家名称
-	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"` // 职位
+	Id            int64                  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`             // 玩家id
+	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`          // 玩家名称
+	Position      int32                  `protobuf:"varint,3,opt,name=Position,proto3" json:"Position,omitempty"` // 职位
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -273,10 +347,12 @@ func (x *GuildMemberData) GetPosition() int32 {
 // 公会信息
 type GuildInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                   // 公会id
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                // 名称
-	Intro         string                 `protobuf:"bytes,3,opt,name=intro,proto3" json:"intro,omitempty"`              // 介绍
-	MemberCount   int32                  `protobuf:"varint,4,opt,name=memberCount,proto3" json:"memberCount,omitempty"` // 成员数
+	Id            int64                  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`                   // 公会id
+	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`                // 名称
+	Intro         string                 `protobuf:"bytes,3,opt,name=Intro,proto3" json:"Intro,omitempty"`              // 介绍
+	MemberCount   int32                  `protobuf:"varint,4,opt,name=MemberCount,proto3" json:"MemberCount,omitempty"` // 成员数
+	Level         int32                  `protobuf:"varint,5,opt,name=Level,proto3" json:"Level,omitempty"`             // 公会等级(和GuildProgressData.Level保持一致,用于公会列表显示)
+	MemberLimit   int32                  `protobuf:"varint,6,opt,name=MemberLimit,proto3" json:"MemberLimit,omitempty"` // 成员上限(0表示不限制)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -339,9 +415,23 @@ func (x *GuildInfo) GetMemberCount() int32 {
 	return 0
 }
 
+func (x *GuildInfo) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *GuildInfo) GetMemberLimit() int32 {
+	if x != nil {
+		return x.MemberLimit
+	}
+	return 0
+}
+
 type GuildSync struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          *PlayerGuildData       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Data          *PlayerGuildData       `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -386,9 +476,9 @@ func (x *GuildSync) GetData() *PlayerGuildData {
 // 申请加入公会的请求信息
 type GuildJoinRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      int64                  `protobuf:"varint,1,opt,name=playerId,proto3" json:"playerId,omitempty"` // 申请加入公会的玩家id
-	PlayerName    string                 `protobuf:"bytes,2,opt,name=playerName,proto3" json:"playerName,omitempty"`
-	TimestampSec  int32                  `protobuf:"varint,3,opt,name=timestampSec,proto3" json:"timestampSec,omitempty"` // 时间戳(秒)
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"` // 申请加入公会的玩家id
+	PlayerName    string                 `protobuf:"bytes,2,opt,name=PlayerName,proto3" json:"PlayerName,omitempty"`
+	TimestampSec  int32                  `protobuf:"varint,3,opt,name=TimestampSec,proto3" json:"TimestampSec,omitempty"` // 时间戳(秒)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -447,7 +537,7 @@ func (x *GuildJoinRequest) GetTimestampSec() int32 {
 // 查看公会列表
 type GuildListReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageIndex     int32                  `protobuf:"varint,1,opt,name=pageIndex,proto3" json:"pageIndex,omitempty"` // 分页索引
+	PageIndex     int32                  `protobuf:"varint,1,opt,name=PageIndex,proto3" json:"PageIndex,omitempty"` // 分页索引
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -493,9 +583,9 @@ func (x *GuildListReq) GetPageIndex() int32 {
 // @Player
 type GuildListRes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageIndex     int32                  `protobuf:"varint,1,opt,name=pageIndex,proto3" json:"pageIndex,omitempty"`  // 分页索引
-	PageCount     int32                  `protobuf:"varint,2,opt,name=pageCount,proto3" json:"pageCount,omitempty"`  // 总页数
-	GuildInfos    []*GuildInfo           `protobuf:"bytes,3,rep,name=guildInfos,proto3" json:"guildInfos,omitempty"` // 公会列表
+	PageIndex     int32                  `protobuf:"varint,1,opt,name=PageIndex,proto3" json:"PageIndex,omitempty"`  // 分页索引
+	PageCount     int32                  `protobuf:"varint,2,opt,name=PageCount,proto3" json:"PageCount,omitempty"`  // 总页数
+	GuildInfos    []*GuildInfo           `protobuf:"bytes,3,rep,name=GuildInfos,proto3" json:"GuildInfos,omitempty"` // 公会列表
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -551,18 +641,142 @@ func (x *GuildListRes) GetGuildInfos() []*GuildInfo {
 	return nil
 }
 
+// 搜索公会
+// 数据来自redis中的公会摘要缓存,按公会名排序
+type GuildSearchReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NamePrefix    string                 `protobuf:"bytes,1,opt,name=NamePrefix,proto3" json:"NamePrefix,omitempty"`    // 公会名前缀(不区分大小写),为空表示不限制
+	HasFreeSlot   bool                   `protobuf:"varint,2,opt,name=HasFreeSlot,proto3" json:"HasFreeSlot,omitempty"` // 只显示成员未满的公会
+	MinLevel      int32                  `protobuf:"varint,3,opt,name=MinLevel,proto3" json:"MinLevel,omitempty"`       // 最低公会等级(0表示不限制)
+	Cursor        string                 `protobuf:"bytes,4,opt,name=Cursor,proto3" json:"Cursor,omitempty"`            // 分页游标,第一页为空,之后使用上一页返回的NextCursor
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildSearchReq) Reset() {
+	*x = GuildSearchReq{}
+	mi := &file_guild_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildSearchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildSearchReq) ProtoMessage() {}
+
+func (x *GuildSearchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildSearchReq.ProtoReflect.Descriptor instead.
+func (*GuildSearchReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GuildSearchReq) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *GuildSearchReq) GetHasFreeSlot() bool {
+	if x != nil {
+		return x.HasFreeSlot
+	}
+	return false
+}
+
+func (x *GuildSearchReq) GetMinLevel() int32 {
+	if x != nil {
+		return x.MinLevel
+	}
+	return 0
+}
+
+func (x *GuildSearchReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// 搜索公会返回结果
+// @Player
+type GuildSearchRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildInfos    []*GuildInfo           `protobuf:"bytes,1,rep,name=GuildInfos,proto3" json:"GuildInfos,omitempty"` // 公会列表
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=NextCursor,proto3" json:"NextCursor,omitempty"` // 下一页的游标,为空表示没有更多数据了
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildSearchRes) Reset() {
+	*x = GuildSearchRes{}
+	mi := &file_guild_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildSearchRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildSearchRes) ProtoMessage() {}
+
+func (x *GuildSearchRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildSearchRes.ProtoReflect.Descriptor instead.
+func (*GuildSearchRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GuildSearchRes) GetGuildInfos() []*GuildInfo {
+	if x != nil {
+		return x.GuildInfos
+	}
+	return nil
+}
+
+func (x *GuildSearchRes) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // 创建公会请求
 type GuildCreateReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // 名称
-	Intro         string                 `protobuf:"bytes,2,opt,name=intro,proto3" json:"intro,omitempty"` // 介绍
+	Name          string                 `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`   // 名称
+	Intro         string                 `protobuf:"bytes,2,opt,name=Intro,proto3" json:"Intro,omitempty"` // 介绍
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GuildCreateReq) Reset() {
 	*x = GuildCreateReq{}
-	mi := &file_guild_proto_msgTypes[8]
+	mi := &file_guild_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -574,7 +788,7 @@ func (x *GuildCreateReq) String() string {
 func (*GuildCreateReq) ProtoMessage() {}
 
 func (x *GuildCreateReq) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[8]
+	mi := &file_guild_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -587,7 +801,7 @@ func (x *GuildCreateReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildCreateReq.ProtoReflect.Descriptor instead.
 func (*GuildCreateReq) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{8}
+	return file_guild_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GuildCreateReq) GetName() string {
@@ -608,16 +822,16 @@ func (x *GuildCreateReq) GetIntro() string {
 // @Player
 type GuildCreateRes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`    // 公会id
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // 名称
+	Error         string                 `protobuf:"bytes,1,opt,name=Error,proto3" json:"Error,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=Id,proto3" json:"Id,omitempty"`    // 公会id
+	Name          string                 `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"` // 名称
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GuildCreateRes) Reset() {
 	*x = GuildCreateRes{}
-	mi := &file_guild_proto_msgTypes[9]
+	mi := &file_guild_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -629,7 +843,7 @@ func (x *GuildCreateRes) String() string {
 func (*GuildCreateRes) ProtoMessage() {}
 
 func (x *GuildCreateRes) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[9]
+	mi := &file_guild_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,7 +856,7 @@ func (x *GuildCreateRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildCreateRes.ProtoReflect.Descriptor instead.
 func (*GuildCreateRes) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{9}
+	return file_guild_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GuildCreateRes) GetError() string {
@@ -669,14 +883,14 @@ func (x *GuildCreateRes) GetName() string {
 // 加入公会请求
 type GuildJoinReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` // 公会id
+	Id            int64                  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"` // 公会id
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GuildJoinReq) Reset() {
 	*x = GuildJoinReq{}
-	mi := &file_guild_proto_msgTypes[10]
+	mi := &file_guild_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -688,7 +902,7 @@ func (x *GuildJoinReq) String() string {
 func (*GuildJoinReq) ProtoMessage() {}
 
 func (x *GuildJoinReq) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[10]
+	mi := &file_guild_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -701,7 +915,7 @@ func (x *GuildJoinReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildJoinReq.ProtoReflect.Descriptor instead.
 func (*GuildJoinReq) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{10}
+	return file_guild_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GuildJoinReq) GetId() int64 {
@@ -715,15 +929,15 @@ func (x *GuildJoinReq) GetId() int64 {
 // @Player
 type GuildJoinRes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"` // 公会id
+	Error         string                 `protobuf:"bytes,1,opt,name=Error,proto3" json:"Error,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=Id,proto3" json:"Id,omitempty"` // 公会id
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GuildJoinRes) Reset() {
 	*x = GuildJoinRes{}
-	mi := &file_guild_proto_msgTypes[11]
+	mi := &file_guild_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -735,7 +949,7 @@ func (x *GuildJoinRes) String() string {
 func (*GuildJoinRes) ProtoMessage() {}
 
 func (x *GuildJoinRes) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[11]
+	mi := &file_guild_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +962,7 @@ func (x *GuildJoinRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildJoinRes.ProtoReflect.Descriptor instead.
 func (*GuildJoinRes) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{11}
+	return file_guild_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GuildJoinRes) GetError() string {
@@ -768,15 +982,15 @@ func (x *GuildJoinRes) GetId() int64 {
 // 管理员同意请求者加入公会
 type GuildJoinAgreeReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JoinPlayerId  int64                  `protobuf:"varint,1,opt,name=joinPlayerId,proto3" json:"joinPlayerId,omitempty"` // 申请加入公会的玩家id
-	IsAgree       bool                   `protobuf:"varint,2,opt,name=isAgree,proto3" json:"isAgree,omitempty"`           // 是否同意加入
+	JoinPlayerId  int64                  `protobuf:"varint,1,opt,name=JoinPlayerId,proto3" json:"JoinPlayerId,omitempty"` // 申请加入公会的玩家id
+	IsAgree       bool                   `protobuf:"varint,2,opt,name=IsAgree,proto3" json:"IsAgree,omitempty"`           // 是否同意加入
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GuildJoinAgreeReq) Reset() {
 	*x = GuildJoinAgreeReq{}
-	mi := &file_guild_proto_msgTypes[12]
+	mi := &file_guild_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -788,7 +1002,7 @@ func (x *GuildJoinAgreeReq) String() string {
 func (*GuildJoinAgreeReq) ProtoMessage() {}
 
 func (x *GuildJoinAgreeReq) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[12]
+	mi := &file_guild_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -801,7 +1015,7 @@ func (x *GuildJoinAgreeReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildJoinAgreeReq.ProtoReflect.Descriptor instead.
 func (*GuildJoinAgreeReq) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{12}
+	return file_guild_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GuildJoinAgreeReq) GetJoinPlayerId() int64 {
@@ -822,18 +1036,18 @@ func (x *GuildJoinAgreeReq) GetIsAgree() bool {
 // @Player
 type GuildJoinAgreeRes struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
-	Error           string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	GuildId         int64                  `protobuf:"varint,2,opt,name=guildId,proto3" json:"guildId,omitempty"`
-	ManagerPlayerId int64                  `protobuf:"varint,3,opt,name=managerPlayerId,proto3" json:"managerPlayerId,omitempty"` // 管理员id
-	JoinPlayerId    int64                  `protobuf:"varint,4,opt,name=joinPlayerId,proto3" json:"joinPlayerId,omitempty"`       // 申请加入公会的玩家id
-	IsAgree         bool                   `protobuf:"varint,5,opt,name=isAgree,proto3" json:"isAgree,omitempty"`                 // 是否同意加入
+	Error           string                 `protobuf:"bytes,1,opt,name=Error,proto3" json:"Error,omitempty"`
+	GuildId         int64                  `protobuf:"varint,2,opt,name=GuildId,proto3" json:"GuildId,omitempty"`
+	ManagerPlayerId int64                  `protobuf:"varint,3,opt,name=ManagerPlayerId,proto3" json:"ManagerPlayerId,omitempty"` // 管理员id
+	JoinPlayerId    int64                  `protobuf:"varint,4,opt,name=JoinPlayerId,proto3" json:"JoinPlayerId,omitempty"`       // 申请加入公会的玩家id
+	IsAgree         bool                   `protobuf:"varint,5,opt,name=IsAgree,proto3" json:"IsAgree,omitempty"`                 // 是否同意加入
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GuildJoinAgreeRes) Reset() {
 	*x = GuildJoinAgreeRes{}
-	mi := &file_guild_proto_msgTypes[13]
+	mi := &file_guild_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -845,7 +1059,7 @@ func (x *GuildJoinAgreeRes) String() string {
 func (*GuildJoinAgreeRes) ProtoMessage() {}
 
 func (x *GuildJoinAgreeRes) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[13]
+	mi := &file_guild_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,7 +1072,7 @@ func (x *GuildJoinAgreeRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildJoinAgreeRes.ProtoReflect.Descriptor instead.
 func (*GuildJoinAgreeRes) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{13}
+	return file_guild_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GuildJoinAgreeRes) GetError() string {
@@ -905,7 +1119,7 @@ type GuildDataViewReq struct {
 
 func (x *GuildDataViewReq) Reset() {
 	*x = GuildDataViewReq{}
-	mi := &file_guild_proto_msgTypes[14]
+	mi := &file_guild_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -917,7 +1131,7 @@ func (x *GuildDataViewReq) String() string {
 func (*GuildDataViewReq) ProtoMessage() {}
 
 func (x *GuildDataViewReq) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[14]
+	mi := &file_guild_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -930,21 +1144,21 @@ func (x *GuildDataViewReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildDataViewReq.ProtoReflect.Descriptor instead.
 func (*GuildDataViewReq) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{14}
+	return file_guild_proto_rawDescGZIP(), []int{16}
 }
 
 // 查看公会数据返回结果
 // @Player
 type GuildDataViewRes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GuildData     *GuildData             `protobuf:"bytes,1,opt,name=guildData,proto3" json:"guildData,omitempty"`
+	GuildData     *GuildData             `protobuf:"bytes,1,opt,name=GuildData,proto3" json:"GuildData,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GuildDataViewRes) Reset() {
 	*x = GuildDataViewRes{}
-	mi := &file_guild_proto_msgTypes[15]
+	mi := &file_guild_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -956,7 +1170,7 @@ func (x *GuildDataViewRes) String() string {
 func (*GuildDataViewRes) ProtoMessage() {}
 
 func (x *GuildDataViewRes) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[15]
+	mi := &file_guild_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -969,7 +1183,7 @@ func (x *GuildDataViewRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildDataViewRes.ProtoReflect.Descriptor instead.
 func (*GuildDataViewRes) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{15}
+	return file_guild_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GuildDataViewRes) GetGuildData() *GuildData {
@@ -982,15 +1196,15 @@ func (x *GuildDataViewRes) GetGuildData() *GuildData {
 // 提示有人申请加入本公会
 type GuildJoinReqTip struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      int64                  `protobuf:"varint,1,opt,name=playerId,proto3" json:"playerId,omitempty"`    // 玩家id
-	PlayerName    string                 `protobuf:"bytes,2,opt,name=playerName,proto3" json:"playerName,omitempty"` // 玩家名
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`    // 玩家id
+	PlayerName    string                 `protobuf:"bytes,2,opt,name=PlayerName,proto3" json:"PlayerName,omitempty"` // 玩家名
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GuildJoinReqTip) Reset() {
 	*x = GuildJoinReqTip{}
-	mi := &file_guild_proto_msgTypes[16]
+	mi := &file_guild_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1002,7 +1216,7 @@ func (x *GuildJoinReqTip) String() string {
 func (*GuildJoinReqTip) ProtoMessage() {}
 
 func (x *GuildJoinReqTip) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[16]
+	mi := &file_guild_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1229,7 @@ func (x *GuildJoinReqTip) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildJoinReqTip.ProtoReflect.Descriptor instead.
 func (*GuildJoinReqTip) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{16}
+	return file_guild_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GuildJoinReqTip) GetPlayerId() int64 {
@@ -1033,20 +1247,21 @@ func (x *GuildJoinReqTip) GetPlayerName() string {
 }
 
 // 自己的入会申请的操作结果
+// 入会申请过期被清理时,Error为JoinRequestExpired
 type GuildJoinReqOpResult struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
-	Error           string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	GuildId         int64                  `protobuf:"varint,2,opt,name=guildId,proto3" json:"guildId,omitempty"`
-	ManagerPlayerId int64                  `protobuf:"varint,3,opt,name=managerPlayerId,proto3" json:"managerPlayerId,omitempty"` // 管理员id
-	JoinPlayerId    int64                  `protobuf:"varint,4,opt,name=joinPlayerId,proto3" json:"joinPlayerId,omitempty"`       // 申请加入公会的玩家id
-	IsAgree         bool                   `protobuf:"varint,5,opt,name=isAgree,proto3" json:"isAgree,omitempty"`                 // 是否同意加入
+	Error           string                 `protobuf:"bytes,1,opt,name=Error,proto3" json:"Error,omitempty"`
+	GuildId         int64                  `protobuf:"varint,2,opt,name=GuildId,proto3" json:"GuildId,omitempty"`
+	ManagerPlayerId int64                  `protobuf:"varint,3,opt,name=ManagerPlayerId,proto3" json:"ManagerPlayerId,omitempty"` // 管理员id
+	JoinPlayerId    int64                  `protobuf:"varint,4,opt,name=JoinPlayerId,proto3" json:"JoinPlayerId,omitempty"`       // 申请加入公会的玩家id
+	IsAgree         bool                   `protobuf:"varint,5,opt,name=IsAgree,proto3" json:"IsAgree,omitempty"`                 // 是否同意加入
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GuildJoinReqOpResult) Reset() {
 	*x = GuildJoinReqOpResult{}
-	mi := &file_guild_proto_msgTypes[17]
+	mi := &file_guild_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1058,7 +1273,7 @@ func (x *GuildJoinReqOpResult) String() string {
 func (*GuildJoinReqOpResult) ProtoMessage() {}
 
 func (x *GuildJoinReqOpResult) ProtoReflect() protoreflect.Message {
-	mi := &file_guild_proto_msgTypes[17]
+	mi := &file_guild_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,7 +1286,7 @@ func (x *GuildJoinReqOpResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GuildJoinReqOpResult.ProtoReflect.Descriptor instead.
 func (*GuildJoinReqOpResult) Descriptor() ([]byte, []int) {
-	return file_guild_proto_rawDescGZIP(), []int{17}
+	return file_guild_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GuildJoinReqOpResult) GetError() string {
@@ -1109,99 +1324,1368 @@ func (x *GuildJoinReqOpResult) GetIsAgree() bool {
 	return false
 }
 
-var File_guild_proto protoreflect.FileDescriptor
+// 取消自己的入会申请
+type GuildJoinCancelReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       int64                  `protobuf:"varint,1,opt,name=GuildId,proto3" json:"GuildId,omitempty"` // 申请加入的公会id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_guild_proto_rawDesc = "" +
-	"\n" +
-	"\vguild.proto\x12\agserver\x1a\fplayer.proto\"\xf3\x02\n" +
-	"\rGuildLoadData\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
-	"\bbaseInfo\x18\x02 \x01(\v2\x12.gserver.GuildInfoR\bbaseInfo\x12=\n" +
-	"\amembers\x18\x03 \x03(\v2#.gserver.GuildLoadData.MembersEntryR\amembers\x12L\n" +
-	"\fjoinRequests\x18\x04 \x03(\v2(.gserver.GuildLoadData.JoinRequestsEntryR\fjoinRequests\x1aT\n" +
-	"\fMembersEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\x03R\x03key\x12.\n" +
-	"\x05value\x18\x02 \x01(\v2\x18.gserver.GuildMemberDataR\x05value:\x028\x01\x1a?\n" +
-	"\x11JoinRequestsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\x82\x03\n" +
-	"\tGuildData\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
-	"\bbaseInfo\x18\x02 \x01(\v2\x12.gserver.GuildInfoR\bbaseInfo\x129\n" +
-	"\amembers\x18\x03 \x03(\v2\x1f.gserver.GuildData.MembersEntryR\amembers\x12H\n" +
-	"\fjoinRequests\x18\x04 \x03(\v2$.gserver.GuildData.JoinRequestsEntryR\fjoinRequests\x1aT\n" +
-	"\fMembersEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\x03R\x03key\x12.\n" +
-	"\x05value\x18\x02 \x01(\v2\x18.gserver.GuildMemberDataR\x05value:\x028\x01\x1aZ\n" +
-	"\x11JoinRequestsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\x03R\x03key\x12/\n" +
-	"\x05value\x18\x02 \x01(\v2\x19.gserver.GuildJoinRequestR\x05value:\x028\x01\"Q\n" +
-	"\x0fGuildMemberData\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
-	"\bposition\x18\x03 \x01(\x05R\bposition\"g\n" +
-	"\tGuildInfo\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05intro\x18\x03 \x01(\tR\x05intro\x12 \n" +
-	"\vmemberCount\x18\x04 \x01(\x05R\vmemberCount\"9\n" +
-	"\tGuildSync\x12,\n" +
-	"\x04data\x18\x01 \x01(\v2\x18.gserver.PlayerGuildDataR\x04data\"r\n" +
-	"\x10GuildJoinRequest\x12\x1a\n" +
-	"\bplayerId\x18\x01 \x01(\x03R\bplayerId\x12\x1e\n" +
-	"\n" +
-	"playerName\x18\x02 \x01(\tR\n" +
-	"playerName\x12\"\n" +
-	"\ftimestampSec\x18\x03 \x01(\x05R\ftimestampSec\",\n" +
-	"\fGuildListReq\x12\x1c\n" +
-	"\tpageIndex\x18\x01 \x01(\x05R\tpageIndex\"~\n" +
-	"\fGuildListRes\x12\x1c\n" +
-	"\tpageIndex\x18\x01 \x01(\x05R\tpageIndex\x12\x1c\n" +
-	"\tpageCount\x18\x02 \x01(\x05R\tpageCount\x122\n" +
-	"\n" +
-	"guildInfos\x18\x03 \x03(\v2\x12.gserver.GuildInfoR\n" +
-	"guildInfos\":\n" +
-	"\x0eGuildCreateReq\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05intro\x18\x02 \x01(\tR\x05intro\"J\n" +
-	"\x0eGuildCreateRes\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\"\x1e\n" +
-	"\fGuildJoinReq\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"4\n" +
-	"\fGuildJoinRes\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\x03R\x02id\"Q\n" +
-	"\x11GuildJoinAgreeReq\x12\"\n" +
-	"\fjoinPlayerId\x18\x01 \x01(\x03R\fjoinPlayerId\x12\x18\n" +
-	"\aisAgree\x18\x02 \x01(\bR\aisAgree\"\xab\x01\n" +
-	"\x11GuildJoinAgreeRes\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\x12\x18\n" +
-	"\aguildId\x18\x02 \x01(\x03R\aguildId\x12(\n" +
-	"\x0fmanagerPlayerId\x18\x03 \x01(\x03R\x0fmanagerPlayerId\x12\"\n" +
-	"\fjoinPlayerId\x18\x04 \x01(\x03R\fjoinPlayerId\x12\x18\n" +
-	"\aisAgree\x18\x05 \x01(\bR\aisAgree\"\x12\n" +
-	"\x10GuildDataViewReq\"D\n" +
-	"\x10GuildDataViewRes\x120\n" +
-	"\tguildData\x18\x01 \x01(\v2\x12.gserver.GuildDataR\tguildData\"M\n" +
-	"\x0fGuildJoinReqTip\x12\x1a\n" +
-	"\bplayerId\x18\x01 \x01(\x03R\bplayerId\x12\x1e\n" +
-	"\n" +
-	"playerName\x18\x02 \x01(\tR\n" +
-	"playerName\"\xae\x01\n" +
-	"\x14GuildJoinReqOpResult\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\x12\x18\n" +
-	"\aguildId\x18\x02 \x01(\x03R\aguildId\x12(\n" +
-	"\x0fmanagerPlayerId\x18\x03 \x01(\x03R\x0fmanagerPlayerId\x12\"\n" +
-	"\fjoinPlayerId\x18\x04 \x01(\x03R\fjoinPlayerId\x12\x18\n" +
-	"\aisAgree\x18\x05 \x01(\bR\aisAgree*4\n" +
+func (x *GuildJoinCancelReq) Reset() {
+	*x = GuildJoinCancelReq{}
+	mi := &file_guild_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildJoinCancelReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildJoinCancelReq) ProtoMessage() {}
+
+func (x *GuildJoinCancelReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildJoinCancelReq.ProtoReflect.Descriptor instead.
+func (*GuildJoinCancelReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GuildJoinCancelReq) GetGuildId() int64 {
+	if x != nil {
+		return x.GuildId
+	}
+	return 0
+}
+
+// 取消入会申请返回结果
+// @Player
+type GuildJoinCancelRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       int64                  `protobuf:"varint,1,opt,name=GuildId,proto3" json:"GuildId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildJoinCancelRes) Reset() {
+	*x = GuildJoinCancelRes{}
+	mi := &file_guild_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildJoinCancelRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildJoinCancelRes) ProtoMessage() {}
+
+func (x *GuildJoinCancelRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildJoinCancelRes.ProtoReflect.Descriptor instead.
+func (*GuildJoinCancelRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GuildJoinCancelRes) GetGuildId() int64 {
+	if x != nil {
+		return x.GuildId
+	}
+	return 0
+}
+
+// 退出公会
+type GuildLeaveReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildLeaveReq) Reset() {
+	*x = GuildLeaveReq{}
+	mi := &file_guild_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildLeaveReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildLeaveReq) ProtoMessage() {}
+
+func (x *GuildLeaveReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildLeaveReq.ProtoReflect.Descriptor instead.
+func (*GuildLeaveReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{22}
+}
+
+// 退出公会返回结果
+// @Player
+type GuildLeaveRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       int64                  `protobuf:"varint,1,opt,name=GuildId,proto3" json:"GuildId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildLeaveRes) Reset() {
+	*x = GuildLeaveRes{}
+	mi := &file_guild_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildLeaveRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildLeaveRes) ProtoMessage() {}
+
+func (x *GuildLeaveRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildLeaveRes.ProtoReflect.Descriptor instead.
+func (*GuildLeaveRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GuildLeaveRes) GetGuildId() int64 {
+	if x != nil {
+		return x.GuildId
+	}
+	return 0
+}
+
+// 踢出公会成员
+type GuildKickReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"` // 被踢的玩家id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildKickReq) Reset() {
+	*x = GuildKickReq{}
+	mi := &file_guild_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildKickReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildKickReq) ProtoMessage() {}
+
+func (x *GuildKickReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildKickReq.ProtoReflect.Descriptor instead.
+func (*GuildKickReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GuildKickReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 踢出公会成员返回结果
+// @Player
+type GuildKickRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildKickRes) Reset() {
+	*x = GuildKickRes{}
+	mi := &file_guild_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildKickRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildKickRes) ProtoMessage() {}
+
+func (x *GuildKickRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildKickRes.ProtoReflect.Descriptor instead.
+func (*GuildKickRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GuildKickRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 解散公会(会长)
+type GuildDisbandReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildDisbandReq) Reset() {
+	*x = GuildDisbandReq{}
+	mi := &file_guild_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildDisbandReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildDisbandReq) ProtoMessage() {}
+
+func (x *GuildDisbandReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildDisbandReq.ProtoReflect.Descriptor instead.
+func (*GuildDisbandReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{26}
+}
+
+// 解散公会返回结果
+// @Player
+type GuildDisbandRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       int64                  `protobuf:"varint,1,opt,name=GuildId,proto3" json:"GuildId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildDisbandRes) Reset() {
+	*x = GuildDisbandRes{}
+	mi := &file_guild_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildDisbandRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildDisbandRes) ProtoMessage() {}
+
+func (x *GuildDisbandRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildDisbandRes.ProtoReflect.Descriptor instead.
+func (*GuildDisbandRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GuildDisbandRes) GetGuildId() int64 {
+	if x != nil {
+		return x.GuildId
+	}
+	return 0
+}
+
+// 转让会长
+type GuildTransferLeaderReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"` // 新会长的玩家id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildTransferLeaderReq) Reset() {
+	*x = GuildTransferLeaderReq{}
+	mi := &file_guild_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildTransferLeaderReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildTransferLeaderReq) ProtoMessage() {}
+
+func (x *GuildTransferLeaderReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildTransferLeaderReq.ProtoReflect.Descriptor instead.
+func (*GuildTransferLeaderReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GuildTransferLeaderReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 转让会长返回结果
+// @Player
+type GuildTransferLeaderRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildTransferLeaderRes) Reset() {
+	*x = GuildTransferLeaderRes{}
+	mi := &file_guild_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildTransferLeaderRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildTransferLeaderRes) ProtoMessage() {}
+
+func (x *GuildTransferLeaderRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildTransferLeaderRes.ProtoReflect.Descriptor instead.
+func (*GuildTransferLeaderRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GuildTransferLeaderRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 设置成员职位(会长)
+type GuildSetPositionReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=Position,proto3" json:"Position,omitempty"` // 职位(enum GuildPosition),不能设置为会长
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildSetPositionReq) Reset() {
+	*x = GuildSetPositionReq{}
+	mi := &file_guild_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildSetPositionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildSetPositionReq) ProtoMessage() {}
+
+func (x *GuildSetPositionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildSetPositionReq.ProtoReflect.Descriptor instead.
+func (*GuildSetPositionReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GuildSetPositionReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *GuildSetPositionReq) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// 设置成员职位返回结果
+// @Player
+type GuildSetPositionRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=Position,proto3" json:"Position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildSetPositionRes) Reset() {
+	*x = GuildSetPositionRes{}
+	mi := &file_guild_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildSetPositionRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildSetPositionRes) ProtoMessage() {}
+
+func (x *GuildSetPositionRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildSetPositionRes.ProtoReflect.Descriptor instead.
+func (*GuildSetPositionRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GuildSetPositionRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *GuildSetPositionRes) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// 被移出公会(被踢或公会解散)
+// 由公会所在服务器通过RoutePlayerPacket(WithSaveDb())通知,玩家不在线时,上线后处理
+type GuildMemberRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GuildId       int64                  `protobuf:"varint,1,opt,name=GuildId,proto3" json:"GuildId,omitempty"`
+	OperatorId    int64                  `protobuf:"varint,2,opt,name=OperatorId,proto3" json:"OperatorId,omitempty"` // 操作者id
+	Reason        int32                  `protobuf:"varint,3,opt,name=Reason,proto3" json:"Reason,omitempty"`         // enum GuildRemoveReason
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildMemberRemoved) Reset() {
+	*x = GuildMemberRemoved{}
+	mi := &file_guild_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildMemberRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildMemberRemoved) ProtoMessage() {}
+
+func (x *GuildMemberRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildMemberRemoved.ProtoReflect.Descriptor instead.
+func (*GuildMemberRemoved) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GuildMemberRemoved) GetGuildId() int64 {
+	if x != nil {
+		return x.GuildId
+	}
+	return 0
+}
+
+func (x *GuildMemberRemoved) GetOperatorId() int64 {
+	if x != nil {
+		return x.OperatorId
+	}
+	return 0
+}
+
+func (x *GuildMemberRemoved) GetReason() int32 {
+	if x != nil {
+		return x.Reason
+	}
+	return 0
+}
+
+// 公会成员改名了,新名字使用路由消息里的玩家名,非客户端消息
+type GuildMemberRenameReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildMemberRenameReq) Reset() {
+	*x = GuildMemberRenameReq{}
+	mi := &file_guild_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildMemberRenameReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildMemberRenameReq) ProtoMessage() {}
+
+func (x *GuildMemberRenameReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildMemberRenameReq.ProtoReflect.Descriptor instead.
+func (*GuildMemberRenameReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{33}
+}
+
+// @Player
+type GuildMemberRenameRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildMemberRenameRes) Reset() {
+	*x = GuildMemberRenameRes{}
+	mi := &file_guild_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildMemberRenameRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildMemberRenameRes) ProtoMessage() {}
+
+func (x *GuildMemberRenameRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildMemberRenameRes.ProtoReflect.Descriptor instead.
+func (*GuildMemberRenameRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{34}
+}
+
+// 公会成员变化,广播给公会成员
+type GuildMemberUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *GuildMemberData       `protobuf:"bytes,1,opt,name=Member,proto3" json:"Member,omitempty"`
+	IsRemoved     bool                   `protobuf:"varint,2,opt,name=IsRemoved,proto3" json:"IsRemoved,omitempty"` // 是否移出公会
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildMemberUpdate) Reset() {
+	*x = GuildMemberUpdate{}
+	mi := &file_guild_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildMemberUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildMemberUpdate) ProtoMessage() {}
+
+func (x *GuildMemberUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildMemberUpdate.ProtoReflect.Descriptor instead.
+func (*GuildMemberUpdate) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GuildMemberUpdate) GetMember() *GuildMemberData {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+func (x *GuildMemberUpdate) GetIsRemoved() bool {
+	if x != nil {
+		return x.IsRemoved
+	}
+	return false
+}
+
+// 公会等级经验数据
+type GuildProgressData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         int32                  `protobuf:"varint,1,opt,name=Level,proto3" json:"Level,omitempty"` // 公会等级
+	Exp           int32                  `protobuf:"varint,2,opt,name=Exp,proto3" json:"Exp,omitempty"`     // 当前等级的经验值
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildProgressData) Reset() {
+	*x = GuildProgressData{}
+	mi := &file_guild_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildProgressData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildProgressData) ProtoMessage() {}
+
+func (x *GuildProgressData) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildProgressData.ProtoReflect.Descriptor instead.
+func (*GuildProgressData) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GuildProgressData) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *GuildProgressData) GetExp() int32 {
+	if x != nil {
+		return x.Exp
+	}
+	return 0
+}
+
+// 公会成员的贡献记录
+type GuildContribution struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId       int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`             // 玩家id
+	Total          int32                  `protobuf:"varint,2,opt,name=Total,proto3" json:"Total,omitempty"`                   // 累计贡献值
+	DonateCount    int32                  `protobuf:"varint,3,opt,name=DonateCount,proto3" json:"DonateCount,omitempty"`       // 累计捐献次数
+	LastDonateTime int32                  `protobuf:"varint,4,opt,name=LastDonateTime,proto3" json:"LastDonateTime,omitempty"` // 最近一次捐献的时间戳(秒)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GuildContribution) Reset() {
+	*x = GuildContribution{}
+	mi := &file_guild_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildContribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildContribution) ProtoMessage() {}
+
+func (x *GuildContribution) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildContribution.ProtoReflect.Descriptor instead.
+func (*GuildContribution) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GuildContribution) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *GuildContribution) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GuildContribution) GetDonateCount() int32 {
+	if x != nil {
+		return x.DonateCount
+	}
+	return 0
+}
+
+func (x *GuildContribution) GetLastDonateTime() int32 {
+	if x != nil {
+		return x.LastDonateTime
+	}
+	return 0
+}
+
+// 公会等级经验和成员贡献在mongo中的保存格式
+type GuildProgressSaveData struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Base          *GuildProgressData           `protobuf:"bytes,1,opt,name=Base,proto3" json:"Base,omitempty"`                                                                                              // 公会等级经验
+	Contributions map[int64]*GuildContribution `protobuf:"bytes,2,rep,name=Contributions,proto3" json:"Contributions,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 成员的贡献记录(明文)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildProgressSaveData) Reset() {
+	*x = GuildProgressSaveData{}
+	mi := &file_guild_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildProgressSaveData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildProgressSaveData) ProtoMessage() {}
+
+func (x *GuildProgressSaveData) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildProgressSaveData.ProtoReflect.Descriptor instead.
+func (*GuildProgressSaveData) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GuildProgressSaveData) GetBase() *GuildProgressData {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GuildProgressSaveData) GetContributions() map[int64]*GuildContribution {
+	if x != nil {
+		return x.Contributions
+	}
+	return nil
+}
+
+// 公会捐献
+// 玩家所在服务器先扣除物品,再路由到公会所在服务器增加公会经验和成员贡献
+type GuildDonateReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DonateCfgId   int32                  `protobuf:"varint,1,opt,name=DonateCfgId,proto3" json:"DonateCfgId,omitempty"` // 捐献配置id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildDonateReq) Reset() {
+	*x = GuildDonateReq{}
+	mi := &file_guild_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildDonateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildDonateReq) ProtoMessage() {}
+
+func (x *GuildDonateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildDonateReq.ProtoReflect.Descriptor instead.
+func (*GuildDonateReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GuildDonateReq) GetDonateCfgId() int32 {
+	if x != nil {
+		return x.DonateCfgId
+	}
+	return 0
+}
+
+// 公会捐献返回结果
+// @Player
+type GuildDonateRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DonateCfgId   int32                  `protobuf:"varint,1,opt,name=DonateCfgId,proto3" json:"DonateCfgId,omitempty"`
+	Progress      *GuildProgressData     `protobuf:"bytes,2,opt,name=Progress,proto3" json:"Progress,omitempty"`         // 捐献后的公会等级经验
+	Contribution  *GuildContribution     `protobuf:"bytes,3,opt,name=Contribution,proto3" json:"Contribution,omitempty"` // 捐献后自己的贡献记录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildDonateRes) Reset() {
+	*x = GuildDonateRes{}
+	mi := &file_guild_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildDonateRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildDonateRes) ProtoMessage() {}
+
+func (x *GuildDonateRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildDonateRes.ProtoReflect.Descriptor instead.
+func (*GuildDonateRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GuildDonateRes) GetDonateCfgId() int32 {
+	if x != nil {
+		return x.DonateCfgId
+	}
+	return 0
+}
+
+func (x *GuildDonateRes) GetProgress() *GuildProgressData {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *GuildDonateRes) GetContribution() *GuildContribution {
+	if x != nil {
+		return x.Contribution
+	}
+	return nil
+}
+
+// 公会等级经验变化,广播给公会成员
+type GuildProgressUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Progress      *GuildProgressData     `protobuf:"bytes,1,opt,name=Progress,proto3" json:"Progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildProgressUpdate) Reset() {
+	*x = GuildProgressUpdate{}
+	mi := &file_guild_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildProgressUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildProgressUpdate) ProtoMessage() {}
+
+func (x *GuildProgressUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildProgressUpdate.ProtoReflect.Descriptor instead.
+func (*GuildProgressUpdate) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GuildProgressUpdate) GetProgress() *GuildProgressData {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+// 公会商店购买
+// 先路由到公会所在服务器检查公会条件(公会等级,成员贡献等),再由玩家所在服务器扣除消耗和发放物品
+type GuildShopBuyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExchangeCfgId int32                  `protobuf:"varint,1,opt,name=ExchangeCfgId,proto3" json:"ExchangeCfgId,omitempty"` // 兑换配置id(ExchangeCategory_GuildShop)
+	Count         int32                  `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`                 // 购买数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildShopBuyReq) Reset() {
+	*x = GuildShopBuyReq{}
+	mi := &file_guild_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildShopBuyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildShopBuyReq) ProtoMessage() {}
+
+func (x *GuildShopBuyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildShopBuyReq.ProtoReflect.Descriptor instead.
+func (*GuildShopBuyReq) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GuildShopBuyReq) GetExchangeCfgId() int32 {
+	if x != nil {
+		return x.ExchangeCfgId
+	}
+	return 0
+}
+
+func (x *GuildShopBuyReq) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// 公会商店购买返回结果
+// @Player
+type GuildShopBuyRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExchangeCfgId int32                  `protobuf:"varint,1,opt,name=ExchangeCfgId,proto3" json:"ExchangeCfgId,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	Record        *ExchangeRecord        `protobuf:"bytes,3,opt,name=Record,proto3" json:"Record,omitempty"` // 购买后的兑换记录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuildShopBuyRes) Reset() {
+	*x = GuildShopBuyRes{}
+	mi := &file_guild_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuildShopBuyRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuildShopBuyRes) ProtoMessage() {}
+
+func (x *GuildShopBuyRes) ProtoReflect() protoreflect.Message {
+	mi := &file_guild_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuildShopBuyRes.ProtoReflect.Descriptor instead.
+func (*GuildShopBuyRes) Descriptor() ([]byte, []int) {
+	return file_guild_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GuildShopBuyRes) GetExchangeCfgId() int32 {
+	if x != nil {
+		return x.ExchangeCfgId
+	}
+	return 0
+}
+
+func (x *GuildShopBuyRes) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GuildShopBuyRes) GetRecord() *ExchangeRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+var File_guild_proto protoreflect.FileDescriptor
+
+const file_guild_proto_rawDesc = "" +
+	"\n" +
+	"\vguild.proto\x12\agserver\x1a\fplayer.proto\"\xaf\x03\n" +
+	"\rGuildLoadData\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\x03R\x02Id\x12.\n" +
+	"\bBaseInfo\x18\x02 \x01(\v2\x12.gserver.GuildInfoR\bBaseInfo\x12=\n" +
+	"\aMembers\x18\x03 \x03(\v2#.gserver.GuildLoadData.MembersEntryR\aMembers\x12L\n" +
+	"\fJoinRequests\x18\x04 \x03(\v2(.gserver.GuildLoadData.JoinRequestsEntryR\fJoinRequests\x12:\n" +
+	"\bProgress\x18\x05 \x01(\v2\x1e.gserver.GuildProgressSaveDataR\bProgress\x1aT\n" +
+	"\fMembersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.gserver.GuildMemberDataR\x05value:\x028\x01\x1a?\n" +
+	"\x11JoinRequestsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\xe5\x04\n" +
+	"\tGuildData\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\x03R\x02Id\x12.\n" +
+	"\bBaseInfo\x18\x02 \x01(\v2\x12.gserver.GuildInfoR\bBaseInfo\x129\n" +
+	"\aMembers\x18\x03 \x03(\v2\x1f.gserver.GuildData.MembersEntryR\aMembers\x12H\n" +
+	"\fJoinRequests\x18\x04 \x03(\v2$.gserver.GuildData.JoinRequestsEntryR\fJoinRequests\x126\n" +
+	"\bProgress\x18\x05 \x01(\v2\x1a.gserver.GuildProgressDataR\bProgress\x12K\n" +
+	"\rContributions\x18\x06 \x03(\v2%.gserver.GuildData.ContributionsEntryR\rContributions\x1aT\n" +
+	"\fMembersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.gserver.GuildMemberDataR\x05value:\x028\x01\x1aZ\n" +
+	"\x11JoinRequestsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.gserver.GuildJoinRequestR\x05value:\x028\x01\x1a\\\n" +
+	"\x12ContributionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.gserver.GuildContributionR\x05value:\x028\x01\"Q\n" +
+	"\x0fGuildMemberData\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\x03R\x02Id\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x1a\n" +
+	"\bPosition\x18\x03 \x01(\x05R\bPosition\"\x9f\x01\n" +
+	"\tGuildInfo\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\x03R\x02Id\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x14\n" +
+	"\x05Intro\x18\x03 \x01(\tR\x05Intro\x12 \n" +
+	"\vMemberCount\x18\x04 \x01(\x05R\vMemberCount\x12\x14\n" +
+	"\x05Level\x18\x05 \x01(\x05R\x05Level\x12 \n" +
+	"\vMemberLimit\x18\x06 \x01(\x05R\vMemberLimit\"9\n" +
+	"\tGuildSync\x12,\n" +
+	"\x04Data\x18\x01 \x01(\v2\x18.gserver.PlayerGuildDataR\x04Data\"r\n" +
+	"\x10GuildJoinRequest\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x1e\n" +
+	"\n" +
+	"PlayerName\x18\x02 \x01(\tR\n" +
+	"PlayerName\x12\"\n" +
+	"\fTimestampSec\x18\x03 \x01(\x05R\fTimestampSec\",\n" +
+	"\fGuildListReq\x12\x1c\n" +
+	"\tPageIndex\x18\x01 \x01(\x05R\tPageIndex\"~\n" +
+	"\fGuildListRes\x12\x1c\n" +
+	"\tPageIndex\x18\x01 \x01(\x05R\tPageIndex\x12\x1c\n" +
+	"\tPageCount\x18\x02 \x01(\x05R\tPageCount\x122\n" +
+	"\n" +
+	"GuildInfos\x18\x03 \x03(\v2\x12.gserver.GuildInfoR\n" +
+	"GuildInfos\"\x86\x01\n" +
+	"\x0eGuildSearchReq\x12\x1e\n" +
+	"\n" +
+	"NamePrefix\x18\x01 \x01(\tR\n" +
+	"NamePrefix\x12 \n" +
+	"\vHasFreeSlot\x18\x02 \x01(\bR\vHasFreeSlot\x12\x1a\n" +
+	"\bMinLevel\x18\x03 \x01(\x05R\bMinLevel\x12\x16\n" +
+	"\x06Cursor\x18\x04 \x01(\tR\x06Cursor\"d\n" +
+	"\x0eGuildSearchRes\x122\n" +
+	"\n" +
+	"GuildInfos\x18\x01 \x03(\v2\x12.gserver.GuildInfoR\n" +
+	"GuildInfos\x12\x1e\n" +
+	"\n" +
+	"NextCursor\x18\x02 \x01(\tR\n" +
+	"NextCursor\":\n" +
+	"\x0eGuildCreateReq\x12\x12\n" +
+	"\x04Name\x18\x01 \x01(\tR\x04Name\x12\x14\n" +
+	"\x05Intro\x18\x02 \x01(\tR\x05Intro\"J\n" +
+	"\x0eGuildCreateRes\x12\x14\n" +
+	"\x05Error\x18\x01 \x01(\tR\x05Error\x12\x0e\n" +
+	"\x02Id\x18\x02 \x01(\x03R\x02Id\x12\x12\n" +
+	"\x04Name\x18\x03 \x01(\tR\x04Name\"\x1e\n" +
+	"\fGuildJoinReq\x12\x0e\n" +
+	"\x02Id\x18\x01 \x01(\x03R\x02Id\"4\n" +
+	"\fGuildJoinRes\x12\x14\n" +
+	"\x05Error\x18\x01 \x01(\tR\x05Error\x12\x0e\n" +
+	"\x02Id\x18\x02 \x01(\x03R\x02Id\"Q\n" +
+	"\x11GuildJoinAgreeReq\x12\"\n" +
+	"\fJoinPlayerId\x18\x01 \x01(\x03R\fJoinPlayerId\x12\x18\n" +
+	"\aIsAgree\x18\x02 \x01(\bR\aIsAgree\"\xab\x01\n" +
+	"\x11GuildJoinAgreeRes\x12\x14\n" +
+	"\x05Error\x18\x01 \x01(\tR\x05Error\x12\x18\n" +
+	"\aGuildId\x18\x02 \x01(\x03R\aGuildId\x12(\n" +
+	"\x0fManagerPlayerId\x18\x03 \x01(\x03R\x0fManagerPlayerId\x12\"\n" +
+	"\fJoinPlayerId\x18\x04 \x01(\x03R\fJoinPlayerId\x12\x18\n" +
+	"\aIsAgree\x18\x05 \x01(\bR\aIsAgree\"\x12\n" +
+	"\x10GuildDataViewReq\"D\n" +
+	"\x10GuildDataViewRes\x120\n" +
+	"\tGuildData\x18\x01 \x01(\v2\x12.gserver.GuildDataR\tGuildData\"M\n" +
+	"\x0fGuildJoinReqTip\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x1e\n" +
+	"\n" +
+	"PlayerName\x18\x02 \x01(\tR\n" +
+	"PlayerName\"\xae\x01\n" +
+	"\x14GuildJoinReqOpResult\x12\x14\n" +
+	"\x05Error\x18\x01 \x01(\tR\x05Error\x12\x18\n" +
+	"\aGuildId\x18\x02 \x01(\x03R\aGuildId\x12(\n" +
+	"\x0fManagerPlayerId\x18\x03 \x01(\x03R\x0fManagerPlayerId\x12\"\n" +
+	"\fJoinPlayerId\x18\x04 \x01(\x03R\fJoinPlayerId\x12\x18\n" +
+	"\aIsAgree\x18\x05 \x01(\bR\aIsAgree\".\n" +
+	"\x12GuildJoinCancelReq\x12\x18\n" +
+	"\aGuildId\x18\x01 \x01(\x03R\aGuildId\".\n" +
+	"\x12GuildJoinCancelRes\x12\x18\n" +
+	"\aGuildId\x18\x01 \x01(\x03R\aGuildId\"\x0f\n" +
+	"\rGuildLeaveReq\")\n" +
+	"\rGuildLeaveRes\x12\x18\n" +
+	"\aGuildId\x18\x01 \x01(\x03R\aGuildId\"*\n" +
+	"\fGuildKickReq\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"*\n" +
+	"\fGuildKickRes\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"\x11\n" +
+	"\x0fGuildDisbandReq\"+\n" +
+	"\x0fGuildDisbandRes\x12\x18\n" +
+	"\aGuildId\x18\x01 \x01(\x03R\aGuildId\"4\n" +
+	"\x16GuildTransferLeaderReq\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"4\n" +
+	"\x16GuildTransferLeaderRes\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\"M\n" +
+	"\x13GuildSetPositionReq\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x1a\n" +
+	"\bPosition\x18\x02 \x01(\x05R\bPosition\"M\n" +
+	"\x13GuildSetPositionRes\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x1a\n" +
+	"\bPosition\x18\x02 \x01(\x05R\bPosition\"f\n" +
+	"\x12GuildMemberRemoved\x12\x18\n" +
+	"\aGuildId\x18\x01 \x01(\x03R\aGuildId\x12\x1e\n" +
+	"\n" +
+	"OperatorId\x18\x02 \x01(\x03R\n" +
+	"OperatorId\x12\x16\n" +
+	"\x06Reason\x18\x03 \x01(\x05R\x06Reason\"\x16\n" +
+	"\x14GuildMemberRenameReq\"\x16\n" +
+	"\x14GuildMemberRenameRes\"c\n" +
+	"\x11GuildMemberUpdate\x120\n" +
+	"\x06Member\x18\x01 \x01(\v2\x18.gserver.GuildMemberDataR\x06Member\x12\x1c\n" +
+	"\tIsRemoved\x18\x02 \x01(\bR\tIsRemoved\";\n" +
+	"\x11GuildProgressData\x12\x14\n" +
+	"\x05Level\x18\x01 \x01(\x05R\x05Level\x12\x10\n" +
+	"\x03Exp\x18\x02 \x01(\x05R\x03Exp\"\x8f\x01\n" +
+	"\x11GuildContribution\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x14\n" +
+	"\x05Total\x18\x02 \x01(\x05R\x05Total\x12 \n" +
+	"\vDonateCount\x18\x03 \x01(\x05R\vDonateCount\x12&\n" +
+	"\x0eLastDonateTime\x18\x04 \x01(\x05R\x0eLastDonateTime\"\xfe\x01\n" +
+	"\x15GuildProgressSaveData\x12.\n" +
+	"\x04Base\x18\x01 \x01(\v2\x1a.gserver.GuildProgressDataR\x04Base\x12W\n" +
+	"\rContributions\x18\x02 \x03(\v21.gserver.GuildProgressSaveData.ContributionsEntryR\rContributions\x1a\\\n" +
+	"\x12ContributionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x120\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.gserver.GuildContributionR\x05value:\x028\x01\"2\n" +
+	"\x0eGuildDonateReq\x12 \n" +
+	"\vDonateCfgId\x18\x01 \x01(\x05R\vDonateCfgId\"\xaa\x01\n" +
+	"\x0eGuildDonateRes\x12 \n" +
+	"\vDonateCfgId\x18\x01 \x01(\x05R\vDonateCfgId\x126\n" +
+	"\bProgress\x18\x02 \x01(\v2\x1a.gserver.GuildProgressDataR\bProgress\x12>\n" +
+	"\fContribution\x18\x03 \x01(\v2\x1a.gserver.GuildContributionR\fContribution\"M\n" +
+	"\x13GuildProgressUpdate\x126\n" +
+	"\bProgress\x18\x01 \x01(\v2\x1a.gserver.GuildProgressDataR\bProgress\"M\n" +
+	"\x0fGuildShopBuyReq\x12$\n" +
+	"\rExchangeCfgId\x18\x01 \x01(\x05R\rExchangeCfgId\x12\x14\n" +
+	"\x05Count\x18\x02 \x01(\x05R\x05Count\"~\n" +
+	"\x0fGuildShopBuyRes\x12$\n" +
+	"\rExchangeCfgId\x18\x01 \x01(\x05R\rExchangeCfgId\x12\x14\n" +
+	"\x05Count\x18\x02 \x01(\x05R\x05Count\x12/\n" +
+	"\x06Record\x18\x03 \x01(\v2\x17.gserver.ExchangeRecordR\x06Record*4\n" +
 	"\rGuildPosition\x12\n" +
 	"\n" +
 	"\x06Member\x10\x00\x12\v\n" +
 	"\aManager\x10\x01\x12\n" +
 	"\n" +
-	"\x06Leader\x10\x02B\x06Z\x04./pbb\x06proto3"
+	"\x06Leader\x10\x02*k\n" +
+	"\x11GuildRemoveReason\x12\x1b\n" +
+	"\x17GuildRemoveReason_Leave\x10\x00\x12\x1a\n" +
+	"\x16GuildRemoveReason_Kick\x10\x01\x12\x1d\n" +
+	"\x19GuildRemoveReason_Disband\x10\x02B\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_guild_proto_rawDescOnce sync.Once
@@ -1215,52 +2699,95 @@ func file_guild_proto_rawDescGZIP() []byte {
 	return file_guild_proto_rawDescData
 }
 
-var file_guild_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_guild_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_guild_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_guild_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_guild_proto_goTypes = []any{
-	(GuildPosition)(0),           // 0: gserver.GuildPosition
-	(*GuildLoadData)(nil),        // 1: gserver.GuildLoadData
-	(*GuildData)(nil),            // 2: gserver.GuildData
-	(*GuildMemberData)(nil),      // 3: gserver.GuildMemberData
-	(*GuildInfo)(nil),            // 4: gserver.GuildInfo
-	(*GuildSync)(nil),            // 5: gserver.GuildSync
-	(*GuildJoinRequest)(nil),     // 6: gserver.GuildJoinRequest
-	(*GuildListReq)(nil),         // 7: gserver.GuildListReq
-	(*GuildListRes)(nil),         // 8: gserver.GuildListRes
-	(*GuildCreateReq)(nil),       // 9: gserver.GuildCreateReq
-	(*GuildCreateRes)(nil),       // 10: gserver.GuildCreateRes
-	(*GuildJoinReq)(nil),         // 11: gserver.GuildJoinReq
-	(*GuildJoinRes)(nil),         // 12: gserver.GuildJoinRes
-	(*GuildJoinAgreeReq)(nil),    // 13: gserver.GuildJoinAgreeReq
-	(*GuildJoinAgreeRes)(nil),    // 14: gserver.GuildJoinAgreeRes
-	(*GuildDataViewReq)(nil),     // 15: gserver.GuildDataViewReq
-	(*GuildDataViewRes)(nil),     // 16: gserver.GuildDataViewRes
-	(*GuildJoinReqTip)(nil),      // 17: gserver.GuildJoinReqTip
-	(*GuildJoinReqOpResult)(nil), // 18: gserver.GuildJoinReqOpResult
-	nil,                          // 19: gserver.GuildLoadData.MembersEntry
-	nil,                          // 20: gserver.GuildLoadData.JoinRequestsEntry
-	nil,                          // 21: gserver.GuildData.MembersEntry
-	nil,                          // 22: gserver.GuildData.JoinRequestsEntry
-	(*PlayerGuildData)(nil),      // 23: gserver.PlayerGuildData
+	(GuildPosition)(0),             // 0: gserver.GuildPosition
+	(GuildRemoveReason)(0),         // 1: gserver.GuildRemoveReason
+	(*GuildLoadData)(nil),          // 2: gserver.GuildLoadData
+	(*GuildData)(nil),              // 3: gserver.GuildData
+	(*GuildMemberData)(nil),        // 4: gserver.GuildMemberData
+	(*GuildInfo)(nil),              // 5: gserver.GuildInfo
+	(*GuildSync)(nil),              // 6: gserver.GuildSync
+	(*GuildJoinRequest)(nil),       // 7: gserver.GuildJoinRequest
+	(*GuildListReq)(nil),           // 8: gserver.GuildListReq
+	(*GuildListRes)(nil),           // 9: gserver.GuildListRes
+	(*GuildSearchReq)(nil),         // 10: gserver.GuildSearchReq
+	(*GuildSearchRes)(nil),         // 11: gserver.GuildSearchRes
+	(*GuildCreateReq)(nil),         // 12: gserver.GuildCreateReq
+	(*GuildCreateRes)(nil),         // 13: gserver.GuildCreateRes
+	(*GuildJoinReq)(nil),           // 14: gserver.GuildJoinReq
+	(*GuildJoinRes)(nil),           // 15: gserver.GuildJoinRes
+	(*GuildJoinAgreeReq)(nil),      // 16: gserver.GuildJoinAgreeReq
+	(*GuildJoinAgreeRes)(nil),      // 17: gserver.GuildJoinAgreeRes
+	(*GuildDataViewReq)(nil),       // 18: gserver.GuildDataViewReq
+	(*GuildDataViewRes)(nil),       // 19: gserver.GuildDataViewRes
+	(*GuildJoinReqTip)(nil),        // 20: gserver.GuildJoinReqTip
+	(*GuildJoinReqOpResult)(nil),   // 21: gserver.GuildJoinReqOpResult
+	(*GuildJoinCancelReq)(nil),     // 22: gserver.GuildJoinCancelReq
+	(*GuildJoinCancelRes)(nil),     // 23: gserver.GuildJoinCancelRes
+	(*GuildLeaveReq)(nil),          // 24: gserver.GuildLeaveReq
+	(*GuildLeaveRes)(nil),          // 25: gserver.GuildLeaveRes
+	(*GuildKickReq)(nil),           // 26: gserver.GuildKickReq
+	(*GuildKickRes)(nil),           // 27: gserver.GuildKickRes
+	(*GuildDisbandReq)(nil),        // 28: gserver.GuildDisbandReq
+	(*GuildDisbandRes)(nil),        // 29: gserver.GuildDisbandRes
+	(*GuildTransferLeaderReq)(nil), // 30: gserver.GuildTransferLeaderReq
+	(*GuildTransferLeaderRes)(nil), // 31: gserver.GuildTransferLeaderRes
+	(*GuildSetPositionReq)(nil),    // 32: gserver.GuildSetPositionReq
+	(*GuildSetPositionRes)(nil),    // 33: gserver.GuildSetPositionRes
+	(*GuildMemberRemoved)(nil),     // 34: gserver.GuildMemberRemoved
+	(*GuildMemberRenameReq)(nil),   // 35: gserver.GuildMemberRenameReq
+	(*GuildMemberRenameRes)(nil),   // 36: gserver.GuildMemberRenameRes
+	(*GuildMemberUpdate)(nil),      // 37: gserver.GuildMemberUpdate
+	(*GuildProgressData)(nil),      // 38: gserver.GuildProgressData
+	(*GuildContribution)(nil),      // 39: gserver.GuildContribution
+	(*GuildProgressSaveData)(nil),  // 40: gserver.GuildProgressSaveData
+	(*GuildDonateReq)(nil),         // 41: gserver.GuildDonateReq
+	(*GuildDonateRes)(nil),         // 42: gserver.GuildDonateRes
+	(*GuildProgressUpdate)(nil),    // 43: gserver.GuildProgressUpdate
+	(*GuildShopBuyReq)(nil),        // 44: gserver.GuildShopBuyReq
+	(*GuildShopBuyRes)(nil),        // 45: gserver.GuildShopBuyRes
+	nil,                            // 46: gserver.GuildLoadData.MembersEntry
+	nil,                            // 47: gserver.GuildLoadData.JoinRequestsEntry
+	nil,                            // 48: gserver.GuildData.MembersEntry
+	nil,                            // 49: gserver.GuildData.JoinRequestsEntry
+	nil,                            // 50: gserver.GuildData.ContributionsEntry
+	nil,                            // 51: gserver.GuildProgressSaveData.ContributionsEntry
+	(*PlayerGuildData)(nil),        // 52: gserver.PlayerGuildData
+	(*ExchangeRecord)(nil),         // 53: gserver.ExchangeRecord
 }
 var file_guild_proto_depIdxs = []int32{
-	4,  // 0: gserver.GuildLoadData.baseInfo:type_name -> gserver.GuildInfo
-	19, // 1: gserver.GuildLoadData.members:type_name -> gserver.GuildLoadData.MembersEntry
-	20, // 2: gserver.GuildLoadData.joinRequests:type_name -> gserver.GuildLoadData.JoinRequestsEntry
-	4,  // 3: gserver.GuildData.baseInfo:type_name -> gserver.GuildInfo
-	21, // 4: gserver.GuildData.members:type_name -> gserver.GuildData.MembersEntry
-	22, // 5: gserver.GuildData.joinRequests:type_name -> gserver.GuildData.JoinRequestsEntry
-	23, // 6: gserver.GuildSync.data:type_name -> gserver.PlayerGuildData
-	4,  // 7: gserver.GuildListRes.guildInfos:type_name -> gserver.GuildInfo
-	2,  // 8: gserver.GuildDataViewRes.guildData:type_name -> gserver.GuildData
-	3,  // 9: gserver.GuildLoadData.MembersEntry.value:type_name -> gserver.GuildMemberData
-	3,  // 10: gserver.GuildData.MembersEntry.value:type_name -> gserver.GuildMemberData
-	6,  // 11: gserver.GuildData.JoinRequestsEntry.value:type_name -> gserver.GuildJoinRequest
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	5,  // 0: gserver.GuildLoadData.BaseInfo:type_name -> gserver.GuildInfo
+	46, // 1: gserver.GuildLoadData.Members:type_name -> gserver.GuildLoadData.MembersEntry
+	47, // 2: gserver.GuildLoadData.JoinRequests:type_name -> gserver.GuildLoadData.JoinRequestsEntry
+	40, // 3: gserver.GuildLoadData.Progress:type_name -> gserver.GuildProgressSaveData
+	5,  // 4: gserver.GuildData.BaseInfo:type_name -> gserver.GuildInfo
+	48, // 5: gserver.GuildData.Members:type_name -> gserver.GuildData.MembersEntry
+	49, // 6: gserver.GuildData.JoinRequests:type_name -> gserver.GuildData.JoinRequestsEntry
+	38, // 7: gserver.GuildData.Progress:type_name -> gserver.GuildProgressData
+	50, // 8: gserver.GuildData.Contributions:type_name -> gserver.GuildData.ContributionsEntry
+	52, // 9: gserver.GuildSync.Data:type_name -> gserver.PlayerGuildData
+	5,  // 10: gserver.GuildListRes.GuildInfos:type_name -> gserver.GuildInfo
+	5,  // 11: gserver.GuildSearchRes.GuildInfos:type_name -> gserver.GuildInfo
+	3,  // 12: gserver.GuildDataViewRes.GuildData:type_name -> gserver.GuildData
+	4,  // 13: gserver.GuildMemberUpdate.Member:type_name -> gserver.GuildMemberData
+	38, // 14: gserver.GuildProgressSaveData.Base:type_name -> gserver.GuildProgressData
+	51, // 15: gserver.GuildProgressSaveData.Contributions:type_name -> gserver.GuildProgressSaveData.ContributionsEntry
+	38, // 16: gserver.GuildDonateRes.Progress:type_name -> gserver.GuildProgressData
+	39, // 17: gserver.GuildDonateRes.Contribution:type_name -> gserver.GuildContribution
+	38, // 18: gserver.GuildProgressUpdate.Progress:type_name -> gserver.GuildProgressData
+	53, // 19: gserver.GuildShopBuyRes.Record:type_name -> gserver.ExchangeRecord
+	4,  // 20: gserver.GuildLoadData.MembersEntry.value:type_name -> gserver.GuildMemberData
+	4,  // 21: gserver.GuildData.MembersEntry.value:type_name -> gserver.GuildMemberData
+	7,  // 22: gserver.GuildData.JoinRequestsEntry.value:type_name -> gserver.GuildJoinRequest
+	39, // 23: gserver.GuildData.ContributionsEntry.value:type_name -> gserver.GuildContribution
+	39, // 24: gserver.GuildProgressSaveData.ContributionsEntry.value:type_name -> gserver.GuildContribution
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_guild_proto_init() }
@@ -1274,8 +2801,8 @@ func file_guild_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_guild_proto_rawDesc), len(file_guild_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   22,
+			NumEnums:      2,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
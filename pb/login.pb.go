@@ -26,7 +26,10 @@ type LoginReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountName   string                 `protobuf:"bytes,1,opt,name=AccountName,proto3" json:"AccountName,omitempty"`
 	Password      string                 `protobuf:"bytes,2,opt,name=Password,proto3" json:"Password,omitempty"`
-	Version       string                 `protobuf:"bytes,3,opt,name=Version,proto3" json:"Version,omitempty"` // 客户端版本号,如0.0.0.1
+	Version       string                 `protobuf:"bytes,3,opt,name=Version,proto3" json:"Version,omitempty"`           // 客户端版本号,如0.0.0.1
+	RefreshToken  string                 `protobuf:"bytes,4,opt,name=RefreshToken,proto3" json:"RefreshToken,omitempty"` // 不发密码时,使用上次登录返回的RefreshToken登录
+	Provider      string                 `protobuf:"bytes,5,opt,name=Provider,proto3" json:"Provider,omitempty"`         // 登录方式,空表示账号密码登录,如guest(游客)
+	Credential    string                 `protobuf:"bytes,6,opt,name=Credential,proto3" json:"Credential,omitempty"`     // 登录方式对应的凭证,如游客登录的设备id,渠道登录的token
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,15 +85,39 @@ func (x *LoginReq) GetVersion() string {
 	return ""
 }
 
+func (x *LoginReq) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginReq) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LoginReq) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
 // 账号登录回复
 type LoginRes struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountName   string                 `protobuf:"bytes,1,opt,name=AccountName,proto3" json:"AccountName,omitempty"`
-	AccountId     int64                  `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
-	LoginSession  string                 `protobuf:"bytes,3,opt,name=LoginSession,proto3" json:"LoginSession,omitempty"` // 账号验证成功后的缓存session
-	GameServer    *GameServerInfo        `protobuf:"bytes,4,opt,name=GameServer,proto3" json:"GameServer,omitempty"`     // 游戏服信息
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountName     string                 `protobuf:"bytes,1,opt,name=AccountName,proto3" json:"AccountName,omitempty"`
+	AccountId       int64                  `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	LoginSession    string                 `protobuf:"bytes,3,opt,name=LoginSession,proto3" json:"LoginSession,omitempty"`        // 账号验证成功后的缓存session
+	GameServer      *GameServerInfo        `protobuf:"bytes,4,opt,name=GameServer,proto3" json:"GameServer,omitempty"`            // 游戏服信息
+	RefreshToken    string                 `protobuf:"bytes,5,opt,name=RefreshToken,proto3" json:"RefreshToken,omitempty"`        // 长期有效的登录凭证,客户端保存后可以免密码登录,每次登录后更换
+	BanEndTimestamp int64                  `protobuf:"varint,6,opt,name=BanEndTimestamp,proto3" json:"BanEndTimestamp,omitempty"` // 封号的截止时间(ErrorCode_AccountBanned时),0表示永久
+	BanReason       string                 `protobuf:"bytes,7,opt,name=BanReason,proto3" json:"BanReason,omitempty"`              // 封号原因(ErrorCode_AccountBanned时)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *LoginRes) Reset() {
@@ -151,6 +178,96 @@ func (x *LoginRes) GetGameServer() *GameServerInfo {
 	return nil
 }
 
+func (x *LoginRes) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginRes) GetBanEndTimestamp() int64 {
+	if x != nil {
+		return x.BanEndTimestamp
+	}
+	return 0
+}
+
+func (x *LoginRes) GetBanReason() string {
+	if x != nil {
+		return x.BanReason
+	}
+	return ""
+}
+
+// 登录排队的进度,排到后服务器会再发一次LoginRes
+type LoginQueueUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=Position,proto3" json:"Position,omitempty"`       // 排在第几位(从1开始)
+	QueueLength   int32                  `protobuf:"varint,3,opt,name=QueueLength,proto3" json:"QueueLength,omitempty"` // 排队总人数
+	EtaSeconds    int32                  `protobuf:"varint,4,opt,name=EtaSeconds,proto3" json:"EtaSeconds,omitempty"`   // 预计等待秒数(0表示暂时无法估算)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginQueueUpdate) Reset() {
+	*x = LoginQueueUpdate{}
+	mi := &file_login_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginQueueUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginQueueUpdate) ProtoMessage() {}
+
+func (x *LoginQueueUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginQueueUpdate.ProtoReflect.Descriptor instead.
+func (*LoginQueueUpdate) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LoginQueueUpdate) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *LoginQueueUpdate) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *LoginQueueUpdate) GetQueueLength() int32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
+func (x *LoginQueueUpdate) GetEtaSeconds() int32 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
 // 注册账号
 type AccountReg struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -162,7 +279,7 @@ type AccountReg struct {
 
 func (x *AccountReg) Reset() {
 	*x = AccountReg{}
-	mi := &file_login_proto_msgTypes[2]
+	mi := &file_login_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -174,7 +291,7 @@ func (x *AccountReg) String() string {
 func (*AccountReg) ProtoMessage() {}
 
 func (x *AccountReg) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[2]
+	mi := &file_login_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -187,7 +304,7 @@ func (x *AccountReg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountReg.ProtoReflect.Descriptor instead.
 func (*AccountReg) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{2}
+	return file_login_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *AccountReg) GetAccountName() string {
@@ -215,7 +332,7 @@ type AccountRes struct {
 
 func (x *AccountRes) Reset() {
 	*x = AccountRes{}
-	mi := &file_login_proto_msgTypes[3]
+	mi := &file_login_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -227,7 +344,7 @@ func (x *AccountRes) String() string {
 func (*AccountRes) ProtoMessage() {}
 
 func (x *AccountRes) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[3]
+	mi := &file_login_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -240,7 +357,7 @@ func (x *AccountRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountRes.ProtoReflect.Descriptor instead.
 func (*AccountRes) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{3}
+	return file_login_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AccountRes) GetAccountName() string {
@@ -257,6 +374,128 @@ func (x *AccountRes) GetAccountId() int64 {
 	return 0
 }
 
+// 游客账号绑定账号名和密码
+type AccountBindReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=Provider,proto3" json:"Provider,omitempty"`       // 登录方式,目前只支持guest
+	Credential    string                 `protobuf:"bytes,2,opt,name=Credential,proto3" json:"Credential,omitempty"`   // 登录方式对应的凭证
+	AccountName   string                 `protobuf:"bytes,3,opt,name=AccountName,proto3" json:"AccountName,omitempty"` // 绑定的账号名
+	Password      string                 `protobuf:"bytes,4,opt,name=Password,proto3" json:"Password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountBindReq) Reset() {
+	*x = AccountBindReq{}
+	mi := &file_login_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountBindReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountBindReq) ProtoMessage() {}
+
+func (x *AccountBindReq) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountBindReq.ProtoReflect.Descriptor instead.
+func (*AccountBindReq) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AccountBindReq) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *AccountBindReq) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+func (x *AccountBindReq) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *AccountBindReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// 绑定账号回复
+type AccountBindRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountName   string                 `protobuf:"bytes,1,opt,name=AccountName,proto3" json:"AccountName,omitempty"`
+	AccountId     int64                  `protobuf:"varint,2,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountBindRes) Reset() {
+	*x = AccountBindRes{}
+	mi := &file_login_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountBindRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountBindRes) ProtoMessage() {}
+
+func (x *AccountBindRes) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountBindRes.ProtoReflect.Descriptor instead.
+func (*AccountBindRes) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AccountBindRes) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *AccountBindRes) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
 // 游戏服务器信息
 type GameServerInfo struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -268,7 +507,7 @@ type GameServerInfo struct {
 
 func (x *GameServerInfo) Reset() {
 	*x = GameServerInfo{}
-	mi := &file_login_proto_msgTypes[4]
+	mi := &file_login_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -280,7 +519,7 @@ func (x *GameServerInfo) String() string {
 func (*GameServerInfo) ProtoMessage() {}
 
 func (x *GameServerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[4]
+	mi := &file_login_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -293,7 +532,7 @@ func (x *GameServerInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameServerInfo.ProtoReflect.Descriptor instead.
 func (*GameServerInfo) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{4}
+	return file_login_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GameServerInfo) GetServerId() int32 {
@@ -316,13 +555,14 @@ type PlayerEntryGameReq struct {
 	AccountId     int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
 	LoginSession  string                 `protobuf:"bytes,2,opt,name=LoginSession,proto3" json:"LoginSession,omitempty"` // 账号验证成功后的缓存session
 	RegionId      int32                  `protobuf:"varint,3,opt,name=RegionId,proto3" json:"RegionId,omitempty"`        // 区服id
+	PlayerId      int64                  `protobuf:"varint,4,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`        // 选择的角色,0表示该区服最近登录的角色
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PlayerEntryGameReq) Reset() {
 	*x = PlayerEntryGameReq{}
-	mi := &file_login_proto_msgTypes[5]
+	mi := &file_login_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +574,7 @@ func (x *PlayerEntryGameReq) String() string {
 func (*PlayerEntryGameReq) ProtoMessage() {}
 
 func (x *PlayerEntryGameReq) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[5]
+	mi := &file_login_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +587,7 @@ func (x *PlayerEntryGameReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerEntryGameReq.ProtoReflect.Descriptor instead.
 func (*PlayerEntryGameReq) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{5}
+	return file_login_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PlayerEntryGameReq) GetAccountId() int64 {
@@ -371,6 +611,13 @@ func (x *PlayerEntryGameReq) GetRegionId() int32 {
 	return 0
 }
 
+func (x *PlayerEntryGameReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
 // 玩家登录游戏服回复
 type PlayerEntryGameRes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -385,7 +632,7 @@ type PlayerEntryGameRes struct {
 
 func (x *PlayerEntryGameRes) Reset() {
 	*x = PlayerEntryGameRes{}
-	mi := &file_login_proto_msgTypes[6]
+	mi := &file_login_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -397,7 +644,7 @@ func (x *PlayerEntryGameRes) String() string {
 func (*PlayerEntryGameRes) ProtoMessage() {}
 
 func (x *PlayerEntryGameRes) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[6]
+	mi := &file_login_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,7 +657,7 @@ func (x *PlayerEntryGameRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerEntryGameRes.ProtoReflect.Descriptor instead.
 func (*PlayerEntryGameRes) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{6}
+	return file_login_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PlayerEntryGameRes) GetAccountId() int64 {
@@ -462,7 +709,7 @@ type PlayerReconnectGameReq struct {
 
 func (x *PlayerReconnectGameReq) Reset() {
 	*x = PlayerReconnectGameReq{}
-	mi := &file_login_proto_msgTypes[7]
+	mi := &file_login_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -474,7 +721,7 @@ func (x *PlayerReconnectGameReq) String() string {
 func (*PlayerReconnectGameReq) ProtoMessage() {}
 
 func (x *PlayerReconnectGameReq) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[7]
+	mi := &file_login_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,7 +734,7 @@ func (x *PlayerReconnectGameReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerReconnectGameReq.ProtoReflect.Descriptor instead.
 func (*PlayerReconnectGameReq) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{7}
+	return file_login_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PlayerReconnectGameReq) GetAccountId() int64 {
@@ -537,7 +784,7 @@ type PlayerReconnectGameRes struct {
 
 func (x *PlayerReconnectGameRes) Reset() {
 	*x = PlayerReconnectGameRes{}
-	mi := &file_login_proto_msgTypes[8]
+	mi := &file_login_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -549,7 +796,7 @@ func (x *PlayerReconnectGameRes) String() string {
 func (*PlayerReconnectGameRes) ProtoMessage() {}
 
 func (x *PlayerReconnectGameRes) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[8]
+	mi := &file_login_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -562,7 +809,7 @@ func (x *PlayerReconnectGameRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerReconnectGameRes.ProtoReflect.Descriptor instead.
 func (*PlayerReconnectGameRes) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{8}
+	return file_login_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PlayerReconnectGameRes) GetAccountId() int64 {
@@ -600,7 +847,7 @@ type CreatePlayerReq struct {
 
 func (x *CreatePlayerReq) Reset() {
 	*x = CreatePlayerReq{}
-	mi := &file_login_proto_msgTypes[9]
+	mi := &file_login_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -612,7 +859,7 @@ func (x *CreatePlayerReq) String() string {
 func (*CreatePlayerReq) ProtoMessage() {}
 
 func (x *CreatePlayerReq) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[9]
+	mi := &file_login_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -625,7 +872,7 @@ func (x *CreatePlayerReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePlayerReq.ProtoReflect.Descriptor instead.
 func (*CreatePlayerReq) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{9}
+	return file_login_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CreatePlayerReq) GetAccountId() int64 {
@@ -669,13 +916,14 @@ type CreatePlayerRes struct {
 	AccountId     int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
 	RegionId      int32                  `protobuf:"varint,2,opt,name=RegionId,proto3" json:"RegionId,omitempty"` // 区服id
 	Name          string                 `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`          // 玩家名
+	PlayerId      int64                  `protobuf:"varint,4,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreatePlayerRes) Reset() {
 	*x = CreatePlayerRes{}
-	mi := &file_login_proto_msgTypes[10]
+	mi := &file_login_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -687,7 +935,7 @@ func (x *CreatePlayerRes) String() string {
 func (*CreatePlayerRes) ProtoMessage() {}
 
 func (x *CreatePlayerRes) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[10]
+	mi := &file_login_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,7 +948,7 @@ func (x *CreatePlayerRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePlayerRes.ProtoReflect.Descriptor instead.
 func (*CreatePlayerRes) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{10}
+	return file_login_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CreatePlayerRes) GetAccountId() int64 {
@@ -724,29 +972,42 @@ func (x *CreatePlayerRes) GetName() string {
 	return ""
 }
 
-// 测试命令
-type TestCmd struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cmd           string                 `protobuf:"bytes,1,opt,name=Cmd,proto3" json:"Cmd,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreatePlayerRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
 }
 
-func (x *TestCmd) Reset() {
-	*x = TestCmd{}
-	mi := &file_login_proto_msgTypes[11]
+// 角色概要信息
+type CharacterSummary struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId           int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	RegionId           int32                  `protobuf:"varint,3,opt,name=RegionId,proto3" json:"RegionId,omitempty"` // 区服id
+	Level              int32                  `protobuf:"varint,4,opt,name=Level,proto3" json:"Level,omitempty"`
+	LastLoginTimestamp int64                  `protobuf:"varint,5,opt,name=LastLoginTimestamp,proto3" json:"LastLoginTimestamp,omitempty"` // 最近一次登录时间戳
+	DeleteTimestamp    int64                  `protobuf:"varint,6,opt,name=DeleteTimestamp,proto3" json:"DeleteTimestamp,omitempty"`       // 删除时间戳,0表示没有删除
+	RecoverDeadline    int64                  `protobuf:"varint,7,opt,name=RecoverDeadline,proto3" json:"RecoverDeadline,omitempty"`       // 已删除的角色,在这个时间戳之前可以恢复
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CharacterSummary) Reset() {
+	*x = CharacterSummary{}
+	mi := &file_login_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TestCmd) String() string {
+func (x *CharacterSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestCmd) ProtoMessage() {}
+func (*CharacterSummary) ProtoMessage() {}
 
-func (x *TestCmd) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[11]
+func (x *CharacterSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,9 +1018,444 @@ func (x *TestCmd) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestCmd.ProtoReflect.Descriptor instead.
-func (*TestCmd) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CharacterSummary.ProtoReflect.Descriptor instead.
+func (*CharacterSummary) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CharacterSummary) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *CharacterSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CharacterSummary) GetRegionId() int32 {
+	if x != nil {
+		return x.RegionId
+	}
+	return 0
+}
+
+func (x *CharacterSummary) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *CharacterSummary) GetLastLoginTimestamp() int64 {
+	if x != nil {
+		return x.LastLoginTimestamp
+	}
+	return 0
+}
+
+func (x *CharacterSummary) GetDeleteTimestamp() int64 {
+	if x != nil {
+		return x.DeleteTimestamp
+	}
+	return 0
+}
+
+func (x *CharacterSummary) GetRecoverDeadline() int64 {
+	if x != nil {
+		return x.RecoverDeadline
+	}
+	return 0
+}
+
+// 获取账号在所有区服的角色列表
+type CharacterListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	LoginSession  string                 `protobuf:"bytes,2,opt,name=LoginSession,proto3" json:"LoginSession,omitempty"` // 账号验证成功后的缓存session
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CharacterListReq) Reset() {
+	*x = CharacterListReq{}
+	mi := &file_login_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CharacterListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CharacterListReq) ProtoMessage() {}
+
+func (x *CharacterListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CharacterListReq.ProtoReflect.Descriptor instead.
+func (*CharacterListReq) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CharacterListReq) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CharacterListReq) GetLoginSession() string {
+	if x != nil {
+		return x.LoginSession
+	}
+	return ""
+}
+
+// 角色列表,包含可以恢复的已删除角色
+type CharacterListRes struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	AccountId              int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	Characters             []*CharacterSummary    `protobuf:"bytes,2,rep,name=Characters,proto3" json:"Characters,omitempty"`
+	MaxCharactersPerRegion int32                  `protobuf:"varint,3,opt,name=MaxCharactersPerRegion,proto3" json:"MaxCharactersPerRegion,omitempty"` // 每个区服最多可以创建的角色数
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CharacterListRes) Reset() {
+	*x = CharacterListRes{}
+	mi := &file_login_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CharacterListRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CharacterListRes) ProtoMessage() {}
+
+func (x *CharacterListRes) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CharacterListRes.ProtoReflect.Descriptor instead.
+func (*CharacterListRes) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CharacterListRes) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CharacterListRes) GetCharacters() []*CharacterSummary {
+	if x != nil {
+		return x.Characters
+	}
+	return nil
+}
+
+func (x *CharacterListRes) GetMaxCharactersPerRegion() int32 {
+	if x != nil {
+		return x.MaxCharactersPerRegion
+	}
+	return 0
+}
+
+// 删除角色,在恢复期内可以恢复
+type DeletePlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	LoginSession  string                 `protobuf:"bytes,2,opt,name=LoginSession,proto3" json:"LoginSession,omitempty"`
+	PlayerId      int64                  `protobuf:"varint,3,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePlayerReq) Reset() {
+	*x = DeletePlayerReq{}
+	mi := &file_login_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePlayerReq) ProtoMessage() {}
+
+func (x *DeletePlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePlayerReq.ProtoReflect.Descriptor instead.
+func (*DeletePlayerReq) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeletePlayerReq) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *DeletePlayerReq) GetLoginSession() string {
+	if x != nil {
+		return x.LoginSession
+	}
+	return ""
+}
+
+func (x *DeletePlayerReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+type DeletePlayerRes struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	PlayerId        int64                  `protobuf:"varint,2,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	RecoverDeadline int64                  `protobuf:"varint,3,opt,name=RecoverDeadline,proto3" json:"RecoverDeadline,omitempty"` // 在这个时间戳之前可以恢复
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeletePlayerRes) Reset() {
+	*x = DeletePlayerRes{}
+	mi := &file_login_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePlayerRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePlayerRes) ProtoMessage() {}
+
+func (x *DeletePlayerRes) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePlayerRes.ProtoReflect.Descriptor instead.
+func (*DeletePlayerRes) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeletePlayerRes) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *DeletePlayerRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *DeletePlayerRes) GetRecoverDeadline() int64 {
+	if x != nil {
+		return x.RecoverDeadline
+	}
+	return 0
+}
+
+// 恢复已删除的角色
+type RecoverPlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	LoginSession  string                 `protobuf:"bytes,2,opt,name=LoginSession,proto3" json:"LoginSession,omitempty"`
+	PlayerId      int64                  `protobuf:"varint,3,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoverPlayerReq) Reset() {
+	*x = RecoverPlayerReq{}
+	mi := &file_login_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoverPlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoverPlayerReq) ProtoMessage() {}
+
+func (x *RecoverPlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoverPlayerReq.ProtoReflect.Descriptor instead.
+func (*RecoverPlayerReq) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecoverPlayerReq) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *RecoverPlayerReq) GetLoginSession() string {
+	if x != nil {
+		return x.LoginSession
+	}
+	return ""
+}
+
+func (x *RecoverPlayerReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+type RecoverPlayerRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=AccountId,proto3" json:"AccountId,omitempty"`
+	PlayerId      int64                  `protobuf:"varint,2,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoverPlayerRes) Reset() {
+	*x = RecoverPlayerRes{}
+	mi := &file_login_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoverPlayerRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoverPlayerRes) ProtoMessage() {}
+
+func (x *RecoverPlayerRes) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoverPlayerRes.ProtoReflect.Descriptor instead.
+func (*RecoverPlayerRes) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RecoverPlayerRes) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *RecoverPlayerRes) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 测试命令
+type TestCmd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cmd           string                 `protobuf:"bytes,1,opt,name=Cmd,proto3" json:"Cmd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestCmd) Reset() {
+	*x = TestCmd{}
+	mi := &file_login_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestCmd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCmd) ProtoMessage() {}
+
+func (x *TestCmd) ProtoReflect() protoreflect.Message {
+	mi := &file_login_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCmd.ProtoReflect.Descriptor instead.
+func (*TestCmd) Descriptor() ([]byte, []int) {
+	return file_login_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *TestCmd) GetCmd() string {
@@ -779,7 +1475,7 @@ type TestRes struct {
 
 func (x *TestRes) Reset() {
 	*x = TestRes{}
-	mi := &file_login_proto_msgTypes[12]
+	mi := &file_login_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -791,7 +1487,7 @@ func (x *TestRes) String() string {
 func (*TestRes) ProtoMessage() {}
 
 func (x *TestRes) ProtoReflect() protoreflect.Message {
-	mi := &file_login_proto_msgTypes[12]
+	mi := &file_login_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,7 +1500,7 @@ func (x *TestRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TestRes.ProtoReflect.Descriptor instead.
 func (*TestRes) Descriptor() ([]byte, []int) {
-	return file_login_proto_rawDescGZIP(), []int{12}
+	return file_login_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *TestRes) GetResult() string {
@@ -818,18 +1514,33 @@ var File_login_proto protoreflect.FileDescriptor
 
 const file_login_proto_rawDesc = "" +
 	"\n" +
-	"\vlogin.proto\x12\agserver\x1a\fplayer.proto\"b\n" +
+	"\vlogin.proto\x12\agserver\x1a\fplayer.proto\"\xc2\x01\n" +
 	"\bLoginReq\x12 \n" +
 	"\vAccountName\x18\x01 \x01(\tR\vAccountName\x12\x1a\n" +
 	"\bPassword\x18\x02 \x01(\tR\bPassword\x12\x18\n" +
-	"\aVersion\x18\x03 \x01(\tR\aVersion\"\xa7\x01\n" +
+	"\aVersion\x18\x03 \x01(\tR\aVersion\x12\"\n" +
+	"\fRefreshToken\x18\x04 \x01(\tR\fRefreshToken\x12\x1a\n" +
+	"\bProvider\x18\x05 \x01(\tR\bProvider\x12\x1e\n" +
+	"\n" +
+	"Credential\x18\x06 \x01(\tR\n" +
+	"Credential\"\x93\x02\n" +
 	"\bLoginRes\x12 \n" +
 	"\vAccountName\x18\x01 \x01(\tR\vAccountName\x12\x1c\n" +
 	"\tAccountId\x18\x02 \x01(\x03R\tAccountId\x12\"\n" +
 	"\fLoginSession\x18\x03 \x01(\tR\fLoginSession\x127\n" +
 	"\n" +
 	"GameServer\x18\x04 \x01(\v2\x17.gserver.GameServerInfoR\n" +
-	"GameServer\"J\n" +
+	"GameServer\x12\"\n" +
+	"\fRefreshToken\x18\x05 \x01(\tR\fRefreshToken\x12(\n" +
+	"\x0fBanEndTimestamp\x18\x06 \x01(\x03R\x0fBanEndTimestamp\x12\x1c\n" +
+	"\tBanReason\x18\a \x01(\tR\tBanReason\"\x8e\x01\n" +
+	"\x10LoginQueueUpdate\x12\x1c\n" +
+	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\x1a\n" +
+	"\bPosition\x18\x02 \x01(\x05R\bPosition\x12 \n" +
+	"\vQueueLength\x18\x03 \x01(\x05R\vQueueLength\x12\x1e\n" +
+	"\n" +
+	"EtaSeconds\x18\x04 \x01(\x05R\n" +
+	"EtaSeconds\"J\n" +
 	"\n" +
 	"AccountReg\x12 \n" +
 	"\vAccountName\x18\x01 \x01(\tR\vAccountName\x12\x1a\n" +
@@ -837,14 +1548,25 @@ const file_login_proto_rawDesc = "" +
 	"\n" +
 	"AccountRes\x12 \n" +
 	"\vAccountName\x18\x01 \x01(\tR\vAccountName\x12\x1c\n" +
+	"\tAccountId\x18\x02 \x01(\x03R\tAccountId\"\x8a\x01\n" +
+	"\x0eAccountBindReq\x12\x1a\n" +
+	"\bProvider\x18\x01 \x01(\tR\bProvider\x12\x1e\n" +
+	"\n" +
+	"Credential\x18\x02 \x01(\tR\n" +
+	"Credential\x12 \n" +
+	"\vAccountName\x18\x03 \x01(\tR\vAccountName\x12\x1a\n" +
+	"\bPassword\x18\x04 \x01(\tR\bPassword\"P\n" +
+	"\x0eAccountBindRes\x12 \n" +
+	"\vAccountName\x18\x01 \x01(\tR\vAccountName\x12\x1c\n" +
 	"\tAccountId\x18\x02 \x01(\x03R\tAccountId\"X\n" +
 	"\x0eGameServerInfo\x12\x1a\n" +
 	"\bServerId\x18\x01 \x01(\x05R\bServerId\x12*\n" +
-	"\x10ClientListenAddr\x18\x02 \x01(\tR\x10ClientListenAddr\"r\n" +
+	"\x10ClientListenAddr\x18\x02 \x01(\tR\x10ClientListenAddr\"\x8e\x01\n" +
 	"\x12PlayerEntryGameReq\x12\x1c\n" +
 	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\"\n" +
 	"\fLoginSession\x18\x02 \x01(\tR\fLoginSession\x12\x1a\n" +
-	"\bRegionId\x18\x03 \x01(\x05R\bRegionId\"\xae\x01\n" +
+	"\bRegionId\x18\x03 \x01(\x05R\bRegionId\x12\x1a\n" +
+	"\bPlayerId\x18\x04 \x01(\x03R\bPlayerId\"\xae\x01\n" +
 	"\x12PlayerEntryGameRes\x12\x1c\n" +
 	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\x1a\n" +
 	"\bPlayerId\x18\x02 \x01(\x03R\bPlayerId\x12\x1a\n" +
@@ -868,11 +1590,44 @@ const file_login_proto_rawDesc = "" +
 	"\fLoginSession\x18\x02 \x01(\tR\fLoginSession\x12\x1a\n" +
 	"\bRegionId\x18\x03 \x01(\x05R\bRegionId\x12\x12\n" +
 	"\x04Name\x18\x04 \x01(\tR\x04Name\x12\x16\n" +
-	"\x06Gender\x18\x05 \x01(\x05R\x06Gender\"_\n" +
+	"\x06Gender\x18\x05 \x01(\x05R\x06Gender\"{\n" +
 	"\x0fCreatePlayerRes\x12\x1c\n" +
 	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\x1a\n" +
 	"\bRegionId\x18\x02 \x01(\x05R\bRegionId\x12\x12\n" +
-	"\x04Name\x18\x03 \x01(\tR\x04Name\"\x1b\n" +
+	"\x04Name\x18\x03 \x01(\tR\x04Name\x12\x1a\n" +
+	"\bPlayerId\x18\x04 \x01(\x03R\bPlayerId\"\xf8\x01\n" +
+	"\x10CharacterSummary\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x1a\n" +
+	"\bRegionId\x18\x03 \x01(\x05R\bRegionId\x12\x14\n" +
+	"\x05Level\x18\x04 \x01(\x05R\x05Level\x12.\n" +
+	"\x12LastLoginTimestamp\x18\x05 \x01(\x03R\x12LastLoginTimestamp\x12(\n" +
+	"\x0fDeleteTimestamp\x18\x06 \x01(\x03R\x0fDeleteTimestamp\x12(\n" +
+	"\x0fRecoverDeadline\x18\a \x01(\x03R\x0fRecoverDeadline\"T\n" +
+	"\x10CharacterListReq\x12\x1c\n" +
+	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\"\n" +
+	"\fLoginSession\x18\x02 \x01(\tR\fLoginSession\"\xa3\x01\n" +
+	"\x10CharacterListRes\x12\x1c\n" +
+	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x129\n" +
+	"\n" +
+	"Characters\x18\x02 \x03(\v2\x19.gserver.CharacterSummaryR\n" +
+	"Characters\x126\n" +
+	"\x16MaxCharactersPerRegion\x18\x03 \x01(\x05R\x16MaxCharactersPerRegion\"o\n" +
+	"\x0fDeletePlayerReq\x12\x1c\n" +
+	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\"\n" +
+	"\fLoginSession\x18\x02 \x01(\tR\fLoginSession\x12\x1a\n" +
+	"\bPlayerId\x18\x03 \x01(\x03R\bPlayerId\"u\n" +
+	"\x0fDeletePlayerRes\x12\x1c\n" +
+	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\x1a\n" +
+	"\bPlayerId\x18\x02 \x01(\x03R\bPlayerId\x12(\n" +
+	"\x0fRecoverDeadline\x18\x03 \x01(\x03R\x0fRecoverDeadline\"p\n" +
+	"\x10RecoverPlayerReq\x12\x1c\n" +
+	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\"\n" +
+	"\fLoginSession\x18\x02 \x01(\tR\fLoginSession\x12\x1a\n" +
+	"\bPlayerId\x18\x03 \x01(\x03R\bPlayerId\"L\n" +
+	"\x10RecoverPlayerRes\x12\x1c\n" +
+	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\x1a\n" +
+	"\bPlayerId\x18\x02 \x01(\x03R\bPlayerId\"\x1b\n" +
 	"\aTestCmd\x12\x10\n" +
 	"\x03Cmd\x18\x01 \x01(\tR\x03Cmd\"!\n" +
 	"\aTestRes\x12\x16\n" +
@@ -890,29 +1645,40 @@ func file_login_proto_rawDescGZIP() []byte {
 	return file_login_proto_rawDescData
 }
 
-var file_login_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_login_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_login_proto_goTypes = []any{
 	(*LoginReq)(nil),               // 0: gserver.LoginReq
 	(*LoginRes)(nil),               // 1: gserver.LoginRes
-	(*AccountReg)(nil),             // 2: gserver.AccountReg
-	(*AccountRes)(nil),             // 3: gserver.AccountRes
-	(*GameServerInfo)(nil),         // 4: gserver.GameServerInfo
-	(*PlayerEntryGameReq)(nil),     // 5: gserver.PlayerEntryGameReq
-	(*PlayerEntryGameRes)(nil),     // 6: gserver.PlayerEntryGameRes
-	(*PlayerReconnectGameReq)(nil), // 7: gserver.PlayerReconnectGameReq
-	(*PlayerReconnectGameRes)(nil), // 8: gserver.PlayerReconnectGameRes
-	(*CreatePlayerReq)(nil),        // 9: gserver.CreatePlayerReq
-	(*CreatePlayerRes)(nil),        // 10: gserver.CreatePlayerRes
-	(*TestCmd)(nil),                // 11: gserver.TestCmd
-	(*TestRes)(nil),                // 12: gserver.TestRes
+	(*LoginQueueUpdate)(nil),       // 2: gserver.LoginQueueUpdate
+	(*AccountReg)(nil),             // 3: gserver.AccountReg
+	(*AccountRes)(nil),             // 4: gserver.AccountRes
+	(*AccountBindReq)(nil),         // 5: gserver.AccountBindReq
+	(*AccountBindRes)(nil),         // 6: gserver.AccountBindRes
+	(*GameServerInfo)(nil),         // 7: gserver.GameServerInfo
+	(*PlayerEntryGameReq)(nil),     // 8: gserver.PlayerEntryGameReq
+	(*PlayerEntryGameRes)(nil),     // 9: gserver.PlayerEntryGameRes
+	(*PlayerReconnectGameReq)(nil), // 10: gserver.PlayerReconnectGameReq
+	(*PlayerReconnectGameRes)(nil), // 11: gserver.PlayerReconnectGameRes
+	(*CreatePlayerReq)(nil),        // 12: gserver.CreatePlayerReq
+	(*CreatePlayerRes)(nil),        // 13: gserver.CreatePlayerRes
+	(*CharacterSummary)(nil),       // 14: gserver.CharacterSummary
+	(*CharacterListReq)(nil),       // 15: gserver.CharacterListReq
+	(*CharacterListRes)(nil),       // 16: gserver.CharacterListRes
+	(*DeletePlayerReq)(nil),        // 17: gserver.DeletePlayerReq
+	(*DeletePlayerRes)(nil),        // 18: gserver.DeletePlayerRes
+	(*RecoverPlayerReq)(nil),       // 19: gserver.RecoverPlayerReq
+	(*RecoverPlayerRes)(nil),       // 20: gserver.RecoverPlayerRes
+	(*TestCmd)(nil),                // 21: gserver.TestCmd
+	(*TestRes)(nil),                // 22: gserver.TestRes
 }
 var file_login_proto_depIdxs = []int32{
-	4, // 0: gserver.LoginRes.GameServer:type_name -> gserver.GameServerInfo
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	7,  // 0: gserver.LoginRes.GameServer:type_name -> gserver.GameServerInfo
+	14, // 1: gserver.CharacterListRes.Characters:type_name -> gserver.CharacterSummary
+	2,  // [2:2] is the sub-list for method output_type
+	2,  // [2:2] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_login_proto_init() }
@@ -927,7 +1693,7 @@ func file_login_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_login_proto_rawDesc), len(file_login_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
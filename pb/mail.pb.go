@@ -0,0 +1,968 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v4.25.9
+// source: mail.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 邮件数据
+type MailData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailId        int64                  `protobuf:"varint,1,opt,name=MailId,proto3" json:"MailId,omitempty"`             // 邮件唯一id
+	Title         string                 `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`                // 标题
+	Content       string                 `protobuf:"bytes,3,opt,name=Content,proto3" json:"Content,omitempty"`            // 正文
+	Attachments   []*AddElemArg          `protobuf:"bytes,4,rep,name=Attachments,proto3" json:"Attachments,omitempty"`    // 附件
+	SendTime      int32                  `protobuf:"varint,5,opt,name=SendTime,proto3" json:"SendTime,omitempty"`         // 发送时间戳(秒)
+	ExpireTime    int32                  `protobuf:"varint,6,opt,name=ExpireTime,proto3" json:"ExpireTime,omitempty"`     // 过期时间戳(秒),0表示不过期
+	FromPlayerId  int64                  `protobuf:"varint,7,opt,name=FromPlayerId,proto3" json:"FromPlayerId,omitempty"` // 发件人id,0表示系统邮件
+	FromName      string                 `protobuf:"bytes,8,opt,name=FromName,proto3" json:"FromName,omitempty"`          // 发件人名字
+	IsRead        bool                   `protobuf:"varint,9,opt,name=IsRead,proto3" json:"IsRead,omitempty"`             // 是否已读
+	IsClaimed     bool                   `protobuf:"varint,10,opt,name=IsClaimed,proto3" json:"IsClaimed,omitempty"`      // 附件是否已领取
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailData) Reset() {
+	*x = MailData{}
+	mi := &file_mail_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailData) ProtoMessage() {}
+
+func (x *MailData) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailData.ProtoReflect.Descriptor instead.
+func (*MailData) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MailData) GetMailId() int64 {
+	if x != nil {
+		return x.MailId
+	}
+	return 0
+}
+
+func (x *MailData) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MailData) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *MailData) GetAttachments() []*AddElemArg {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *MailData) GetSendTime() int32 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+func (x *MailData) GetExpireTime() int32 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+func (x *MailData) GetFromPlayerId() int64 {
+	if x != nil {
+		return x.FromPlayerId
+	}
+	return 0
+}
+
+func (x *MailData) GetFromName() string {
+	if x != nil {
+		return x.FromName
+	}
+	return ""
+}
+
+func (x *MailData) GetIsRead() bool {
+	if x != nil {
+		return x.IsRead
+	}
+	return false
+}
+
+func (x *MailData) GetIsClaimed() bool {
+	if x != nil {
+		return x.IsClaimed
+	}
+	return false
+}
+
+// 同步邮件数据给客户端
+type MailSync struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mails         map[int64]*MailData    `protobuf:"bytes,1,rep,name=Mails,proto3" json:"Mails,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailSync) Reset() {
+	*x = MailSync{}
+	mi := &file_mail_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailSync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailSync) ProtoMessage() {}
+
+func (x *MailSync) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailSync.ProtoReflect.Descriptor instead.
+func (*MailSync) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MailSync) GetMails() map[int64]*MailData {
+	if x != nil {
+		return x.Mails
+	}
+	return nil
+}
+
+// 收到一封新邮件
+// 由发件方通过RoutePlayerPacket(WithSaveDb())路由给收件人,收件人处理后再转发给客户端
+type MailAdd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mail          *MailData              `protobuf:"bytes,1,opt,name=Mail,proto3" json:"Mail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailAdd) Reset() {
+	*x = MailAdd{}
+	mi := &file_mail_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailAdd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailAdd) ProtoMessage() {}
+
+func (x *MailAdd) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailAdd.ProtoReflect.Descriptor instead.
+func (*MailAdd) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MailAdd) GetMail() *MailData {
+	if x != nil {
+		return x.Mail
+	}
+	return nil
+}
+
+// 邮件删除(过期或玩家主动删除)
+type MailRemove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailIds       []int64                `protobuf:"varint,1,rep,packed,name=MailIds,proto3" json:"MailIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailRemove) Reset() {
+	*x = MailRemove{}
+	mi := &file_mail_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailRemove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailRemove) ProtoMessage() {}
+
+func (x *MailRemove) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailRemove.ProtoReflect.Descriptor instead.
+func (*MailRemove) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MailRemove) GetMailIds() []int64 {
+	if x != nil {
+		return x.MailIds
+	}
+	return nil
+}
+
+// 阅读邮件req
+type MailReadReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailId        int64                  `protobuf:"varint,1,opt,name=MailId,proto3" json:"MailId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailReadReq) Reset() {
+	*x = MailReadReq{}
+	mi := &file_mail_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailReadReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailReadReq) ProtoMessage() {}
+
+func (x *MailReadReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailReadReq.ProtoReflect.Descriptor instead.
+func (*MailReadReq) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MailReadReq) GetMailId() int64 {
+	if x != nil {
+		return x.MailId
+	}
+	return 0
+}
+
+// 阅读邮件res
+type MailReadRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailId        int64                  `protobuf:"varint,1,opt,name=MailId,proto3" json:"MailId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailReadRes) Reset() {
+	*x = MailReadRes{}
+	mi := &file_mail_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailReadRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailReadRes) ProtoMessage() {}
+
+func (x *MailReadRes) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailReadRes.ProtoReflect.Descriptor instead.
+func (*MailReadRes) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MailReadRes) GetMailId() int64 {
+	if x != nil {
+		return x.MailId
+	}
+	return 0
+}
+
+// 领取邮件附件req
+type MailClaimReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailIds       []int64                `protobuf:"varint,1,rep,packed,name=MailIds,proto3" json:"MailIds,omitempty"` // 支持批量领取
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailClaimReq) Reset() {
+	*x = MailClaimReq{}
+	mi := &file_mail_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailClaimReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailClaimReq) ProtoMessage() {}
+
+func (x *MailClaimReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailClaimReq.ProtoReflect.Descriptor instead.
+func (*MailClaimReq) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MailClaimReq) GetMailIds() []int64 {
+	if x != nil {
+		return x.MailIds
+	}
+	return nil
+}
+
+// 领取邮件附件res
+type MailClaimRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailIds       []int64                `protobuf:"varint,1,rep,packed,name=MailIds,proto3" json:"MailIds,omitempty"` // 领取成功的邮件id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailClaimRes) Reset() {
+	*x = MailClaimRes{}
+	mi := &file_mail_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailClaimRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailClaimRes) ProtoMessage() {}
+
+func (x *MailClaimRes) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailClaimRes.ProtoReflect.Descriptor instead.
+func (*MailClaimRes) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MailClaimRes) GetMailIds() []int64 {
+	if x != nil {
+		return x.MailIds
+	}
+	return nil
+}
+
+// 删除邮件req
+type MailDeleteReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailIds       []int64                `protobuf:"varint,1,rep,packed,name=MailIds,proto3" json:"MailIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailDeleteReq) Reset() {
+	*x = MailDeleteReq{}
+	mi := &file_mail_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailDeleteReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailDeleteReq) ProtoMessage() {}
+
+func (x *MailDeleteReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailDeleteReq.ProtoReflect.Descriptor instead.
+func (*MailDeleteReq) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MailDeleteReq) GetMailIds() []int64 {
+	if x != nil {
+		return x.MailIds
+	}
+	return nil
+}
+
+// 删除邮件res
+type MailDeleteRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MailIds       []int64                `protobuf:"varint,1,rep,packed,name=MailIds,proto3" json:"MailIds,omitempty"` // 删除成功的邮件id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailDeleteRes) Reset() {
+	*x = MailDeleteRes{}
+	mi := &file_mail_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailDeleteRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailDeleteRes) ProtoMessage() {}
+
+func (x *MailDeleteRes) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailDeleteRes.ProtoReflect.Descriptor instead.
+func (*MailDeleteRes) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MailDeleteRes) GetMailIds() []int64 {
+	if x != nil {
+		return x.MailIds
+	}
+	return nil
+}
+
+// 邮件模块数据
+type MailSaveData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mails         map[int64][]byte       `protobuf:"bytes,1,rep,name=Mails,proto3" json:"Mails,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // map<int64,*MailData>
+	SystemMail    *MailSystemData        `protobuf:"bytes,2,opt,name=SystemMail,proto3" json:"SystemMail,omitempty"`                                                                  // 系统邮件的拉取记录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MailSaveData) Reset() {
+	*x = MailSaveData{}
+	mi := &file_mail_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailSaveData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailSaveData) ProtoMessage() {}
+
+func (x *MailSaveData) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailSaveData.ProtoReflect.Descriptor instead.
+func (*MailSaveData) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MailSaveData) GetMails() map[int64][]byte {
+	if x != nil {
+		return x.Mails
+	}
+	return nil
+}
+
+func (x *MailSaveData) GetSystemMail() *MailSystemData {
+	if x != nil {
+		return x.SystemMail
+	}
+	return nil
+}
+
+// 玩家的系统邮件拉取记录
+type MailSystemData struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	LastSystemMailId int64                  `protobuf:"varint,1,opt,name=LastSystemMailId,proto3" json:"LastSystemMailId,omitempty"` // 已拉取过的最大系统邮件id
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MailSystemData) Reset() {
+	*x = MailSystemData{}
+	mi := &file_mail_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailSystemData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailSystemData) ProtoMessage() {}
+
+func (x *MailSystemData) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailSystemData.ProtoReflect.Descriptor instead.
+func (*MailSystemData) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MailSystemData) GetLastSystemMailId() int64 {
+	if x != nil {
+		return x.LastSystemMailId
+	}
+	return 0
+}
+
+// 系统邮件的目标玩家过滤条件,字段为0表示不限制
+type SystemMailFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLevel      int32                  `protobuf:"varint,1,opt,name=MinLevel,proto3" json:"MinLevel,omitempty"`           // 最小等级
+	MaxLevel      int32                  `protobuf:"varint,2,opt,name=MaxLevel,proto3" json:"MaxLevel,omitempty"`           // 最大等级
+	MinCreateTime int64                  `protobuf:"varint,3,opt,name=MinCreateTime,proto3" json:"MinCreateTime,omitempty"` // 创建角色时间戳下限(秒)
+	MaxCreateTime int64                  `protobuf:"varint,4,opt,name=MaxCreateTime,proto3" json:"MaxCreateTime,omitempty"` // 创建角色时间戳上限(秒)
+	RegionId      int32                  `protobuf:"varint,5,opt,name=RegionId,proto3" json:"RegionId,omitempty"`           // 区服id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemMailFilter) Reset() {
+	*x = SystemMailFilter{}
+	mi := &file_mail_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemMailFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemMailFilter) ProtoMessage() {}
+
+func (x *SystemMailFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemMailFilter.ProtoReflect.Descriptor instead.
+func (*SystemMailFilter) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SystemMailFilter) GetMinLevel() int32 {
+	if x != nil {
+		return x.MinLevel
+	}
+	return 0
+}
+
+func (x *SystemMailFilter) GetMaxLevel() int32 {
+	if x != nil {
+		return x.MaxLevel
+	}
+	return 0
+}
+
+func (x *SystemMailFilter) GetMinCreateTime() int64 {
+	if x != nil {
+		return x.MinCreateTime
+	}
+	return 0
+}
+
+func (x *SystemMailFilter) GetMaxCreateTime() int64 {
+	if x != nil {
+		return x.MaxCreateTime
+	}
+	return 0
+}
+
+func (x *SystemMailFilter) GetRegionId() int32 {
+	if x != nil {
+		return x.RegionId
+	}
+	return 0
+}
+
+// 系统邮件(全服邮件)
+type SystemMailData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SystemMailId  int64                  `protobuf:"varint,1,opt,name=SystemMailId,proto3" json:"SystemMailId,omitempty"` // 全局递增的id,玩家按该id记录拉取水位
+	Mail          *MailData              `protobuf:"bytes,2,opt,name=Mail,proto3" json:"Mail,omitempty"`                  // 邮件内容
+	Filter        *SystemMailFilter      `protobuf:"bytes,3,opt,name=Filter,proto3" json:"Filter,omitempty"`              // 目标玩家过滤条件
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemMailData) Reset() {
+	*x = SystemMailData{}
+	mi := &file_mail_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemMailData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemMailData) ProtoMessage() {}
+
+func (x *SystemMailData) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemMailData.ProtoReflect.Descriptor instead.
+func (*SystemMailData) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SystemMailData) GetSystemMailId() int64 {
+	if x != nil {
+		return x.SystemMailId
+	}
+	return 0
+}
+
+func (x *SystemMailData) GetMail() *MailData {
+	if x != nil {
+		return x.Mail
+	}
+	return nil
+}
+
+func (x *SystemMailData) GetFilter() *SystemMailFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// 新增系统邮件,发给每个game服的GlobalEntity
+type SystemMailAdd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *SystemMailData        `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemMailAdd) Reset() {
+	*x = SystemMailAdd{}
+	mi := &file_mail_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemMailAdd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemMailAdd) ProtoMessage() {}
+
+func (x *SystemMailAdd) ProtoReflect() protoreflect.Message {
+	mi := &file_mail_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemMailAdd.ProtoReflect.Descriptor instead.
+func (*SystemMailAdd) Descriptor() ([]byte, []int) {
+	return file_mail_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SystemMailAdd) GetData() *SystemMailData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_mail_proto protoreflect.FileDescriptor
+
+const file_mail_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"mail.proto\x12\agserver\x1a\tcfg.proto\"\xbb\x02\n" +
+	"\bMailData\x12\x16\n" +
+	"\x06MailId\x18\x01 \x01(\x03R\x06MailId\x12\x14\n" +
+	"\x05Title\x18\x02 \x01(\tR\x05Title\x12\x18\n" +
+	"\aContent\x18\x03 \x01(\tR\aContent\x125\n" +
+	"\vAttachments\x18\x04 \x03(\v2\x13.gserver.AddElemArgR\vAttachments\x12\x1a\n" +
+	"\bSendTime\x18\x05 \x01(\x05R\bSendTime\x12\x1e\n" +
+	"\n" +
+	"ExpireTime\x18\x06 \x01(\x05R\n" +
+	"ExpireTime\x12\"\n" +
+	"\fFromPlayerId\x18\a \x01(\x03R\fFromPlayerId\x12\x1a\n" +
+	"\bFromName\x18\b \x01(\tR\bFromName\x12\x16\n" +
+	"\x06IsRead\x18\t \x01(\bR\x06IsRead\x12\x1c\n" +
+	"\tIsClaimed\x18\n" +
+	" \x01(\bR\tIsClaimed\"\x8b\x01\n" +
+	"\bMailSync\x122\n" +
+	"\x05Mails\x18\x01 \x03(\v2\x1c.gserver.MailSync.MailsEntryR\x05Mails\x1aK\n" +
+	"\n" +
+	"MailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.gserver.MailDataR\x05value:\x028\x01\"0\n" +
+	"\aMailAdd\x12%\n" +
+	"\x04Mail\x18\x01 \x01(\v2\x11.gserver.MailDataR\x04Mail\"&\n" +
+	"\n" +
+	"MailRemove\x12\x18\n" +
+	"\aMailIds\x18\x01 \x03(\x03R\aMailIds\"%\n" +
+	"\vMailReadReq\x12\x16\n" +
+	"\x06MailId\x18\x01 \x01(\x03R\x06MailId\"%\n" +
+	"\vMailReadRes\x12\x16\n" +
+	"\x06MailId\x18\x01 \x01(\x03R\x06MailId\"(\n" +
+	"\fMailClaimReq\x12\x18\n" +
+	"\aMailIds\x18\x01 \x03(\x03R\aMailIds\"(\n" +
+	"\fMailClaimRes\x12\x18\n" +
+	"\aMailIds\x18\x01 \x03(\x03R\aMailIds\")\n" +
+	"\rMailDeleteReq\x12\x18\n" +
+	"\aMailIds\x18\x01 \x03(\x03R\aMailIds\")\n" +
+	"\rMailDeleteRes\x12\x18\n" +
+	"\aMailIds\x18\x01 \x03(\x03R\aMailIds\"\xb9\x01\n" +
+	"\fMailSaveData\x126\n" +
+	"\x05Mails\x18\x01 \x03(\v2 .gserver.MailSaveData.MailsEntryR\x05Mails\x127\n" +
+	"\n" +
+	"SystemMail\x18\x02 \x01(\v2\x17.gserver.MailSystemDataR\n" +
+	"SystemMail\x1a8\n" +
+	"\n" +
+	"MailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"B\n" +
+	"\x0eMailSystemData\x12*\n" +
+	"\x10LastSystemMailId\x18\x01 \x01(\x03R\x10LastSystemMailIdJ\x04\b\x02\x10\x03\"\xb2\x01\n" +
+	"\x10SystemMailFilter\x12\x1a\n" +
+	"\bMinLevel\x18\x01 \x01(\x05R\bMinLevel\x12\x1a\n" +
+	"\bMaxLevel\x18\x02 \x01(\x05R\bMaxLevel\x12$\n" +
+	"\rMinCreateTime\x18\x03 \x01(\x03R\rMinCreateTime\x12$\n" +
+	"\rMaxCreateTime\x18\x04 \x01(\x03R\rMaxCreateTime\x12\x1a\n" +
+	"\bRegionId\x18\x05 \x01(\x05R\bRegionId\"\x8e\x01\n" +
+	"\x0eSystemMailData\x12\"\n" +
+	"\fSystemMailId\x18\x01 \x01(\x03R\fSystemMailId\x12%\n" +
+	"\x04Mail\x18\x02 \x01(\v2\x11.gserver.MailDataR\x04Mail\x121\n" +
+	"\x06Filter\x18\x03 \x01(\v2\x19.gserver.SystemMailFilterR\x06Filter\"<\n" +
+	"\rSystemMailAdd\x12+\n" +
+	"\x04Data\x18\x01 \x01(\v2\x17.gserver.SystemMailDataR\x04DataB\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_mail_proto_rawDescOnce sync.Once
+	file_mail_proto_rawDescData []byte
+)
+
+func file_mail_proto_rawDescGZIP() []byte {
+	file_mail_proto_rawDescOnce.Do(func() {
+		file_mail_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mail_proto_rawDesc), len(file_mail_proto_rawDesc)))
+	})
+	return file_mail_proto_rawDescData
+}
+
+var file_mail_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_mail_proto_goTypes = []any{
+	(*MailData)(nil),         // 0: gserver.MailData
+	(*MailSync)(nil),         // 1: gserver.MailSync
+	(*MailAdd)(nil),          // 2: gserver.MailAdd
+	(*MailRemove)(nil),       // 3: gserver.MailRemove
+	(*MailReadReq)(nil),      // 4: gserver.MailReadReq
+	(*MailReadRes)(nil),      // 5: gserver.MailReadRes
+	(*MailClaimReq)(nil),     // 6: gserver.MailClaimReq
+	(*MailClaimRes)(nil),     // 7: gserver.MailClaimRes
+	(*MailDeleteReq)(nil),    // 8: gserver.MailDeleteReq
+	(*MailDeleteRes)(nil),    // 9: gserver.MailDeleteRes
+	(*MailSaveData)(nil),     // 10: gserver.MailSaveData
+	(*MailSystemData)(nil),   // 11: gserver.MailSystemData
+	(*SystemMailFilter)(nil), // 12: gserver.SystemMailFilter
+	(*SystemMailData)(nil),   // 13: gserver.SystemMailData
+	(*SystemMailAdd)(nil),    // 14: gserver.SystemMailAdd
+	nil,                      // 15: gserver.MailSync.MailsEntry
+	nil,                      // 16: gserver.MailSaveData.MailsEntry
+	(*AddElemArg)(nil),       // 17: gserver.AddElemArg
+}
+var file_mail_proto_depIdxs = []int32{
+	17, // 0: gserver.MailData.Attachments:type_name -> gserver.AddElemArg
+	15, // 1: gserver.MailSync.Mails:type_name -> gserver.MailSync.MailsEntry
+	0,  // 2: gserver.MailAdd.Mail:type_name -> gserver.MailData
+	16, // 3: gserver.MailSaveData.Mails:type_name -> gserver.MailSaveData.MailsEntry
+	11, // 4: gserver.MailSaveData.SystemMail:type_name -> gserver.MailSystemData
+	0,  // 5: gserver.SystemMailData.Mail:type_name -> gserver.MailData
+	12, // 6: gserver.SystemMailData.Filter:type_name -> gserver.SystemMailFilter
+	13, // 7: gserver.SystemMailAdd.Data:type_name -> gserver.SystemMailData
+	0,  // 8: gserver.MailSync.MailsEntry.value:type_name -> gserver.MailData
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_mail_proto_init() }
+func file_mail_proto_init() {
+	if File_mail_proto != nil {
+		return
+	}
+	file_cfg_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mail_proto_rawDesc), len(file_mail_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_mail_proto_goTypes,
+		DependencyIndexes: file_mail_proto_depIdxs,
+		MessageInfos:      file_mail_proto_msgTypes,
+	}.Build()
+	File_mail_proto = out.File
+	file_mail_proto_goTypes = nil
+	file_mail_proto_depIdxs = nil
+}
@@ -259,6 +259,7 @@ type QuestData struct {
 	CfgId         int32                  `protobuf:"varint,1,opt,name=CfgId,proto3" json:"CfgId,omitempty"`           // 配置id
 	Progress      int32                  `protobuf:"varint,2,opt,name=Progress,proto3" json:"Progress,omitempty"`     // 进度
 	ActivityId    int32                  `protobuf:"varint,3,opt,name=ActivityId,proto3" json:"ActivityId,omitempty"` // 活动id,只有活动子任务才会有值
+	CfgVersion    int64                  `protobuf:"varint,4,opt,name=CfgVersion,proto3" json:"CfgVersion,omitempty"` // 接任务时的配置数据版本(cfg.Snapshot.Version),数据迁移时用来检查过期的任务
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -314,6 +315,13 @@ func (x *QuestData) GetActivityId() int32 {
 	return 0
 }
 
+func (x *QuestData) GetCfgVersion() int64 {
+	if x != nil {
+		return x.CfgVersion
+	}
+	return 0
+}
+
 // 已完成的任务
 type FinishedQuestData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -419,6 +427,9 @@ type PlayerData struct {
 	PendingMessages map[int64][]byte       `protobuf:"bytes,10,rep,name=PendingMessages,proto3" json:"PendingMessages,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // map<int64,*PendingMessage>
 	Activities      map[int32][]byte       `protobuf:"bytes,11,rep,name=Activities,proto3" json:"Activities,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`           // map<int32,*ActivityDefaultBaseData>
 	Exchange        map[int32][]byte       `protobuf:"bytes,12,rep,name=Exchange,proto3" json:"Exchange,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`               // map<int32,*ExchangeRecord>
+	Mail            *MailSaveData          `protobuf:"bytes,13,opt,name=Mail,proto3" json:"Mail,omitempty"`
+	Friends         *FriendsSaveData       `protobuf:"bytes,14,opt,name=Friends,proto3" json:"Friends,omitempty"`
+	DeleteTimestamp int64                  `protobuf:"varint,15,opt,name=DeleteTimestamp,proto3" json:"DeleteTimestamp,omitempty"` // 删除角色的时间戳,0表示没有删除
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -530,6 +541,27 @@ func (x *PlayerData) GetExchange() map[int32][]byte {
 	return nil
 }
 
+func (x *PlayerData) GetMail() *MailSaveData {
+	if x != nil {
+		return x.Mail
+	}
+	return nil
+}
+
+func (x *PlayerData) GetFriends() *FriendsSaveData {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+func (x *PlayerData) GetDeleteTimestamp() int64 {
+	if x != nil {
+		return x.DeleteTimestamp
+	}
+	return 0
+}
+
 // 默认活动模板的基础数据
 type ActivityDefaultBaseData struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -721,11 +753,81 @@ func (x *ExchangeRecord) GetTimestamp() int32 {
 	return 0
 }
 
+// 玩家改名记录,供GM查询
+type PlayerNameRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	OldName       string                 `protobuf:"bytes,2,opt,name=OldName,proto3" json:"OldName,omitempty"`
+	NewName       string                 `protobuf:"bytes,3,opt,name=NewName,proto3" json:"NewName,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"` // 改名时间戳
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerNameRecord) Reset() {
+	*x = PlayerNameRecord{}
+	mi := &file_player_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerNameRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerNameRecord) ProtoMessage() {}
+
+func (x *PlayerNameRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_player_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerNameRecord.ProtoReflect.Descriptor instead.
+func (*PlayerNameRecord) Descriptor() ([]byte, []int) {
+	return file_player_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PlayerNameRecord) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *PlayerNameRecord) GetOldName() string {
+	if x != nil {
+		return x.OldName
+	}
+	return ""
+}
+
+func (x *PlayerNameRecord) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+func (x *PlayerNameRecord) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 var File_player_proto protoreflect.FileDescriptor
 
 const file_player_proto_rawDesc = "" +
 	"\n" +
-	"\fplayer.proto\x12\agserver\x1a\x19google/protobuf/any.proto\"\xf6\x02\n" +
+	"\fplayer.proto\x12\agserver\x1a\x19google/protobuf/any.proto\x1a\n" +
+	"mail.proto\x1a\ffriend.proto\"\xf6\x02\n" +
 	"\bBaseInfo\x12\x16\n" +
 	"\x06Gender\x18\x01 \x01(\x05R\x06Gender\x12\x14\n" +
 	"\x05Level\x18\x02 \x01(\x05R\x05Level\x12\x10\n" +
@@ -762,17 +864,20 @@ const file_player_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\x1a9\n" +
 	"\vQuestsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"]\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"}\n" +
 	"\tQuestData\x12\x14\n" +
 	"\x05CfgId\x18\x01 \x01(\x05R\x05CfgId\x12\x1a\n" +
 	"\bProgress\x18\x02 \x01(\x05R\bProgress\x12\x1e\n" +
 	"\n" +
 	"ActivityId\x18\x03 \x01(\x05R\n" +
-	"ActivityId\"1\n" +
+	"ActivityId\x12\x1e\n" +
+	"\n" +
+	"CfgVersion\x18\x04 \x01(\x03R\n" +
+	"CfgVersion\"1\n" +
 	"\x11FinishedQuestData\x12\x1c\n" +
 	"\tTimestamp\x18\x01 \x01(\x05R\tTimestamp\"+\n" +
 	"\x0fPlayerGuildData\x12\x18\n" +
-	"\aGuildId\x18\x01 \x01(\x03R\aGuildId\"\xba\x05\n" +
+	"\aGuildId\x18\x01 \x01(\x03R\aGuildId\"\xc3\x06\n" +
 	"\n" +
 	"PlayerData\x12\x0f\n" +
 	"\x03_id\x18\x01 \x01(\x03R\x02Id\x12\x12\n" +
@@ -788,7 +893,10 @@ const file_player_proto_rawDesc = "" +
 	"\n" +
 	"Activities\x18\v \x03(\v2#.gserver.PlayerData.ActivitiesEntryR\n" +
 	"Activities\x12=\n" +
-	"\bExchange\x18\f \x03(\v2!.gserver.PlayerData.ExchangeEntryR\bExchange\x1aB\n" +
+	"\bExchange\x18\f \x03(\v2!.gserver.PlayerData.ExchangeEntryR\bExchange\x12)\n" +
+	"\x04Mail\x18\r \x01(\v2\x15.gserver.MailSaveDataR\x04Mail\x122\n" +
+	"\aFriends\x18\x0e \x01(\v2\x18.gserver.FriendsSaveDataR\aFriends\x12(\n" +
+	"\x0fDeleteTimestamp\x18\x0f \x01(\x03R\x0fDeleteTimestamp\x1aB\n" +
 	"\x14PendingMessagesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\x1a=\n" +
@@ -815,7 +923,12 @@ const file_player_proto_rawDesc = "" +
 	"\x0eExchangeRecord\x12\x14\n" +
 	"\x05CfgId\x18\x01 \x01(\x05R\x05CfgId\x12\x14\n" +
 	"\x05Count\x18\x02 \x01(\x05R\x05Count\x12\x1c\n" +
-	"\tTimestamp\x18\x03 \x01(\x05R\tTimestampB\x06Z\x04./pbb\x06proto3"
+	"\tTimestamp\x18\x03 \x01(\x05R\tTimestamp\"\x80\x01\n" +
+	"\x10PlayerNameRecord\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x18\n" +
+	"\aOldName\x18\x02 \x01(\tR\aOldName\x12\x18\n" +
+	"\aNewName\x18\x03 \x01(\tR\aNewName\x12\x1c\n" +
+	"\tTimestamp\x18\x04 \x01(\x03R\tTimestampB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_player_proto_rawDescOnce sync.Once
@@ -829,7 +942,7 @@ func file_player_proto_rawDescGZIP() []byte {
 	return file_player_proto_rawDescData
 }
 
-var file_player_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_player_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_player_proto_goTypes = []any{
 	(*BaseInfo)(nil),                // 0: gserver.BaseInfo
 	(*BagSaveData)(nil),             // 1: gserver.BagSaveData
@@ -841,37 +954,42 @@ var file_player_proto_goTypes = []any{
 	(*ActivityDefaultBaseData)(nil), // 7: gserver.ActivityDefaultBaseData
 	(*PendingMessage)(nil),          // 8: gserver.PendingMessage
 	(*ExchangeRecord)(nil),          // 9: gserver.ExchangeRecord
-	nil,                             // 10: gserver.BagSaveData.CountItemEntry
-	nil,                             // 11: gserver.BagSaveData.UniqueItemEntry
-	nil,                             // 12: gserver.BagSaveData.EquipEntry
-	nil,                             // 13: gserver.QuestSaveData.FinishedEntry
-	nil,                             // 14: gserver.QuestSaveData.QuestsEntry
-	nil,                             // 15: gserver.PlayerData.PendingMessagesEntry
-	nil,                             // 16: gserver.PlayerData.ActivitiesEntry
-	nil,                             // 17: gserver.PlayerData.ExchangeEntry
-	nil,                             // 18: gserver.ActivityDefaultBaseData.PropertiesInt32Entry
-	(*anypb.Any)(nil),               // 19: google.protobuf.Any
+	(*PlayerNameRecord)(nil),        // 10: gserver.PlayerNameRecord
+	nil,                             // 11: gserver.BagSaveData.CountItemEntry
+	nil,                             // 12: gserver.BagSaveData.UniqueItemEntry
+	nil,                             // 13: gserver.BagSaveData.EquipEntry
+	nil,                             // 14: gserver.QuestSaveData.FinishedEntry
+	nil,                             // 15: gserver.QuestSaveData.QuestsEntry
+	nil,                             // 16: gserver.PlayerData.PendingMessagesEntry
+	nil,                             // 17: gserver.PlayerData.ActivitiesEntry
+	nil,                             // 18: gserver.PlayerData.ExchangeEntry
+	nil,                             // 19: gserver.ActivityDefaultBaseData.PropertiesInt32Entry
+	(*MailSaveData)(nil),            // 20: gserver.MailSaveData
+	(*FriendsSaveData)(nil),         // 21: gserver.FriendsSaveData
+	(*anypb.Any)(nil),               // 22: google.protobuf.Any
 }
 var file_player_proto_depIdxs = []int32{
-	10, // 0: gserver.BagSaveData.CountItem:type_name -> gserver.BagSaveData.CountItemEntry
-	11, // 1: gserver.BagSaveData.UniqueItem:type_name -> gserver.BagSaveData.UniqueItemEntry
-	12, // 2: gserver.BagSaveData.Equip:type_name -> gserver.BagSaveData.EquipEntry
-	13, // 3: gserver.QuestSaveData.Finished:type_name -> gserver.QuestSaveData.FinishedEntry
-	14, // 4: gserver.QuestSaveData.Quests:type_name -> gserver.QuestSaveData.QuestsEntry
+	11, // 0: gserver.BagSaveData.CountItem:type_name -> gserver.BagSaveData.CountItemEntry
+	12, // 1: gserver.BagSaveData.UniqueItem:type_name -> gserver.BagSaveData.UniqueItemEntry
+	13, // 2: gserver.BagSaveData.Equip:type_name -> gserver.BagSaveData.EquipEntry
+	14, // 3: gserver.QuestSaveData.Finished:type_name -> gserver.QuestSaveData.FinishedEntry
+	15, // 4: gserver.QuestSaveData.Quests:type_name -> gserver.QuestSaveData.QuestsEntry
 	0,  // 5: gserver.PlayerData.BaseInfo:type_name -> gserver.BaseInfo
 	1,  // 6: gserver.PlayerData.Bags:type_name -> gserver.BagSaveData
 	2,  // 7: gserver.PlayerData.Quest:type_name -> gserver.QuestSaveData
 	5,  // 8: gserver.PlayerData.Guild:type_name -> gserver.PlayerGuildData
-	15, // 9: gserver.PlayerData.PendingMessages:type_name -> gserver.PlayerData.PendingMessagesEntry
-	16, // 10: gserver.PlayerData.Activities:type_name -> gserver.PlayerData.ActivitiesEntry
-	17, // 11: gserver.PlayerData.Exchange:type_name -> gserver.PlayerData.ExchangeEntry
-	18, // 12: gserver.ActivityDefaultBaseData.PropertiesInt32:type_name -> gserver.ActivityDefaultBaseData.PropertiesInt32Entry
-	19, // 13: gserver.PendingMessage.PacketData:type_name -> google.protobuf.Any
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	16, // 9: gserver.PlayerData.PendingMessages:type_name -> gserver.PlayerData.PendingMessagesEntry
+	17, // 10: gserver.PlayerData.Activities:type_name -> gserver.PlayerData.ActivitiesEntry
+	18, // 11: gserver.PlayerData.Exchange:type_name -> gserver.PlayerData.ExchangeEntry
+	20, // 12: gserver.PlayerData.Mail:type_name -> gserver.MailSaveData
+	21, // 13: gserver.PlayerData.Friends:type_name -> gserver.FriendsSaveData
+	19, // 14: gserver.ActivityDefaultBaseData.PropertiesInt32:type_name -> gserver.ActivityDefaultBaseData.PropertiesInt32Entry
+	22, // 15: gserver.PendingMessage.PacketData:type_name -> google.protobuf.Any
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_player_proto_init() }
@@ -879,13 +997,15 @@ func file_player_proto_init() {
 	if File_player_proto != nil {
 		return
 	}
+	file_mail_proto_init()
+	file_friend_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_player_proto_rawDesc), len(file_player_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
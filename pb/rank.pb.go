@@ -0,0 +1,409 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v4.25.9
+// source: rank.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 排行榜的一条数据
+type RankItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int64                  `protobuf:"varint,1,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`    // 玩家名
+	Score         int64                  `protobuf:"varint,3,opt,name=Score,proto3" json:"Score,omitempty"` // 分数
+	Rank          int32                  `protobuf:"varint,4,opt,name=Rank,proto3" json:"Rank,omitempty"`   // 名次(从1开始,0表示未上榜)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankItem) Reset() {
+	*x = RankItem{}
+	mi := &file_rank_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankItem) ProtoMessage() {}
+
+func (x *RankItem) ProtoReflect() protoreflect.Message {
+	mi := &file_rank_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankItem.ProtoReflect.Descriptor instead.
+func (*RankItem) Descriptor() ([]byte, []int) {
+	return file_rank_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RankItem) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *RankItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RankItem) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RankItem) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// 分页查询排行榜req
+type RankListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RankCfgId     int32                  `protobuf:"varint,1,opt,name=RankCfgId,proto3" json:"RankCfgId,omitempty"` // 排行榜配置id
+	PageIndex     int32                  `protobuf:"varint,2,opt,name=PageIndex,proto3" json:"PageIndex,omitempty"` // 页码(从0开始)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankListReq) Reset() {
+	*x = RankListReq{}
+	mi := &file_rank_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankListReq) ProtoMessage() {}
+
+func (x *RankListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rank_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankListReq.ProtoReflect.Descriptor instead.
+func (*RankListReq) Descriptor() ([]byte, []int) {
+	return file_rank_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RankListReq) GetRankCfgId() int32 {
+	if x != nil {
+		return x.RankCfgId
+	}
+	return 0
+}
+
+func (x *RankListReq) GetPageIndex() int32 {
+	if x != nil {
+		return x.PageIndex
+	}
+	return 0
+}
+
+// 分页查询排行榜res
+type RankListRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RankCfgId     int32                  `protobuf:"varint,1,opt,name=RankCfgId,proto3" json:"RankCfgId,omitempty"`
+	PageIndex     int32                  `protobuf:"varint,2,opt,name=PageIndex,proto3" json:"PageIndex,omitempty"`
+	PageCount     int32                  `protobuf:"varint,3,opt,name=PageCount,proto3" json:"PageCount,omitempty"` // 总页数
+	Items         []*RankItem            `protobuf:"bytes,4,rep,name=Items,proto3" json:"Items,omitempty"`
+	Self          *RankItem              `protobuf:"bytes,5,opt,name=Self,proto3" json:"Self,omitempty"` // 自己的排名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankListRes) Reset() {
+	*x = RankListRes{}
+	mi := &file_rank_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankListRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankListRes) ProtoMessage() {}
+
+func (x *RankListRes) ProtoReflect() protoreflect.Message {
+	mi := &file_rank_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankListRes.ProtoReflect.Descriptor instead.
+func (*RankListRes) Descriptor() ([]byte, []int) {
+	return file_rank_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RankListRes) GetRankCfgId() int32 {
+	if x != nil {
+		return x.RankCfgId
+	}
+	return 0
+}
+
+func (x *RankListRes) GetPageIndex() int32 {
+	if x != nil {
+		return x.PageIndex
+	}
+	return 0
+}
+
+func (x *RankListRes) GetPageCount() int32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+func (x *RankListRes) GetItems() []*RankItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RankListRes) GetSelf() *RankItem {
+	if x != nil {
+		return x.Self
+	}
+	return nil
+}
+
+// 查询玩家排名req
+type RankPlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RankCfgId     int32                  `protobuf:"varint,1,opt,name=RankCfgId,proto3" json:"RankCfgId,omitempty"`
+	PlayerId      int64                  `protobuf:"varint,2,opt,name=PlayerId,proto3" json:"PlayerId,omitempty"` // 0表示查询自己
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankPlayerReq) Reset() {
+	*x = RankPlayerReq{}
+	mi := &file_rank_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankPlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankPlayerReq) ProtoMessage() {}
+
+func (x *RankPlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_rank_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankPlayerReq.ProtoReflect.Descriptor instead.
+func (*RankPlayerReq) Descriptor() ([]byte, []int) {
+	return file_rank_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RankPlayerReq) GetRankCfgId() int32 {
+	if x != nil {
+		return x.RankCfgId
+	}
+	return 0
+}
+
+func (x *RankPlayerReq) GetPlayerId() int64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 查询玩家排名res
+type RankPlayerRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RankCfgId     int32                  `protobuf:"varint,1,opt,name=RankCfgId,proto3" json:"RankCfgId,omitempty"`
+	Item          *RankItem              `protobuf:"bytes,2,opt,name=Item,proto3" json:"Item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankPlayerRes) Reset() {
+	*x = RankPlayerRes{}
+	mi := &file_rank_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankPlayerRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankPlayerRes) ProtoMessage() {}
+
+func (x *RankPlayerRes) ProtoReflect() protoreflect.Message {
+	mi := &file_rank_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankPlayerRes.ProtoReflect.Descriptor instead.
+func (*RankPlayerRes) Descriptor() ([]byte, []int) {
+	return file_rank_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RankPlayerRes) GetRankCfgId() int32 {
+	if x != nil {
+		return x.RankCfgId
+	}
+	return 0
+}
+
+func (x *RankPlayerRes) GetItem() *RankItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+var File_rank_proto protoreflect.FileDescriptor
+
+const file_rank_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"rank.proto\x12\agserver\"d\n" +
+	"\bRankItem\x12\x1a\n" +
+	"\bPlayerId\x18\x01 \x01(\x03R\bPlayerId\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x14\n" +
+	"\x05Score\x18\x03 \x01(\x03R\x05Score\x12\x12\n" +
+	"\x04Rank\x18\x04 \x01(\x05R\x04Rank\"I\n" +
+	"\vRankListReq\x12\x1c\n" +
+	"\tRankCfgId\x18\x01 \x01(\x05R\tRankCfgId\x12\x1c\n" +
+	"\tPageIndex\x18\x02 \x01(\x05R\tPageIndex\"\xb7\x01\n" +
+	"\vRankListRes\x12\x1c\n" +
+	"\tRankCfgId\x18\x01 \x01(\x05R\tRankCfgId\x12\x1c\n" +
+	"\tPageIndex\x18\x02 \x01(\x05R\tPageIndex\x12\x1c\n" +
+	"\tPageCount\x18\x03 \x01(\x05R\tPageCount\x12'\n" +
+	"\x05Items\x18\x04 \x03(\v2\x11.gserver.RankItemR\x05Items\x12%\n" +
+	"\x04Self\x18\x05 \x01(\v2\x11.gserver.RankItemR\x04Self\"I\n" +
+	"\rRankPlayerReq\x12\x1c\n" +
+	"\tRankCfgId\x18\x01 \x01(\x05R\tRankCfgId\x12\x1a\n" +
+	"\bPlayerId\x18\x02 \x01(\x03R\bPlayerId\"T\n" +
+	"\rRankPlayerRes\x12\x1c\n" +
+	"\tRankCfgId\x18\x01 \x01(\x05R\tRankCfgId\x12%\n" +
+	"\x04Item\x18\x02 \x01(\v2\x11.gserver.RankItemR\x04ItemB\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_rank_proto_rawDescOnce sync.Once
+	file_rank_proto_rawDescData []byte
+)
+
+func file_rank_proto_rawDescGZIP() []byte {
+	file_rank_proto_rawDescOnce.Do(func() {
+		file_rank_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rank_proto_rawDesc), len(file_rank_proto_rawDesc)))
+	})
+	return file_rank_proto_rawDescData
+}
+
+var file_rank_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_rank_proto_goTypes = []any{
+	(*RankItem)(nil),      // 0: gserver.RankItem
+	(*RankListReq)(nil),   // 1: gserver.RankListReq
+	(*RankListRes)(nil),   // 2: gserver.RankListRes
+	(*RankPlayerReq)(nil), // 3: gserver.RankPlayerReq
+	(*RankPlayerRes)(nil), // 4: gserver.RankPlayerRes
+}
+var file_rank_proto_depIdxs = []int32{
+	0, // 0: gserver.RankListRes.Items:type_name -> gserver.RankItem
+	0, // 1: gserver.RankListRes.Self:type_name -> gserver.RankItem
+	0, // 2: gserver.RankPlayerRes.Item:type_name -> gserver.RankItem
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rank_proto_init() }
+func file_rank_proto_init() {
+	if File_rank_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rank_proto_rawDesc), len(file_rank_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rank_proto_goTypes,
+		DependencyIndexes: file_rank_proto_depIdxs,
+		MessageInfos:      file_rank_proto_msgTypes,
+	}.Build()
+	File_rank_proto = out.File
+	file_rank_proto_goTypes = nil
+	file_rank_proto_depIdxs = nil
+}
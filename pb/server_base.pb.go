@@ -32,6 +32,12 @@ type ServerInfo struct {
 	GateListenAddr     string                 `protobuf:"bytes,6,opt,name=GateListenAddr,proto3" json:"GateListenAddr,omitempty"`         // 监听网关地址
 	LastActiveTime     int64                  `protobuf:"varint,7,opt,name=LastActiveTime,proto3" json:"LastActiveTime,omitempty"`        // 最近上传信息的时间戳(毫秒)
 	Ping               int32                  `protobuf:"varint,8,opt,name=Ping,proto3" json:"Ping,omitempty"`                            // ping值(毫秒)
+	RouteWeight        int32                  `protobuf:"varint,9,opt,name=RouteWeight,proto3" json:"RouteWeight,omitempty"`              // 路由权重(公会等分布式实体按权重分配到游戏服务器,<=0时视为1)
+	Status             int32                  `protobuf:"varint,10,opt,name=Status,proto3" json:"Status,omitempty"`                       // 服务器运行状态(internal.ServerStatus)
+	OnlineCount        int32                  `protobuf:"varint,11,opt,name=OnlineCount,proto3" json:"OnlineCount,omitempty"`             // 在线玩家数(游戏服)
+	QueueDepth         int32                  `protobuf:"varint,12,opt,name=QueueDepth,proto3" json:"QueueDepth,omitempty"`               // 玩家协程消息队列里待处理的消息总数(游戏服)
+	CpuLoad            int32                  `protobuf:"varint,13,opt,name=CpuLoad,proto3" json:"CpuLoad,omitempty"`                     // 进程的cpu使用率(千分比)
+	MaxOnlineCount     int32                  `protobuf:"varint,14,opt,name=MaxOnlineCount,proto3" json:"MaxOnlineCount,omitempty"`       // 最大在线玩家数(游戏服,0表示不限制)
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -122,6 +128,48 @@ func (x *ServerInfo) GetPing() int32 {
 	return 0
 }
 
+func (x *ServerInfo) GetRouteWeight() int32 {
+	if x != nil {
+		return x.RouteWeight
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetOnlineCount() int32 {
+	if x != nil {
+		return x.OnlineCount
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetCpuLoad() int32 {
+	if x != nil {
+		return x.CpuLoad
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetMaxOnlineCount() int32 {
+	if x != nil {
+		return x.MaxOnlineCount
+	}
+	return 0
+}
+
 // 踢玩家下线req
 type KickPlayerReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -236,6 +284,51 @@ func (x *KickPlayerRes) GetPlayerId() int64 {
 	return 0
 }
 
+// 让游戏服进入排空状态
+type DrainServerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      int32                  `protobuf:"varint,1,opt,name=ServerId,proto3" json:"ServerId,omitempty"` // 游戏服id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrainServerReq) Reset() {
+	*x = DrainServerReq{}
+	mi := &file_server_base_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainServerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainServerReq) ProtoMessage() {}
+
+func (x *DrainServerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_server_base_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainServerReq.ProtoReflect.Descriptor instead.
+func (*DrainServerReq) Descriptor() ([]byte, []int) {
+	return file_server_base_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DrainServerReq) GetServerId() int32 {
+	if x != nil {
+		return x.ServerId
+	}
+	return 0
+}
+
 // 客户端掉线
 type ClientDisconnect struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -246,7 +339,7 @@ type ClientDisconnect struct {
 
 func (x *ClientDisconnect) Reset() {
 	*x = ClientDisconnect{}
-	mi := &file_server_base_proto_msgTypes[3]
+	mi := &file_server_base_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -258,7 +351,7 @@ func (x *ClientDisconnect) String() string {
 func (*ClientDisconnect) ProtoMessage() {}
 
 func (x *ClientDisconnect) ProtoReflect() protoreflect.Message {
-	mi := &file_server_base_proto_msgTypes[3]
+	mi := &file_server_base_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -271,7 +364,7 @@ func (x *ClientDisconnect) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientDisconnect.ProtoReflect.Descriptor instead.
 func (*ClientDisconnect) Descriptor() ([]byte, []int) {
-	return file_server_base_proto_rawDescGZIP(), []int{3}
+	return file_server_base_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ClientDisconnect) GetClientConnId() uint32 {
@@ -292,7 +385,7 @@ type ServerHello struct {
 
 func (x *ServerHello) Reset() {
 	*x = ServerHello{}
-	mi := &file_server_base_proto_msgTypes[4]
+	mi := &file_server_base_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -304,7 +397,7 @@ func (x *ServerHello) String() string {
 func (*ServerHello) ProtoMessage() {}
 
 func (x *ServerHello) ProtoReflect() protoreflect.Message {
-	mi := &file_server_base_proto_msgTypes[4]
+	mi := &file_server_base_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,7 +410,7 @@ func (x *ServerHello) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerHello.ProtoReflect.Descriptor instead.
 func (*ServerHello) Descriptor() ([]byte, []int) {
-	return file_server_base_proto_rawDescGZIP(), []int{4}
+	return file_server_base_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ServerHello) GetServerId() int32 {
@@ -338,7 +431,7 @@ var File_server_base_proto protoreflect.FileDescriptor
 
 const file_server_base_proto_rawDesc = "" +
 	"\n" +
-	"\x11server_base.proto\x12\agserver\"\xb4\x02\n" +
+	"\x11server_base.proto\x12\agserver\"\xf2\x03\n" +
 	"\n" +
 	"ServerInfo\x12\x1a\n" +
 	"\bServerId\x18\x01 \x01(\x05R\bServerId\x12\x1e\n" +
@@ -350,14 +443,25 @@ const file_server_base_proto_rawDesc = "" +
 	"\x12WsClientListenAddr\x18\x05 \x01(\tR\x12WsClientListenAddr\x12&\n" +
 	"\x0eGateListenAddr\x18\x06 \x01(\tR\x0eGateListenAddr\x12&\n" +
 	"\x0eLastActiveTime\x18\a \x01(\x03R\x0eLastActiveTime\x12\x12\n" +
-	"\x04Ping\x18\b \x01(\x05R\x04Ping\"I\n" +
+	"\x04Ping\x18\b \x01(\x05R\x04Ping\x12 \n" +
+	"\vRouteWeight\x18\t \x01(\x05R\vRouteWeight\x12\x16\n" +
+	"\x06Status\x18\n" +
+	" \x01(\x05R\x06Status\x12 \n" +
+	"\vOnlineCount\x18\v \x01(\x05R\vOnlineCount\x12\x1e\n" +
+	"\n" +
+	"QueueDepth\x18\f \x01(\x05R\n" +
+	"QueueDepth\x12\x18\n" +
+	"\aCpuLoad\x18\r \x01(\x05R\aCpuLoad\x12&\n" +
+	"\x0eMaxOnlineCount\x18\x0e \x01(\x05R\x0eMaxOnlineCount\"I\n" +
 	"\rKickPlayerReq\x12\x1c\n" +
 	"\tAccountId\x18\x01 \x01(\x03R\tAccountId\x12\x1a\n" +
 	"\bPlayerId\x18\x02 \x01(\x03R\bPlayerId\"_\n" +
 	"\rKickPlayerRes\x12\x14\n" +
 	"\x05Error\x18\x01 \x01(\tR\x05Error\x12\x1c\n" +
 	"\tAccountId\x18\x02 \x01(\x03R\tAccountId\x12\x1a\n" +
-	"\bPlayerId\x18\x03 \x01(\x03R\bPlayerId\"6\n" +
+	"\bPlayerId\x18\x03 \x01(\x03R\bPlayerId\",\n" +
+	"\x0eDrainServerReq\x12\x1a\n" +
+	"\bServerId\x18\x01 \x01(\x05R\bServerId\"6\n" +
 	"\x10ClientDisconnect\x12\"\n" +
 	"\fClientConnId\x18\x01 \x01(\rR\fClientConnId\"I\n" +
 	"\vServerHello\x12\x1a\n" +
@@ -378,13 +482,14 @@ func file_server_base_proto_rawDescGZIP() []byte {
 	return file_server_base_proto_rawDescData
 }
 
-var file_server_base_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_server_base_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_server_base_proto_goTypes = []any{
 	(*ServerInfo)(nil),       // 0: gserver.ServerInfo
 	(*KickPlayerReq)(nil),    // 1: gserver.KickPlayerReq
 	(*KickPlayerRes)(nil),    // 2: gserver.KickPlayerRes
-	(*ClientDisconnect)(nil), // 3: gserver.ClientDisconnect
-	(*ServerHello)(nil),      // 4: gserver.ServerHello
+	(*DrainServerReq)(nil),   // 3: gserver.DrainServerReq
+	(*ClientDisconnect)(nil), // 4: gserver.ClientDisconnect
+	(*ServerHello)(nil),      // 5: gserver.ServerHello
 }
 var file_server_base_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -405,7 +510,7 @@ func file_server_base_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_server_base_proto_rawDesc), len(file_server_base_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
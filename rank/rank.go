@@ -0,0 +1,228 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/fish-tennis/gserver/cache"
+	"github.com/fish-tennis/gserver/pb"
+	"github.com/redis/go-redis/v9"
+)
+
+// 排行榜
+//
+//	数据保存在redis的ZSET中,member是playerId,score是分数
+//	玩家名保存在一个公共的hash中,查询排行榜时再批量读取
+
+const (
+	// 赛季快照的保留时间
+	SnapshotExpireDuration = time.Hour * 24 * 30
+)
+
+var (
+	ErrSnapshotExists = errors.New("SnapshotExists")
+)
+
+// 排行榜的key,使用hash tag保证赛季快照和排行榜在redis集群的同一个slot,才能使用RENAME
+func keyRank(rankName string) string {
+	return "rank:{" + rankName + "}"
+}
+
+// 赛季快照的key
+func keySnapshot(rankName string, seasonId int32) string {
+	return keyRank(rankName) + ":season:" + strconv.FormatInt(int64(seasonId), 10)
+}
+
+// 排行榜玩家名的key
+func keyRankNames() string {
+	return "rank:names"
+}
+
+// 保存玩家名
+func setPlayerName(playerId int64, playerName string) {
+	if playerName == "" {
+		return
+	}
+	_, err := cache.GetRedis().HSet(context.Background(), keyRankNames(), strconv.FormatInt(playerId, 10), playerName).Result()
+	if cache.IsRedisError(err) {
+		slog.Error("rank setPlayerName error", "playerId", playerId, "error", err)
+	}
+}
+
+// 只保留配置的最大人数
+func trim(rankCfg *pb.RankCfg) {
+	if rankCfg.GetMaxCount() <= 0 {
+		return
+	}
+	_, err := cache.GetRedis().ZRemRangeByRank(context.Background(), keyRank(rankCfg.GetName()), 0, -int64(rankCfg.GetMaxCount())-1).Result()
+	if cache.IsRedisError(err) {
+		slog.Error("rank trim error", "rankName", rankCfg.GetName(), "error", err)
+	}
+}
+
+// 设置玩家的分数,rankCfg由调用方传入,玩家协程里使用玩家当前处理流程的配置数据
+func UpdateScore(rankCfg *pb.RankCfg, playerId int64, playerName string, score int64) error {
+	rankName := rankCfg.GetName()
+	_, err := cache.GetRedis().ZAdd(context.Background(), keyRank(rankName), redis.Z{
+		Score:  float64(score),
+		Member: strconv.FormatInt(playerId, 10),
+	}).Result()
+	if cache.IsRedisError(err) {
+		slog.Error("rank UpdateScore error", "rankName", rankName, "playerId", playerId, "error", err)
+		return err
+	}
+	setPlayerName(playerId, playerName)
+	trim(rankCfg)
+	slog.Debug("rank UpdateScore", "rankName", rankName, "playerId", playerId, "score", score)
+	return nil
+}
+
+// 增加玩家的分数,返回增加后的分数
+func IncScore(rankCfg *pb.RankCfg, playerId int64, playerName string, delta int64) (int64, error) {
+	rankName := rankCfg.GetName()
+	score, err := cache.GetRedis().ZIncrBy(context.Background(), keyRank(rankName), float64(delta), strconv.FormatInt(playerId, 10)).Result()
+	if cache.IsRedisError(err) {
+		slog.Error("rank IncScore error", "rankName", rankName, "playerId", playerId, "error", err)
+		return 0, err
+	}
+	setPlayerName(playerId, playerName)
+	trim(rankCfg)
+	slog.Debug("rank IncScore", "rankName", rankName, "playerId", playerId, "delta", delta, "score", score)
+	return int64(score), nil
+}
+
+// 从排行榜中删除玩家
+func RemovePlayer(rankName string, playerId int64) error {
+	_, err := cache.GetRedis().ZRem(context.Background(), keyRank(rankName), strconv.FormatInt(playerId, 10)).Result()
+	if cache.IsRedisError(err) {
+		slog.Error("rank RemovePlayer error", "rankName", rankName, "playerId", playerId, "error", err)
+		return err
+	}
+	return nil
+}
+
+// 获取排行榜前n名
+func GetTopN(rankName string, n int32) ([]*pb.RankItem, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	return getRange(keyRank(rankName), 0, int64(n)-1)
+}
+
+// 分页获取排行榜,返回当前页的数据和排行榜的总人数
+//
+//	pageIndex从0开始
+func GetPage(rankName string, pageIndex, pageSize int32) ([]*pb.RankItem, int64, error) {
+	if pageIndex < 0 || pageSize <= 0 {
+		return nil, 0, errors.New("PageArgError")
+	}
+	total, err := cache.GetRedis().ZCard(context.Background(), keyRank(rankName)).Result()
+	if cache.IsRedisError(err) {
+		slog.Error("rank GetPage error", "rankName", rankName, "error", err)
+		return nil, 0, err
+	}
+	start := int64(pageIndex) * int64(pageSize)
+	if start >= total {
+		return nil, total, nil
+	}
+	items, err := getRange(keyRank(rankName), start, start+int64(pageSize)-1)
+	return items, total, err
+}
+
+// 获取玩家的排名,未上榜时Rank为0
+func GetPlayerRank(rankName string, playerId int64) (*pb.RankItem, error) {
+	item := &pb.RankItem{
+		PlayerId: playerId,
+	}
+	member := strconv.FormatInt(playerId, 10)
+	rank, err := cache.GetRedis().ZRevRank(context.Background(), keyRank(rankName), member).Result()
+	if err == redis.Nil {
+		return item, nil
+	}
+	if err != nil {
+		slog.Error("rank GetPlayerRank error", "rankName", rankName, "playerId", playerId, "error", err)
+		return nil, err
+	}
+	score, err := cache.GetRedis().ZScore(context.Background(), keyRank(rankName), member).Result()
+	if cache.IsRedisError(err) {
+		slog.Error("rank GetPlayerRank error", "rankName", rankName, "playerId", playerId, "error", err)
+		return nil, err
+	}
+	item.Rank = int32(rank) + 1
+	item.Score = int64(score)
+	return item, nil
+}
+
+// 排行榜快照并重置,用于赛季结算
+//
+//	利用RENAMENX原子操作,同一个赛季只能快照一次,防止多个服务器重复结算
+//	排行榜为空时,不会生成快照
+func SnapshotAndReset(rankName string, seasonId int32) error {
+	snapshotKey := keySnapshot(rankName, seasonId)
+	ok, err := cache.GetRedis().RenameNX(context.Background(), keyRank(rankName), snapshotKey).Result()
+	if err != nil {
+		// 排行榜不存在时redis返回ERR no such key
+		exists, existsErr := cache.GetRedis().Exists(context.Background(), keyRank(rankName)).Result()
+		if existsErr == nil && exists == 0 {
+			slog.Info("rank SnapshotAndReset empty", "rankName", rankName, "seasonId", seasonId)
+			return nil
+		}
+		slog.Error("rank SnapshotAndReset error", "rankName", rankName, "seasonId", seasonId, "error", err)
+		return err
+	}
+	if !ok {
+		return ErrSnapshotExists
+	}
+	cache.GetRedis().Expire(context.Background(), snapshotKey, SnapshotExpireDuration)
+	slog.Info("rank SnapshotAndReset", "rankName", rankName, "seasonId", seasonId)
+	return nil
+}
+
+// 获取赛季快照中指定名次区间的数据
+//
+//	名次从1开始,包含minRank和maxRank
+func GetSnapshotRange(rankName string, seasonId int32, minRank, maxRank int32) ([]*pb.RankItem, error) {
+	if minRank <= 0 || maxRank < minRank {
+		return nil, nil
+	}
+	return getRange(keySnapshot(rankName, seasonId), int64(minRank)-1, int64(maxRank)-1)
+}
+
+// 按分数从高到低获取排行榜的数据,并填充玩家名
+func getRange(key string, start, stop int64) ([]*pb.RankItem, error) {
+	zs, err := cache.GetRedis().ZRevRangeWithScores(context.Background(), key, start, stop).Result()
+	if cache.IsRedisError(err) {
+		slog.Error("rank getRange error", "key", key, "error", err)
+		return nil, err
+	}
+	if len(zs) == 0 {
+		return nil, nil
+	}
+	items := make([]*pb.RankItem, len(zs))
+	fields := make([]string, len(zs))
+	for i, z := range zs {
+		member, _ := z.Member.(string)
+		fields[i] = member
+		playerId, _ := strconv.ParseInt(member, 10, 64)
+		items[i] = &pb.RankItem{
+			PlayerId: playerId,
+			Score:    int64(z.Score),
+			Rank:     int32(start) + int32(i) + 1,
+		}
+	}
+	names, err := cache.GetRedis().HMGet(context.Background(), keyRankNames(), fields...).Result()
+	if cache.IsRedisError(err) {
+		// 玩家名读取失败不影响排行榜数据
+		slog.Error("rank getRange names error", "key", key, "error", err)
+		return items, nil
+	}
+	for i, name := range names {
+		if i < len(items) {
+			items[i].Name, _ = name.(string)
+		}
+	}
+	return items, nil
+}
@@ -22,6 +22,8 @@ var (
 // 公会
 type Guild struct {
 	gentity.BaseRoutineEntity
+	// 是否已解散,解散后不再保存数据
+	disbanded bool
 }
 
 // requestPacket->route to guild->convert packet to guildMessage-->guild.PushMessage
@@ -67,6 +69,8 @@ func (this *Guild) processMessage(guildMessage *GuildMessage) {
 			internal.SendAlert(err)
 		}
 	}()
+	// 改名时同步公会失败的话,成员数据里还是旧名字,收到该成员的消息时顺便同步
+	this.GetMembers().syncMemberName(guildMessage)
 	// 调用注册的组件回调接口
 	handlerInfo := _guildPacketHandlerMgr[guildMessage.cmd]
 	if handlerInfo != nil {
@@ -96,6 +100,36 @@ func (this *Guild) GetMember(playerId int64) *pb.GuildMemberData {
 	return this.GetMembers().Get(playerId)
 }
 
+func (this *Guild) IsDisbanded() bool {
+	return this.disbanded
+}
+
+// 移出公会成员,并清除该玩家的公会id
+//
+//	利用mongodb的原子操作清除玩家的公会id,玩家不在线时也能生效
+//	被踢或公会解散时,通过RoutePlayerPacket(WithSaveDb())通知玩家,玩家不在线时,上线后处理
+func (this *Guild) removeMember(playerId int64, operatorId int64, reason pb.GuildRemoveReason) *pb.GuildMemberData {
+	member := this.GetMember(playerId)
+	if member == nil {
+		return nil
+	}
+	this.GetMembers().Remove(playerId)
+	this.GetProgress().RemoveContribution(playerId)
+	if !game.AtomicSetGuildId(playerId, 0, this.GetId()) {
+		// 玩家的公会id已经不是本公会,说明数据已经不一致了,以移除后的状态为准
+		slog.Warn("removeMember AtomicSetGuildId failed", "gid", this.GetId(), "pid", playerId, "reason", reason)
+	}
+	if reason != pb.GuildRemoveReason_GuildRemoveReason_Leave {
+		game.RoutePlayerPacket(playerId, network.NewPacket(&pb.GuildMemberRemoved{
+			GuildId:    this.GetId(),
+			OperatorId: operatorId,
+			Reason:     int32(reason),
+		}), game.WithSaveDb())
+	}
+	slog.Debug("removeMember", "gid", this.GetId(), "pid", playerId, "operatorId", operatorId, "reason", reason)
+	return member
+}
+
 // 路由玩家消息
 // this server -> other server -> player
 func (this *Guild) RoutePlayerPacket(guildMessage *GuildMessage, cmd any, message proto.Message, opts ...game.RouteOption) {
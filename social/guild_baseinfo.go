@@ -5,6 +5,9 @@ import (
 	"log/slog"
 
 	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gserver/cache"
+	"github.com/fish-tennis/gserver/db"
+	"github.com/fish-tennis/gserver/game"
 	"github.com/fish-tennis/gserver/pb"
 )
 
@@ -39,9 +42,39 @@ func (this *GuildBaseInfo) GetGuild() *Guild {
 	return this.GetEntity().(*Guild)
 }
 
+func (this *GuildBaseInfo) OnDataLoad() {
+	// 成员上限可能修改过
+	if this.Data.MemberLimit != game.GuildMemberMaxCount {
+		this.Data.MemberLimit = game.GuildMemberMaxCount
+		this.SetDirty()
+	}
+	this.updateSummary()
+}
+
 func (this *GuildBaseInfo) SetMemberCount(memberCount int32) {
 	this.Data.MemberCount = memberCount
 	this.SetDirty()
+	this.updateSummary()
+}
+
+// 公会等级,由GuildProgress同步过来,用于公会列表显示
+func (this *GuildBaseInfo) SetLevel(level int32) {
+	this.Data.Level = level
+	this.SetDirty()
+	this.updateSummary()
+}
+
+// 成员是否已满
+func (this *GuildBaseInfo) IsFull() bool {
+	return this.Data.MemberLimit > 0 && this.Data.MemberCount >= this.Data.MemberLimit
+}
+
+// 更新redis中的公会摘要,公会列表和搜索使用
+func (this *GuildBaseInfo) updateSummary() {
+	if this.GetGuild().IsDisbanded() {
+		return
+	}
+	cache.UpdateGuildSummary(this.Data)
 }
 
 func (this *GuildBaseInfo) HandleGuildDataViewReq(guildMessage *GuildMessage, req *pb.GuildDataViewReq) (*pb.GuildDataViewRes, error) {
@@ -53,10 +86,47 @@ func (this *GuildBaseInfo) HandleGuildDataViewReq(guildMessage *GuildMessage, re
 	}
 	return &pb.GuildDataViewRes{
 		GuildData: &pb.GuildData{
-			Id:           g.GetId(),
-			BaseInfo:     g.GetBaseInfo().Data,
-			Members:      g.GetMembers().Data,
-			JoinRequests: g.GetJoinRequests().Data,
+			Id:            g.GetId(),
+			BaseInfo:      g.GetBaseInfo().Data,
+			Members:       g.GetMembers().Data,
+			JoinRequests:  g.GetJoinRequests().Data,
+			Progress:      g.GetProgress().Base.Data,
+			Contributions: g.GetProgress().Contributions.Data,
 		},
 	}, nil
 }
+
+// 解散公会
+//
+//	所有成员的公会id都会被清除,不在线的成员上线后会收到通知
+func (this *GuildBaseInfo) HandleGuildDisbandReq(guildMessage *GuildMessage, req *pb.GuildDisbandReq) (*pb.GuildDisbandRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildDisbandReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId)
+	member := g.GetMember(guildMessage.fromPlayerId)
+	if member == nil {
+		return nil, errors.New("not a member")
+	}
+	if member.Position != int32(pb.GuildPosition_Leader) {
+		return nil, errors.New("not the leader")
+	}
+	memberIds := make([]int64, 0, len(g.GetMembers().Data))
+	for memberId := range g.GetMembers().Data {
+		memberIds = append(memberIds, memberId)
+	}
+	for _, memberId := range memberIds {
+		g.removeMember(memberId, guildMessage.fromPlayerId, pb.GuildRemoveReason_GuildRemoveReason_Disband)
+	}
+	err := db.GetGuildDb().DeleteEntity(g.GetId())
+	if err != nil {
+		// 成员已经全部移除,公会数据残留在数据库中也不会再被使用
+		slog.Error("HandleGuildDisbandReq DeleteEntity error", "gid", g.GetId(), "err", err)
+	}
+	// 标记为已解散,不再保存数据,并结束公会协程
+	g.disbanded = true
+	cache.RemoveGuildSummary(this.Data)
+	g.Stop()
+	slog.Info("GuildDisband", "gid", g.GetId(), "pid", guildMessage.fromPlayerId)
+	return &pb.GuildDisbandRes{
+		GuildId: g.GetId(),
+	}, nil
+}
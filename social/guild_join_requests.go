@@ -3,10 +3,12 @@ package social
 import (
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/fish-tennis/gentity"
 	"github.com/fish-tennis/gentity/util"
 	"github.com/fish-tennis/gserver/game"
+	"github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/network"
 	"github.com/fish-tennis/gserver/pb"
 )
@@ -16,6 +18,20 @@ const (
 	ComponentNameJoinRequests = "JoinRequests"
 )
 
+var (
+	// 清理过期入会申请的间隔
+	GuildJoinRequestCleanInterval = time.Minute
+)
+
+// 公会配置,入会申请的数量上限和有效期在服务器配置文件的Guild里设置
+func getGuildConfig() *internal.GuildConfig {
+	if app, ok := gentity.GetApplication().(interface{ GetConfig() *internal.BaseServerConfig }); ok {
+		return &app.GetConfig().Guild
+	}
+	// 没有服务器配置时(如测试)使用默认值
+	return &internal.GuildConfig{}
+}
+
 // 利用go的init进行组件的自动注册
 func init() {
 	_guildComponentRegister.Register(ComponentNameJoinRequests, 0, func(guild *Guild, _ any) gentity.Component {
@@ -52,6 +68,50 @@ func (this *GuildJoinRequests) Remove(playerId int64) {
 	slog.Debug("Remove request", "playerId", playerId)
 }
 
+// 入会申请是否已过期
+func (this *GuildJoinRequests) isExpired(joinRequest *pb.GuildJoinRequest, now int32) bool {
+	ttl := getGuildConfig().GetJoinRequestTTL()
+	if ttl <= 0 {
+		return false
+	}
+	return int64(joinRequest.TimestampSec)+int64(ttl/time.Second) <= int64(now)
+}
+
+// 清理过期的入会申请,并通知申请者
+//
+//	使用WithSaveDb选项,申请者不在线时,下次上线会收到通知
+func (this *GuildJoinRequests) removeExpired(now int32) {
+	var expiredIds []int64
+	for playerId, joinRequest := range this.Data {
+		if this.isExpired(joinRequest, now) {
+			expiredIds = append(expiredIds, playerId)
+		}
+	}
+	for _, playerId := range expiredIds {
+		this.Remove(playerId)
+		game.RoutePlayerPacket(playerId, network.NewPacket(&pb.GuildJoinReqOpResult{
+			Error:        "JoinRequestExpired",
+			GuildId:      this.GetGuild().GetId(),
+			JoinPlayerId: playerId,
+		}), game.WithSaveDb())
+	}
+	if len(expiredIds) > 0 {
+		slog.Debug("removeExpired", "gid", this.GetGuild().GetId(), "count", len(expiredIds))
+	}
+}
+
+// 开启清理过期入会申请的定时器,在公会协程启动时调用
+func (this *GuildJoinRequests) startCleanTimer() {
+	if getGuildConfig().GetJoinRequestTTL() <= 0 || GuildJoinRequestCleanInterval <= 0 {
+		return
+	}
+	g := this.GetGuild()
+	g.GetTimerEntries().After(GuildJoinRequestCleanInterval, func() time.Duration {
+		this.removeExpired(int32(g.GetTimerEntries().Now().Unix()))
+		return GuildJoinRequestCleanInterval
+	})
+}
+
 // 加入公会请求
 func (this *GuildJoinRequests) HandleGuildJoinReq(guildMessage *GuildMessage, req *pb.GuildJoinReq) (*pb.GuildJoinRes, error) {
 	g := this.GetGuild()
@@ -59,13 +119,24 @@ func (this *GuildJoinRequests) HandleGuildJoinReq(guildMessage *GuildMessage, re
 	if g.GetMember(guildMessage.fromPlayerId) != nil {
 		return nil, errors.New("already a member")
 	}
-	if this.Get(guildMessage.fromPlayerId) != nil {
-		return nil, errors.New("already have a join request")
+	now := int32(util.GetCurrentTimeStamp())
+	if joinRequest := this.Get(guildMessage.fromPlayerId); joinRequest != nil {
+		if !this.isExpired(joinRequest, now) {
+			return nil, errors.New("already have a join request")
+		}
+		// 过期的申请还没来得及清理,直接用新的申请覆盖
+		this.Remove(guildMessage.fromPlayerId)
+	}
+	if g.GetBaseInfo().IsFull() {
+		return nil, errors.New("guild full")
+	}
+	if maxCount := getGuildConfig().GetJoinRequestMaxCount(); maxCount > 0 && len(this.Data) >= maxCount {
+		return nil, errors.New("join request full")
 	}
 	this.Add(&pb.GuildJoinRequest{
 		PlayerId:     guildMessage.fromPlayerId,
 		PlayerName:   guildMessage.fromPlayerName,
-		TimestampSec: int32(util.GetCurrentTimeStamp()),
+		TimestampSec: now,
 	})
 	// 广播公会成员
 	g.BroadcastClientPacket(&pb.GuildJoinReqTip{
@@ -92,10 +163,18 @@ func (this *GuildJoinRequests) HandleGuildJoinAgreeReq(guildMessage *GuildMessag
 	if joinRequest == nil {
 		return nil, errors.New("no joinRequest")
 	}
+	if now := int32(util.GetCurrentTimeStamp()); this.isExpired(joinRequest, now) {
+		// 顺便清理所有过期的申请,并通知申请者
+		this.removeExpired(now)
+		return nil, errors.New("joinRequest expired")
+	}
 	if g.GetMember(req.JoinPlayerId) != nil {
 		return nil, errors.New("already joined")
 	}
 	if req.IsAgree {
+		if g.GetBaseInfo().IsFull() {
+			return nil, errors.New("guild full")
+		}
 		g.GetMembers().Add(&pb.GuildMemberData{
 			Id:       joinRequest.PlayerId,
 			Name:     joinRequest.PlayerName,
@@ -142,3 +221,16 @@ func (this *GuildJoinRequests) HandleGuildJoinAgreeReq(guildMessage *GuildMessag
 		IsAgree:         req.IsAgree,
 	}, nil
 }
+
+// 申请者取消自己的入会申请
+func (this *GuildJoinRequests) HandleGuildJoinCancelReq(guildMessage *GuildMessage, req *pb.GuildJoinCancelReq) (*pb.GuildJoinCancelRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildJoinCancelReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId)
+	if this.Get(guildMessage.fromPlayerId) == nil {
+		return nil, errors.New("no joinRequest")
+	}
+	this.Remove(guildMessage.fromPlayerId)
+	return &pb.GuildJoinCancelRes{
+		GuildId: g.GetId(),
+	}, nil
+}
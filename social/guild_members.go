@@ -1,6 +1,7 @@
 package social
 
 import (
+	"errors"
 	"log/slog"
 
 	"github.com/fish-tennis/gentity"
@@ -49,3 +50,168 @@ func (this *GuildMembers) Remove(playerId int64) {
 	this.GetGuild().GetBaseInfo().SetMemberCount(int32(len(this.Data)))
 	slog.Debug("Remove member", "playerId", playerId)
 }
+
+// 公会聊天
+func (this *GuildMembers) HandleGuildChatReq(guildMessage *GuildMessage, req *pb.GuildChatReq) (*pb.GuildChatRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildChatReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId)
+	if this.Get(guildMessage.fromPlayerId) == nil {
+		return nil, errors.New("not a member")
+	}
+	chatMessage := req.GetMessage()
+	if chatMessage == nil {
+		return nil, errors.New("message nil")
+	}
+	// 发送者以路由信息为准
+	chatMessage.FromPlayerId = guildMessage.fromPlayerId
+	chatMessage.Channel = pb.ChatChannel_ChatChannel_Guild
+	g.BroadcastClientPacket(chatMessage)
+	return &pb.GuildChatRes{}, nil
+}
+
+// 退出公会
+//
+//	会长不能退出,需要先转让会长或解散公会
+func (this *GuildMembers) HandleGuildLeaveReq(guildMessage *GuildMessage, req *pb.GuildLeaveReq) (*pb.GuildLeaveRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildLeaveReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId)
+	member := this.Get(guildMessage.fromPlayerId)
+	if member == nil {
+		return nil, errors.New("not a member")
+	}
+	if member.Position == int32(pb.GuildPosition_Leader) {
+		return nil, errors.New("leader cannot leave")
+	}
+	g.removeMember(member.Id, member.Id, pb.GuildRemoveReason_GuildRemoveReason_Leave)
+	g.BroadcastClientPacket(&pb.GuildMemberUpdate{
+		Member:    member,
+		IsRemoved: true,
+	})
+	return &pb.GuildLeaveRes{
+		GuildId: g.GetId(),
+	}, nil
+}
+
+// 踢出公会成员
+//
+//	只能踢职位比自己低的成员
+func (this *GuildMembers) HandleGuildKickReq(guildMessage *GuildMessage, req *pb.GuildKickReq) (*pb.GuildKickRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildKickReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId, "target", req.PlayerId)
+	member := this.Get(guildMessage.fromPlayerId)
+	if member == nil {
+		return nil, errors.New("not a member")
+	}
+	if member.Position < int32(pb.GuildPosition_Manager) {
+		return nil, errors.New("not a manager")
+	}
+	target := this.Get(req.PlayerId)
+	if target == nil {
+		return nil, errors.New("target not a member")
+	}
+	if target.Position >= member.Position {
+		return nil, errors.New("position not enough")
+	}
+	g.removeMember(target.Id, member.Id, pb.GuildRemoveReason_GuildRemoveReason_Kick)
+	g.BroadcastClientPacket(&pb.GuildMemberUpdate{
+		Member:    target,
+		IsRemoved: true,
+	})
+	return &pb.GuildKickRes{
+		PlayerId: req.PlayerId,
+	}, nil
+}
+
+// 转让会长
+func (this *GuildMembers) HandleGuildTransferLeaderReq(guildMessage *GuildMessage, req *pb.GuildTransferLeaderReq) (*pb.GuildTransferLeaderRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildTransferLeaderReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId, "target", req.PlayerId)
+	member := this.Get(guildMessage.fromPlayerId)
+	if member == nil {
+		return nil, errors.New("not a member")
+	}
+	if member.Position != int32(pb.GuildPosition_Leader) {
+		return nil, errors.New("not the leader")
+	}
+	if req.PlayerId == member.Id {
+		return nil, errors.New("already the leader")
+	}
+	target := this.Get(req.PlayerId)
+	if target == nil {
+		return nil, errors.New("target not a member")
+	}
+	// 原会长降为管理员
+	this.setPosition(member, pb.GuildPosition_Manager)
+	this.setPosition(target, pb.GuildPosition_Leader)
+	return &pb.GuildTransferLeaderRes{
+		PlayerId: req.PlayerId,
+	}, nil
+}
+
+// 设置成员职位
+//
+//	只有会长可以设置,会长职位只能通过转让会长变更
+func (this *GuildMembers) HandleGuildSetPositionReq(guildMessage *GuildMessage, req *pb.GuildSetPositionReq) (*pb.GuildSetPositionRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildSetPositionReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId, "target", req.PlayerId, "position", req.Position)
+	member := this.Get(guildMessage.fromPlayerId)
+	if member == nil {
+		return nil, errors.New("not a member")
+	}
+	if member.Position != int32(pb.GuildPosition_Leader) {
+		return nil, errors.New("not the leader")
+	}
+	if req.Position != int32(pb.GuildPosition_Member) && req.Position != int32(pb.GuildPosition_Manager) {
+		return nil, errors.New("position error")
+	}
+	target := this.Get(req.PlayerId)
+	if target == nil {
+		return nil, errors.New("target not a member")
+	}
+	if target.Id == member.Id {
+		return nil, errors.New("cannot set self")
+	}
+	this.setPosition(target, pb.GuildPosition(req.Position))
+	return &pb.GuildSetPositionRes{
+		PlayerId: req.PlayerId,
+		Position: req.Position,
+	}, nil
+}
+
+// 设置职位,并广播给公会成员
+func (this *GuildMembers) setPosition(member *pb.GuildMemberData, position pb.GuildPosition) {
+	if member.Position == int32(position) {
+		return
+	}
+	member.Position = int32(position)
+	this.Set(member.Id, member)
+	this.GetGuild().BroadcastClientPacket(&pb.GuildMemberUpdate{
+		Member: member,
+	})
+	slog.Debug("setPosition", "gid", this.GetGuild().GetId(), "pid", member.Id, "position", position)
+}
+
+// 成员改名后同步成员数据里的名字
+func (this *GuildMembers) HandleGuildMemberRenameReq(guildMessage *GuildMessage, req *pb.GuildMemberRenameReq) (*pb.GuildMemberRenameRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildMemberRenameReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId, "name", guildMessage.fromPlayerName)
+	member := this.Get(guildMessage.fromPlayerId)
+	if member == nil {
+		return nil, errors.New("not a member")
+	}
+	this.syncMemberName(guildMessage)
+	return &pb.GuildMemberRenameRes{}, nil
+}
+
+// 路由消息里带的玩家名和成员数据里的不一致时,更新成员名字并广播
+func (this *GuildMembers) syncMemberName(guildMessage *GuildMessage) {
+	member := this.Get(guildMessage.fromPlayerId)
+	if member == nil || guildMessage.fromPlayerName == "" || member.Name == guildMessage.fromPlayerName {
+		return
+	}
+	member.Name = guildMessage.fromPlayerName
+	this.Set(member.Id, member)
+	this.GetGuild().BroadcastClientPacket(&pb.GuildMemberUpdate{
+		Member: member,
+	})
+}
@@ -1,6 +1,7 @@
 package social
 
 import (
+	"context"
 	"log/slog"
 	"reflect"
 	"time"
@@ -12,6 +13,8 @@ import (
 	"github.com/fish-tennis/gserver/game"
 	. "github.com/fish-tennis/gserver/internal"
 	"github.com/fish-tennis/gserver/pb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // 公会功能演示:
@@ -86,6 +89,12 @@ func (g *GuildHelper) RoutePacketToRoutineMessage(connection Connection, packet
 
 func initGuildMgr() {
 	routineArgs := &gentity.RoutineEntityRoutineArgs{
+		InitFunc: func(routineEntity gentity.RoutineEntity) {
+			// 公会协程启动时,开启公会的定时器
+			if guild, ok := routineEntity.(*Guild); ok {
+				guild.GetJoinRequests().startCleanTimer()
+			}
+		},
 		ProcessMessageFunc: func(routineEntity gentity.RoutineEntity, routineMessage any) {
 			guildMessage, ok := routineMessage.(*GuildMessage)
 			if !ok {
@@ -98,12 +107,19 @@ func initGuildMgr() {
 				return
 			}
 			guild.processMessage(guildMessage)
+			// 已解散的公会,数据已从数据库删除,不能再保存
+			if guild.IsDisbanded() {
+				return
+			}
 			//this.SaveCache()
 			// 这里演示一种直接保存数据库的用法,可以用于那些不经常修改的数据
 			// 这种方式,省去了要处理crash后从缓存恢复数据的步骤
 			gentity.SaveEntityChangedDataToDb(_guildMgr.GetEntityDb(), routineEntity, cache.Get(), false, "")
 		},
 		AfterTimerExecuteFunc: func(routineEntity gentity.RoutineEntity, t time.Time) {
+			if guild, ok := routineEntity.(*Guild); ok && guild.IsDisbanded() {
+				return
+			}
 			gentity.SaveEntityChangedDataToDb(_guildMgr.GetEntityDb(), routineEntity, cache.Get(), false, "")
 		},
 	}
@@ -129,3 +145,40 @@ func GetGuildById(guildId int64) *Guild {
 func onServerListUpdate(serverList map[string][]*pb.ServerInfo, oldServerList map[string][]*pb.ServerInfo) {
 	_guildMgr.ReBalance()
 }
+
+// 公会摘要缓存为空时(如首次部署或redis数据丢失),从数据库重建
+//
+//	多个服务器同时重建也没关系,写入的数据是一样的
+func initGuildSummary() {
+	count, err := cache.GetGuildSummaryCount()
+	if err != nil || count > 0 {
+		return
+	}
+	col := db.GetGuildDb().(*gentity.MongoCollection).GetCollection()
+	cursor, err := col.Find(context.Background(), bson.D{}, options.Find().SetProjection(bson.D{{"baseinfo", 1}}))
+	if err != nil {
+		slog.Error("initGuildSummary db error", "error", err)
+		return
+	}
+	defer cursor.Close(context.Background())
+	type guildBaseInfo struct {
+		BaseInfo *pb.GuildInfo `json:"baseinfo"`
+	}
+	guildCount := 0
+	for cursor.Next(context.Background()) {
+		info := &guildBaseInfo{}
+		if err = cursor.Decode(info); err != nil {
+			slog.Error("initGuildSummary decode error", "error", err)
+			continue
+		}
+		if info.BaseInfo == nil || info.BaseInfo.Id == 0 {
+			continue
+		}
+		if info.BaseInfo.Level <= 0 {
+			info.BaseInfo.Level = 1
+		}
+		cache.UpdateGuildSummary(info.BaseInfo)
+		guildCount++
+	}
+	slog.Info("initGuildSummary", "guildCount", guildCount)
+}
@@ -0,0 +1,199 @@
+package social
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+
+	"github.com/fish-tennis/gentity"
+	"github.com/fish-tennis/gentity/util"
+	"github.com/fish-tennis/gserver/cfg"
+	"github.com/fish-tennis/gserver/internal"
+	"github.com/fish-tennis/gserver/pb"
+)
+
+const (
+	// 组件名
+	ComponentNameProgress = "Progress"
+)
+
+// 利用go的init进行组件的自动注册
+func init() {
+	_guildComponentRegister.Register(ComponentNameProgress, 0, func(guild *Guild, _ any) gentity.Component {
+		return &GuildProgress{
+			BaseComponent: *gentity.NewBaseComponent(guild, ComponentNameProgress),
+			Base: gentity.NewProtoData(&pb.GuildProgressData{
+				Level: 1,
+			}),
+			Contributions: gentity.NewMapData[int64, *pb.GuildContribution](),
+		}
+	})
+	// 公会条件只能在公会所在服务器检查,obj是*guildMemberProperty
+	internal.RegisterConditionChecker(int32(pb.ConditionType_ConditionType_GuildPropertyCompare),
+		internal.DefaultPropertyInt32Checker)
+}
+
+// 公会等级经验和成员贡献
+type GuildProgress struct {
+	gentity.BaseComponent
+	// 保存数据的子模块:等级经验
+	Base *gentity.ProtoData[*pb.GuildProgressData] `child:"Base;plain"`
+	// 保存数据的子模块:成员的贡献记录
+	Contributions *gentity.MapData[int64, *pb.GuildContribution] `child:"Contributions;plain"`
+}
+
+func (g *Guild) GetProgress() *GuildProgress {
+	return g.GetComponentByName(ComponentNameProgress).(*GuildProgress)
+}
+
+func (this *GuildProgress) GetGuild() *Guild {
+	return this.GetEntity().(*Guild)
+}
+
+func (this *GuildProgress) OnDataLoad() {
+	// 兼容没有等级数据的旧公会
+	if this.Base.Data.Level <= 0 {
+		this.Base.Data.Level = 1
+		this.Base.SetDirty()
+	}
+	baseInfo := this.GetGuild().GetBaseInfo()
+	if baseInfo.Data.Level != this.Base.Data.Level {
+		baseInfo.SetLevel(this.Base.Data.Level)
+	}
+}
+
+func (this *GuildProgress) GetLevel() int32 {
+	return this.Base.Data.Level
+}
+
+// 获取成员的累计贡献值
+func (this *GuildProgress) GetContribution(playerId int64) int32 {
+	return this.Contributions.Data[playerId].GetTotal()
+}
+
+// 增加公会经验,经验足够时自动升级
+func (this *GuildProgress) AddExp(incExp int32) {
+	if incExp <= 0 {
+		return
+	}
+	data := this.Base.Data
+	oldLevel := data.Level
+	// 用 int64 运算防止累加溢出
+	newExp := int64(data.Exp) + int64(incExp)
+	if newExp > math.MaxInt32 {
+		newExp = math.MaxInt32
+	}
+	data.Exp = int32(newExp)
+	cfgs := cfg.Get()
+	for data.Level < cfgs.GuildMaxLevel {
+		needExp := cfgs.GetGuildNeedExp(data.Level + 1)
+		if needExp <= 0 || data.Exp < needExp {
+			break
+		}
+		data.Level++
+		data.Exp -= needExp
+	}
+	this.Base.SetDirty()
+	if oldLevel != data.Level {
+		this.GetGuild().GetBaseInfo().SetLevel(data.Level)
+		slog.Info("GuildLevelup", "gid", this.GetGuild().GetId(), "oldLevel", oldLevel, "level", data.Level)
+	}
+	slog.Debug("GuildProgress.AddExp", "gid", this.GetGuild().GetId(), "incExp", incExp, "exp", data.Exp, "level", data.Level)
+}
+
+// 增加成员的贡献值
+func (this *GuildProgress) AddContribution(playerId int64, contribution int32) *pb.GuildContribution {
+	record, ok := this.Contributions.Get(playerId)
+	if !ok {
+		record = &pb.GuildContribution{
+			PlayerId: playerId,
+		}
+	}
+	newTotal := int64(record.Total) + int64(contribution)
+	if newTotal > math.MaxInt32 {
+		newTotal = math.MaxInt32
+	}
+	record.Total = int32(newTotal)
+	record.DonateCount++
+	record.LastDonateTime = int32(util.GetCurrentTimeStamp())
+	this.Contributions.Set(playerId, record)
+	return record
+}
+
+// 删除成员的贡献记录
+func (this *GuildProgress) RemoveContribution(playerId int64) {
+	if this.Contributions.Contains(playerId) {
+		this.Contributions.Delete(playerId)
+	}
+}
+
+// 公会捐献
+//
+//	玩家所在服务器已经扣除了捐献消耗,这里只增加公会经验和成员贡献
+func (this *GuildProgress) HandleGuildDonateReq(guildMessage *GuildMessage, req *pb.GuildDonateReq) (*pb.GuildDonateRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildDonateReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId, "donateCfgId", req.DonateCfgId)
+	if g.GetMember(guildMessage.fromPlayerId) == nil {
+		return nil, errors.New("not a member")
+	}
+	donateCfg := cfg.Get().GuildDonateCfgs.GetCfg(req.DonateCfgId)
+	if donateCfg == nil {
+		return nil, errors.New("donateCfg nil")
+	}
+	this.AddExp(donateCfg.GetExp())
+	contribution := this.AddContribution(guildMessage.fromPlayerId, donateCfg.GetContribution())
+	g.BroadcastClientPacket(&pb.GuildProgressUpdate{
+		Progress: this.Base.Data,
+	})
+	return &pb.GuildDonateRes{
+		DonateCfgId:  req.DonateCfgId,
+		Progress:     this.Base.Data,
+		Contribution: contribution,
+	}, nil
+}
+
+// 公会商店购买前,检查公会条件
+//
+//	检查通过后,由玩家所在服务器扣除消耗和发放物品
+func (this *GuildProgress) HandleGuildShopBuyReq(guildMessage *GuildMessage, req *pb.GuildShopBuyReq) (*pb.GuildShopBuyRes, error) {
+	g := this.GetGuild()
+	slog.Debug("HandleGuildShopBuyReq", "gid", g.GetId(), "pid", guildMessage.fromPlayerId, "exchangeCfgId", req.ExchangeCfgId)
+	if g.GetMember(guildMessage.fromPlayerId) == nil {
+		return nil, errors.New("not a member")
+	}
+	exchangeCfg := cfg.Get().ExchangeCfgs.GetCfg(req.ExchangeCfgId)
+	if exchangeCfg == nil || exchangeCfg.GetCategory() != int32(pb.ExchangeCategory_ExchangeCategory_GuildShop) {
+		return nil, errors.New("exchangeCfg nil")
+	}
+	obj := &guildMemberProperty{
+		guild:    g,
+		playerId: guildMessage.fromPlayerId,
+	}
+	if !internal.CheckConditions(obj, exchangeCfg.GetGuildConditions()) {
+		return nil, errors.New("conditions err")
+	}
+	return &pb.GuildShopBuyRes{
+		ExchangeCfgId: req.ExchangeCfgId,
+		Count:         req.Count,
+	}, nil
+}
+
+// 公会条件检查的对象,可以获取公会属性和成员在公会中的属性
+type guildMemberProperty struct {
+	guild    *Guild
+	playerId int64
+}
+
+// 新增属性名时,需要同步修改配置检查里的guildConditionKeys
+func (p *guildMemberProperty) GetPropertyInt32(propertyName string, conditionCfg *pb.ConditionCfg) int32 {
+	switch propertyName {
+	case "Level":
+		return p.guild.GetProgress().GetLevel()
+	case "Contribution":
+		return p.guild.GetProgress().GetContribution(p.playerId)
+	case "MemberCount":
+		return int32(len(p.guild.GetMembers().Data))
+	}
+	slog.Error("Not support guild property", "gid", p.guild.GetId(), "propertyName", propertyName)
+	return 0
+}
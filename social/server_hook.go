@@ -17,6 +17,7 @@ func (h *Hook) OnRegisterServerHandler(arg any) {
 // 服务器初始化回调
 func (h *Hook) OnApplicationInit(initArg interface{}) {
 	initGuildMgr()
+	initGuildSummary()
 	// 服务器列表更新回调
 	GetServerList().AddListUpdateHook(onServerListUpdate)
 	// 服务器非正常关闭可能导致分布式锁没能释放(如crash),所以服务器启动时,进行自动修复
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"runtime"
+	"time"
+)
+
+// CpuLoadSampler 进程的cpu使用率采样
+// 两次采样之间,进程使用的cpu时间 / (经过的时间 * cpu核数),用千分比表示
+// 非并发安全,需要在同一个协程中定时调用Sample
+type CpuLoadSampler struct {
+	lastCpuTime    time.Duration
+	lastSampleTime time.Time
+}
+
+// Sample 返回距离上次采样期间的cpu使用率(千分比),第一次采样返回0
+func (s *CpuLoadSampler) Sample() int32 {
+	cpuTime := getProcessCpuTime()
+	now := time.Now()
+	var load int64
+	if !s.lastSampleTime.IsZero() {
+		elapsed := now.Sub(s.lastSampleTime) * time.Duration(runtime.NumCPU())
+		if elapsed > 0 {
+			load = int64((cpuTime - s.lastCpuTime) * 1000 / elapsed)
+		}
+	}
+	s.lastCpuTime = cpuTime
+	s.lastSampleTime = now
+	return int32(max(0, min(load, 1000)))
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package util
+
+import (
+	"syscall"
+	"time"
+)
+
+// 进程累计使用的cpu时间(用户态+内核态)
+func getProcessCpuTime() time.Duration {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+}
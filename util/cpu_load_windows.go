@@ -0,0 +1,23 @@
+package util
+
+import (
+	"syscall"
+	"time"
+)
+
+// 进程累计使用的cpu时间(用户态+内核态)
+func getProcessCpuTime() time.Duration {
+	var creationTime, exitTime, kernelTime, userTime syscall.Filetime
+	handle, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0
+	}
+	if err = syscall.GetProcessTimes(handle, &creationTime, &exitTime, &kernelTime, &userTime); err != nil {
+		return 0
+	}
+	// Filetime的单位是100纳秒
+	toDuration := func(ft syscall.Filetime) time.Duration {
+		return time.Duration((int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)) * 100)
+	}
+	return toDuration(kernelTime) + toDuration(userTime)
+}
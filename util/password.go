@@ -0,0 +1,88 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// 密码hash的参数(argon2id),修改参数后,旧的hash在下次登录成功时自动更新
+var (
+	PasswordHashMemory  uint32 = 19 * 1024 // KiB
+	PasswordHashTime    uint32 = 2
+	PasswordHashThreads uint8  = 1
+	PasswordSaltLength         = 16
+	PasswordKeyLength   uint32 = 32
+)
+
+// 验证时允许的hash参数范围,防止异常的hash数据导致panic或者消耗大量内存和cpu
+var (
+	PasswordHashMaxMemory uint32 = 1024 * 1024 // KiB
+	PasswordHashMaxTime   uint32 = 16
+)
+
+const passwordHashPrefix = "$argon2id$"
+
+var ErrInvalidPasswordHash = errors.New("invalid password hash")
+
+// 加盐hash密码,返回的格式: $argon2id$v=19$m=19456,t=2,p=1$盐$hash
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, PasswordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, PasswordHashTime, PasswordHashMemory, PasswordHashThreads, PasswordKeyLength)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", passwordHashPrefix, argon2.Version,
+		PasswordHashMemory, PasswordHashTime, PasswordHashThreads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// 是否是HashPassword生成的hash,旧的账号数据存的是客户端发来的原始值
+func IsPasswordHashed(encoded string) bool {
+	return strings.HasPrefix(encoded, passwordHashPrefix)
+}
+
+// 验证密码
+//
+//	needRehash表示hash参数和当前的不一致,验证成功后应该重新hash
+func VerifyPassword(password, encoded string) (ok bool, needRehash bool, err error) {
+	parts := strings.Split(encoded, "$")
+	// "" "argon2id" "v=19" "m=19456,t=2,p=1" salt key
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, false, ErrInvalidPasswordHash
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, ErrInvalidPasswordHash
+	}
+	var memory, time uint32
+	var threads uint8
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	// argon2.IDKey在t<1或p<1时会panic
+	if time < 1 || time > PasswordHashMaxTime || threads < 1 ||
+		memory < 8*uint32(threads) || memory > PasswordHashMaxMemory {
+		return false, false, ErrInvalidPasswordHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, ErrInvalidPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false, false, ErrInvalidPasswordHash
+	}
+	otherKey := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return false, false, nil
+	}
+	needRehash = memory != PasswordHashMemory || time != PasswordHashTime || threads != PasswordHashThreads ||
+		uint32(len(key)) != PasswordKeyLength || len(salt) != PasswordSaltLength
+	return true, needRehash, nil
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestHashPassword(t *testing.T) {
+	encoded, err := HashPassword("123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsPasswordHashed(encoded) {
+		t.Fatalf("not hashed:%v", encoded)
+	}
+	// 每次的盐不同
+	if encoded2, _ := HashPassword("123456"); encoded2 == encoded {
+		t.Errorf("same hash:%v", encoded)
+	}
+	if ok, needRehash, err := VerifyPassword("123456", encoded); !ok || needRehash || err != nil {
+		t.Errorf("verify failed ok:%v needRehash:%v err:%v", ok, needRehash, err)
+	}
+	if ok, _, _ := VerifyPassword("1234567", encoded); ok {
+		t.Errorf("wrong password verified")
+	}
+	if _, _, err := VerifyPassword("123456", "123456"); err == nil {
+		t.Errorf("invalid hash verified")
+	}
+	// hash参数异常
+	for _, invalid := range []string{
+		"$argon2id$v=19$m=19456,t=0,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+		"$argon2id$v=19$m=19456,t=2,p=0$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+		"$argon2id$v=19$m=0,t=2,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+		"$argon2id$v=19$m=4294967295,t=2,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+	} {
+		if ok, _, err := VerifyPassword("123456", invalid); ok || err == nil {
+			t.Errorf("invalid params verified:%v", invalid)
+		}
+	}
+	// 修改参数后,旧的hash需要更新
+	PasswordHashTime++
+	defer func() { PasswordHashTime-- }()
+	if ok, needRehash, _ := VerifyPassword("123456", encoded); !ok || !needRehash {
+		t.Errorf("verify failed ok:%v needRehash:%v", ok, needRehash)
+	}
+}